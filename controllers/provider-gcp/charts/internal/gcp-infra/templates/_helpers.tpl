@@ -0,0 +1,35 @@
+{{- define "gcp-infra.timeouts" -}}
+{{- if .Values.timeouts }}
+
+  timeouts {
+{{- if .Values.timeouts.create }}
+    create = "{{ .Values.timeouts.create }}"
+{{- end }}
+{{- if .Values.timeouts.update }}
+    update = "{{ .Values.timeouts.update }}"
+{{- end }}
+{{- if .Values.timeouts.delete }}
+    delete = "{{ .Values.timeouts.delete }}"
+{{- end }}
+  }
+{{- end }}
+{{- end -}}
+
+{{- define "gcp-infra.cloud-nat-ports" -}}
+{{- if .minPortsPerVM }}
+  min_ports_per_vm                   = {{ .minPortsPerVM }}
+{{- end }}
+{{- if .maxPortsPerVM }}
+  max_ports_per_vm                   = {{ .maxPortsPerVM }}
+{{- end }}
+{{- if hasKey . "enableDynamicPortAllocation" }}
+  enable_dynamic_port_allocation     = {{ .enableDynamicPortAllocation }}
+{{- end }}
+{{- end -}}
+
+{{- define "gcp-infra.depends-on-network" -}}
+{{- if .Values.create.vpc }}
+
+  depends_on = ["google_compute_network.network"]
+{{- end }}
+{{- end -}}
@@ -2,16 +2,37 @@ provider "google" {
   credentials = "${var.SERVICEACCOUNT}"
   project     = "{{ required "google.project is required" .Values.google.project }}"
   region      = "{{ required "google.region is required" .Values.google.region }}"
+{{- if .Values.google.requestTimeout }}
+
+  request_timeout = "{{ .Values.google.requestTimeout }}"
+{{- end }}
 }
 
 //=====================================================================
 //= Service Account
 //=====================================================================
 
+{{ if .Values.create.serviceAccount -}}
 resource "google_service_account" "serviceaccount" {
-  account_id   = "{{ required "clusterName is required" .Values.clusterName }}"
-  display_name = "{{ required "clusterName is required" .Values.clusterName }}"
+  account_id   = "{{ required "resourceNames.serviceAccount is required" .Values.resourceNames.serviceAccount }}"
+  display_name = "{{ required "resourceNames.serviceAccount is required" .Values.resourceNames.serviceAccount }}"
+{{- if .Values.serviceAccount }}
+  project      = "{{ required "serviceAccount.project is required" .Values.serviceAccount.project }}"
+{{- end }}
 }
+{{- if .Values.workloadIdentity }}
+{{- range $i, $member := .Values.workloadIdentity.members }}
+
+resource "google_service_account_iam_member" "workload-identity-user-{{ $i }}" {
+  service_account_id = "${google_service_account.serviceaccount.name}"
+  role               = "roles/iam.workloadIdentityUser"
+  member             = "{{ $member }}"
+
+  depends_on = ["google_service_account.serviceaccount"]
+}
+{{- end }}
+{{- end }}
+{{- end }}
 
 //=====================================================================
 //= Networks
@@ -19,34 +40,359 @@ resource "google_service_account" "serviceaccount" {
 
 {{ if .Values.create.vpc -}}
 resource "google_compute_network" "network" {
-  name                    = "{{ required "clusterName is required" .Values.clusterName }}"
+  name                    = "{{ required "resourceNames.network is required" .Values.resourceNames.network }}"
   auto_create_subnetworks = "false"
+{{- if .Values.vpc.description }}
+  description             = {{ .Values.vpc.description | quote }}
+{{- end }}
+{{- if .Values.networks.mtu }}
+  mtu                     = {{ .Values.networks.mtu }}
+{{- end }}
+{{- include "gcp-infra.timeouts" . }}
 }
+{{- if .Values.networks.controlPlanePeering }}
+
+resource "google_compute_global_address" "control-plane-peering" {
+  name          = "{{ required "resourceNames.controlPlanePeering is required" .Values.resourceNames.controlPlanePeering }}"
+  purpose       = "VPC_PEERING"
+  address_type  = "INTERNAL"
+  address       = "{{ required "networks.controlPlanePeering.address is required" .Values.networks.controlPlanePeering.address }}"
+  prefix_length = {{ required "networks.controlPlanePeering.prefixLength is required" .Values.networks.controlPlanePeering.prefixLength }}
+  network       = "${google_compute_network.network.self_link}"
+}
+
+resource "google_service_networking_connection" "control-plane-peering" {
+  network                 = "${google_compute_network.network.self_link}"
+  service                 = "servicenetworking.googleapis.com"
+  reserved_peering_ranges = ["${google_compute_global_address.control-plane-peering.name}"]
+}
+{{- if .Values.networks.controlPlanePeering.routes }}
+
+resource "google_compute_network_peering_routes_config" "control-plane-peering" {
+  peering              = "${google_service_networking_connection.control-plane-peering.peering}"
+  network              = "${google_compute_network.network.name}"
+  import_custom_routes = {{ .Values.networks.controlPlanePeering.routes.importCustomRoutes }}
+  export_custom_routes = {{ .Values.networks.controlPlanePeering.routes.exportCustomRoutes }}
+}
+{{- end }}
+{{- end }}
 {{- end}}
 
+{{ if .Values.create.subnets -}}
 resource "google_compute_subnetwork" "subnetwork-nodes" {
-  name          = "{{ required "clusterName is required" .Values.clusterName }}-nodes"
+  name          = "{{ required "resourceNames.subnetNodes is required" .Values.resourceNames.subnetNodes }}"
   ip_cidr_range = "{{ required "networks.worker is required" .Values.networks.worker }}"
   network       = "{{ required "vpc.name is required" .Values.vpc.name }}"
   region        = "{{ required "google.region is required" .Values.google.region }}"
+{{- if .Values.networks.privateGoogleAccess }}
+  private_ip_google_access = true
+{{- end }}
+{{- if .Values.aliasIPs.enabled }}
+
+  secondary_ip_range {
+    range_name    = "{{ required "resourceNames.podsRange is required" .Values.resourceNames.podsRange }}"
+    ip_cidr_range = "{{ required "networks.pods is required" .Values.networks.pods }}"
+  }
+{{- end }}
+{{- if or .Values.aliasIPs.enabled .Values.cilium.enabled }}
+
+  secondary_ip_range {
+    range_name    = "{{ required "resourceNames.servicesRange is required" .Values.resourceNames.servicesRange }}"
+    ip_cidr_range = "{{ required "networks.services is required" .Values.networks.services }}"
+  }
+{{- end }}
+{{- if .Values.flowLogs.enabled }}
+
+  log_config {
+{{- if .Values.flowLogs.aggregationInterval }}
+    aggregation_interval = "{{ .Values.flowLogs.aggregationInterval }}"
+{{- end }}
+  }
+{{- end }}
+{{- include "gcp-infra.depends-on-network" . }}
+{{- include "gcp-infra.timeouts" . }}
+}
+{{- if .Values.flowLogs.exportDestination }}
+
+resource "google_logging_project_sink" "flow-logs" {
+  name                   = "{{ required "resourceNames.flowLogsSink is required" .Values.resourceNames.flowLogsSink }}"
+  destination            = "{{ .Values.flowLogs.exportDestination }}"
+  filter                 = "resource.type=\"gce_subnetwork\" AND log_id(\"compute.googleapis.com/vpc_flows\") AND resource.labels.subnetwork_name=\"${google_compute_subnetwork.subnetwork-nodes.name}\"{{ if .Values.flowLogs.nodesExportFilter }} AND ({{ .Values.flowLogs.nodesExportFilter }}){{ end }}"
+  unique_writer_identity = true
+{{- if .Values.flowLogs.bigQueryPartitionedTables }}
+
+  bigquery_options {
+    use_partitioned_tables = true
+  }
+{{- end }}
 }
+{{- end }}
 
 {{ if .Values.networks.internal -}}
 resource "google_compute_subnetwork" "subnetwork-internal" {
-  name          = "{{ required "clusterName is required" .Values.clusterName }}-internal"
+  name          = "{{ required "resourceNames.subnetInternal is required" .Values.resourceNames.subnetInternal }}"
   ip_cidr_range = "{{ required "networks.internal is required" .Values.networks.internal }}"
   network       = "{{ required "vpc.name is required" .Values.vpc.name }}"
   region        = "{{ required "google.region is required" .Values.google.region }}"
+{{- if and .Values.networks.privateGoogleAccess (not .Values.networks.internalProxyOnly) }}
+  private_ip_google_access = true
+{{- end }}
+{{- if .Values.networks.internalProxyOnly }}
+  purpose       = "INTERNAL_HTTPS_LOAD_BALANCER"
+  role          = "ACTIVE"
+{{- end }}
+{{- if .Values.flowLogs.internalExportFilter }}
+
+  log_config {
+{{- if .Values.flowLogs.aggregationInterval }}
+    aggregation_interval = "{{ .Values.flowLogs.aggregationInterval }}"
+{{- end }}
+  }
+{{- end }}
+{{- include "gcp-infra.depends-on-network" . }}
+{{- include "gcp-infra.timeouts" . }}
+}
+{{- if .Values.flowLogs.internalExportFilter }}
+
+resource "google_logging_project_sink" "flow-logs-internal" {
+  name                   = "{{ required "resourceNames.flowLogsSinkInternal is required" .Values.resourceNames.flowLogsSinkInternal }}"
+  destination            = "{{ required "flowLogs.exportDestination is required" .Values.flowLogs.exportDestination }}"
+  filter                 = "resource.type=\"gce_subnetwork\" AND log_id(\"compute.googleapis.com/vpc_flows\") AND resource.labels.subnetwork_name=\"${google_compute_subnetwork.subnetwork-internal.name}\" AND ({{ .Values.flowLogs.internalExportFilter }})"
+  unique_writer_identity = true
+{{- if .Values.flowLogs.bigQueryPartitionedTables }}
+
+  bigquery_options {
+    use_partitioned_tables = true
+  }
+{{- end }}
+}
+{{- end }}
+{{- range $i, $address := .Values.internalAddresses }}
+
+resource "google_compute_address" "internal-address-{{ $i }}" {
+  name         = "{{ required "internalAddresses[].resourceName is required" $address.resourceName }}"
+  region       = "{{ required "google.region is required" $.Values.google.region }}"
+  subnetwork   = "${google_compute_subnetwork.subnetwork-internal.self_link}"
+  address_type = "INTERNAL"
+}
+{{- end }}
+{{- end}}
+{{- if .Values.networks.proxyOnly }}
+
+resource "google_compute_subnetwork" "subnetwork-proxy-only" {
+  name          = "{{ required "resourceNames.subnetProxyOnly is required" .Values.resourceNames.subnetProxyOnly }}"
+  ip_cidr_range = "{{ required "networks.proxyOnly.cidr is required" .Values.networks.proxyOnly.cidr }}"
+  network       = "{{ required "vpc.name is required" .Values.vpc.name }}"
+  region        = "{{ required "google.region is required" .Values.google.region }}"
+  purpose       = "INTERNAL_HTTPS_LOAD_BALANCER"
+  role          = "{{ required "networks.proxyOnly.role is required" .Values.networks.proxyOnly.role }}"
+{{- include "gcp-infra.depends-on-network" . }}
+{{- include "gcp-infra.timeouts" . }}
 }
 {{- end}}
+{{- if .Values.networks.privateServiceConnect }}
+
+resource "google_compute_subnetwork" "subnetwork-psc" {
+  name          = "{{ required "resourceNames.subnetPSC is required" .Values.resourceNames.subnetPSC }}"
+  ip_cidr_range = "{{ .Values.networks.privateServiceConnect }}"
+  network       = "{{ required "vpc.name is required" .Values.vpc.name }}"
+  region        = "{{ required "google.region is required" .Values.google.region }}"
+  purpose       = "PRIVATE_SERVICE_CONNECT"
+{{- include "gcp-infra.depends-on-network" . }}
+{{- include "gcp-infra.timeouts" . }}
+}
+{{- end}}
+{{- if .Values.networks.disasterRecovery }}
+
+resource "google_compute_subnetwork" "subnetwork-disaster-recovery" {
+  name          = "{{ required "resourceNames.subnetDisasterRecovery is required" .Values.resourceNames.subnetDisasterRecovery }}"
+  ip_cidr_range = "{{ required "networks.disasterRecovery.cidr is required" .Values.networks.disasterRecovery.cidr }}"
+  network       = "{{ required "vpc.name is required" .Values.vpc.name }}"
+  region        = "{{ required "networks.disasterRecovery.region is required" .Values.networks.disasterRecovery.region }}"
+{{- if .Values.networks.privateGoogleAccess }}
+  private_ip_google_access = true
+{{- end }}
+{{- include "gcp-infra.depends-on-network" . }}
+{{- include "gcp-infra.timeouts" . }}
+}
+{{- end}}
+{{- if .Values.networks.egressAppliance }}
+
+resource "google_compute_subnetwork" "subnetwork-egress-appliance" {
+  name          = "{{ required "resourceNames.subnetEgressAppliance is required" .Values.resourceNames.subnetEgressAppliance }}"
+  ip_cidr_range = "{{ required "networks.egressAppliance.cidr is required" .Values.networks.egressAppliance.cidr }}"
+  network       = "{{ required "vpc.name is required" .Values.vpc.name }}"
+  region        = "{{ required "google.region is required" .Values.google.region }}"
+{{- include "gcp-infra.depends-on-network" . }}
+{{- include "gcp-infra.timeouts" . }}
+}
+{{- end}}
+{{- end}}
+{{- if .Values.create.cloudRouter }}
+
+resource "google_compute_router" "router" {
+  name    = "{{ required "resourceNames.cloudRouter is required" .Values.resourceNames.cloudRouter }}"
+  region  = "{{ required "google.region is required" .Values.google.region }}"
+  network = "{{ required "vpc.name is required" .Values.vpc.name }}"
+{{- with .Values.cloudRouter.bgp }}
+
+  bgp {
+    asn               = {{ required "cloudRouter.bgp.asn is required" .asn }}
+    advertise_mode    = "{{ required "cloudRouter.bgp.advertiseMode is required" .advertiseMode }}"
+{{- if .advertiseAllSubnets }}
+    advertised_groups = ["ALL_SUBNETS"]
+{{- end }}
+{{- range .advertisedIPRanges }}
+
+    advertised_ip_ranges {
+      range = "{{ . }}"
+    }
+{{- end }}
+  }
+{{- end }}
+{{- include "gcp-infra.depends-on-network" . }}
+{{- include "gcp-infra.timeouts" . }}
+}
+{{- end }}
+{{- if .Values.cloudNAT.enabled }}
+{{- range $i, $address := required "cloudNAT.addresses is required" .Values.cloudNAT.addresses }}
+
+resource "google_compute_address" "nat-address-{{ $i }}" {
+  name         = "{{ required "cloudNAT.addresses[].resourceName is required" $address.resourceName }}"
+  region       = "{{ required "google.region is required" $.Values.google.region }}"
+  network_tier = "{{ required "cloudNAT.addresses[].networkTier is required" $address.networkTier }}"
+}
+{{- end }}
+
+resource "google_compute_router_nat" "nat" {
+  name                               = "{{ required "resourceNames.cloudNAT is required" .Values.resourceNames.cloudNAT }}"
+  router                             = "{{ required "cloudNAT.routerName is required" .Values.cloudNAT.routerName }}"
+  region                             = "{{ required "google.region is required" .Values.google.region }}"
+  nat_ip_allocate_option             = "MANUAL_ONLY"
+  nat_ips                            = [{{ range $i, $address := .Values.cloudNAT.addresses }}{{ if $i }}, {{ end }}"${google_compute_address.nat-address-{{ $i }}.self_link}"{{ end }}]
+  source_subnetwork_ip_ranges_to_nat = "LIST_OF_SUBNETWORKS"
+{{- include "gcp-infra.cloud-nat-ports" .Values.cloudNAT }}
+{{- range required "cloudNAT.subnets is required" .Values.cloudNAT.subnets }}
+
+  subnetwork {
+    name                    = "${google_compute_subnetwork.subnetwork-{{ . }}.self_link}"
+    source_ip_ranges_to_nat = ["ALL_IP_RANGES"]
+  }
+{{- end }}
+{{- include "gcp-infra.timeouts" . }}
+}
+{{- range $gateway := .Values.cloudNAT.gateways }}
+{{- range $i, $address := required "cloudNAT.gateways[].addresses is required" $gateway.addresses }}
+
+resource "google_compute_address" "nat-{{ $gateway.name }}-address-{{ $i }}" {
+  name         = "{{ required "cloudNAT.gateways[].addresses[].resourceName is required" $address.resourceName }}"
+  region       = "{{ required "google.region is required" $.Values.google.region }}"
+  network_tier = "{{ required "cloudNAT.gateways[].addresses[].networkTier is required" $address.networkTier }}"
+}
+{{- end }}
+
+resource "google_compute_router_nat" "nat-{{ required "cloudNAT.gateways[].name is required" .name }}" {
+  name                               = "{{ required "cloudNAT.gateways[].resourceName is required" .resourceName }}"
+  router                             = "{{ required "cloudNAT.routerName is required" $.Values.cloudNAT.routerName }}"
+  region                             = "{{ required "google.region is required" $.Values.google.region }}"
+  nat_ip_allocate_option             = "MANUAL_ONLY"
+  nat_ips                            = [{{ range $i, $address := .addresses }}{{ if $i }}, {{ end }}"${google_compute_address.nat-{{ $gateway.name }}-address-{{ $i }}.self_link}"{{ end }}]
+  source_subnetwork_ip_ranges_to_nat = "LIST_OF_SUBNETWORKS"
+{{- include "gcp-infra.cloud-nat-ports" $.Values.cloudNAT }}
+{{- range required "cloudNAT.gateways[].subnets is required" .subnets }}
+
+  subnetwork {
+    name                    = "${google_compute_subnetwork.subnetwork-{{ . }}.self_link}"
+    source_ip_ranges_to_nat = ["ALL_IP_RANGES"]
+  }
+{{- end }}
+{{- include "gcp-infra.timeouts" $ }}
+}
+{{- end }}
+{{- end }}
+{{- range .Values.customRoutes }}
+
+resource "google_compute_route" "route-{{ required "customRoutes[].name is required" .name }}" {
+  name                = "{{ required "customRoutes[].resourceName is required" .resourceName }}"
+  network             = "{{ required "vpc.name is required" $.Values.vpc.name }}"
+  dest_range          = "{{ required "customRoutes[].destination is required" .destination }}"
+{{- if .nextHopIP }}
+  next_hop_ip         = "{{ .nextHopIP }}"
+{{- end }}
+{{- if .nextHopVPNTunnel }}
+  next_hop_vpn_tunnel = "{{ .nextHopVPNTunnel }}"
+{{- end }}
+{{- if .nextHopILB }}
+  next_hop_ilb        = "{{ .nextHopILB }}"
+{{- end }}
+{{- if .priority }}
+  priority            = {{ .priority }}
+{{- end }}
+{{- if .tags }}
+  tags                = [{{ range $i, $tag := .tags }}{{ if $i }}, {{ end }}"{{ $tag }}"{{ end }}]
+{{- end }}
+{{- if .egressAppliance }}
+
+  depends_on = ["google_compute_subnetwork.subnetwork-egress-appliance"]
+{{- else }}
+{{- include "gcp-infra.depends-on-network" $ }}
+{{- end }}
+}
+{{- end }}
+{{- if .Values.networks.restrictedAPIs }}
+
+resource "google_compute_route" "restricted-apis" {
+  name             = "{{ required "resourceNames.restrictedAPIsRoute is required" .Values.resourceNames.restrictedAPIsRoute }}"
+  network          = "{{ required "vpc.name is required" .Values.vpc.name }}"
+  dest_range       = "{{ .Values.networks.restrictedAPIs }}"
+  next_hop_gateway = "default-internet-gateway"
+{{- include "gcp-infra.depends-on-network" . }}
+}
+
+resource "google_dns_managed_zone" "restricted-apis" {
+  name       = "{{ required "resourceNames.restrictedAPIsZone is required" .Values.resourceNames.restrictedAPIsZone }}"
+  dns_name   = "googleapis.com."
+  visibility = "private"
+
+  private_visibility_config {
+    networks {
+{{- if .Values.create.vpc }}
+      network_url = "${google_compute_network.network.self_link}"
+{{- else }}
+      network_url = "https://www.googleapis.com/compute/v1/projects/{{ required "google.project is required" .Values.google.project }}/global/networks/{{ required "vpc.name is required" .Values.vpc.name }}"
+{{- end }}
+    }
+  }
+}
+
+resource "google_dns_record_set" "restricted-apis" {
+  managed_zone = "${google_dns_managed_zone.restricted-apis.name}"
+  name         = "restricted.googleapis.com."
+  type         = "A"
+  ttl          = 300
+  rrdatas      = [{{ range $i, $address := .Values.networks.restrictedAPIsAddresses }}{{ if $i }}, {{ end }}"{{ $address }}"{{ end }}]
+}
+
+resource "google_dns_record_set" "restricted-apis-wildcard" {
+  managed_zone = "${google_dns_managed_zone.restricted-apis.name}"
+  name         = "*.googleapis.com."
+  type         = "CNAME"
+  ttl          = 300
+  rrdatas      = ["restricted.googleapis.com."]
+}
+{{- end }}
+
 //=====================================================================
 //= Firewall
 //=====================================================================
+{{- if .Values.firewall.managed }}
+{{- if .Values.firewall.allowInternalAccess }}
 
 // Allow traffic within internal network range.
 resource "google_compute_firewall" "rule-allow-internal-access" {
-  name          = "{{ required "clusterName is required" .Values.clusterName }}-allow-internal-access"
+  name          = "{{ required "resourceNames.allowInternalAccess is required" .Values.resourceNames.allowInternalAccess }}"
   network       = "{{ required "vpc.name is required" .Values.vpc.name }}"
+  priority      = {{ required "firewall.priorities.allow is required" .Values.firewall.priorities.allow }}
   source_ranges = ["10.0.0.0/8"]
 
   allow {
@@ -66,30 +412,37 @@ resource "google_compute_firewall" "rule-allow-internal-access" {
     protocol = "udp"
     ports    = ["1-65535"]
   }
+{{- include "gcp-infra.depends-on-network" . }}
 }
+{{- end }}
+{{- if .Values.firewall.allowExternalAccess }}
 
 resource "google_compute_firewall" "rule-allow-external-access" {
-  name          = "{{ required "clusterName is required" .Values.clusterName }}-allow-external-access"
+  name          = "{{ required "resourceNames.allowExternalAccess is required" .Values.resourceNames.allowExternalAccess }}"
   network       = "{{ required "vpc.name is required" .Values.vpc.name }}"
+  priority      = {{ required "firewall.priorities.allow is required" .Values.firewall.priorities.allow }}
   source_ranges = ["0.0.0.0/0"]
 
   allow {
     protocol = "tcp"
     ports    = ["80", "443"] // Allow ingress
   }
+{{- include "gcp-infra.depends-on-network" . }}
 }
+{{- end }}
+{{- if .Values.firewall.allowHealthChecks }}
 
 // Required to allow Google to perform health checks on our instances.
 // https://cloud.google.com/compute/docs/load-balancing/internal/
 // https://cloud.google.com/compute/docs/load-balancing/network/
 resource "google_compute_firewall" "rule-allow-health-checks" {
-  name          = "{{ required "clusterName is required" .Values.clusterName }}-allow-health-checks"
+  name          = "{{ required "resourceNames.allowHealthChecks is required" .Values.resourceNames.allowHealthChecks }}"
   network       = "{{ required "vpc.name is required" .Values.vpc.name }}"
+  priority      = {{ required "firewall.priorities.allow is required" .Values.firewall.priorities.allow }}
   source_ranges = [
-    "35.191.0.0/16",
-    "209.85.204.0/22",
-    "209.85.152.0/22",
-    "130.211.0.0/22",
+{{- range (required "firewall.healthCheckSourceRanges is required" .Values.firewall.healthCheckSourceRanges) }}
+    "{{ . }}",
+{{- end }}
   ]
 
   allow {
@@ -101,7 +454,81 @@ resource "google_compute_firewall" "rule-allow-health-checks" {
     protocol = "udp"
     ports    = ["30000-32767"]
   }
+{{- include "gcp-infra.depends-on-network" . }}
+}
+{{- end }}
+
+{{ if .Values.firewall.denyAllEgress -}}
+// Deny all egress traffic that is not explicitly allowed. The priority is chosen so that
+// user-managed allow rules with the default priority take precedence.
+resource "google_compute_firewall" "rule-deny-all-egress" {
+  name               = "{{ required "resourceNames.denyAllEgress is required" .Values.resourceNames.denyAllEgress }}"
+  network            = "{{ required "vpc.name is required" .Values.vpc.name }}"
+  direction          = "EGRESS"
+  priority           = {{ required "firewall.priorities.denyAllEgress is required" .Values.firewall.priorities.denyAllEgress }}
+  destination_ranges = ["0.0.0.0/0"]
+
+  deny {
+    protocol = "all"
+  }
+{{- include "gcp-infra.depends-on-network" . }}
+}
+
+// Allow egress traffic within the cluster networks.
+resource "google_compute_firewall" "rule-allow-cluster-egress" {
+  name               = "{{ required "resourceNames.allowClusterEgress is required" .Values.resourceNames.allowClusterEgress }}"
+  network            = "{{ required "vpc.name is required" .Values.vpc.name }}"
+  direction          = "EGRESS"
+  priority           = {{ required "firewall.priorities.allow is required" .Values.firewall.priorities.allow }}
+  destination_ranges = [
+    "{{ required "networks.worker is required" .Values.networks.worker }}",
+{{- if .Values.networks.internal }}
+    "{{ .Values.networks.internal }}",
+{{- end }}
+{{- if .Values.networks.pods }}
+    "{{ .Values.networks.pods }}",
+{{- end }}
+{{- if .Values.networks.services }}
+    "{{ .Values.networks.services }}",
+{{- end }}
+  ]
+
+  allow {
+    protocol = "all"
+  }
+{{- include "gcp-infra.depends-on-network" . }}
+}
+
+// Allow egress traffic to the metadata server (DNS, NTP, instance metadata).
+resource "google_compute_firewall" "rule-allow-metadata-egress" {
+  name               = "{{ required "resourceNames.allowMetadataEgress is required" .Values.resourceNames.allowMetadataEgress }}"
+  network            = "{{ required "vpc.name is required" .Values.vpc.name }}"
+  direction          = "EGRESS"
+  priority           = {{ required "firewall.priorities.allow is required" .Values.firewall.priorities.allow }}
+  destination_ranges = ["169.254.169.254/32"]
+
+  allow {
+    protocol = "all"
+  }
+{{- include "gcp-infra.depends-on-network" . }}
+}
+
+// Allow HTTPS egress traffic, required to reach the Kubernetes control plane and the Google APIs.
+resource "google_compute_firewall" "rule-allow-control-plane-egress" {
+  name               = "{{ required "resourceNames.allowControlPlaneEgress is required" .Values.resourceNames.allowControlPlaneEgress }}"
+  network            = "{{ required "vpc.name is required" .Values.vpc.name }}"
+  direction          = "EGRESS"
+  priority           = {{ required "firewall.priorities.allow is required" .Values.firewall.priorities.allow }}
+  destination_ranges = ["0.0.0.0/0"]
+
+  allow {
+    protocol = "tcp"
+    ports    = ["443"]
+  }
+{{- include "gcp-infra.depends-on-network" . }}
 }
+{{- end }}
+{{- end }}
 
 // We have introduced new output variables. However, they are not applied for
 // existing clusters as Terraform won't detect a diff when we run `terraform plan`.
@@ -121,15 +548,93 @@ output "{{ .Values.outputKeys.vpcName }}" {
   value = "{{ required "vpc.name is required" .Values.vpc.name }}"
 }
 
+{{ if .Values.create.serviceAccount -}}
 output "{{ .Values.outputKeys.serviceAccountEmail }}" {
   value = "${google_service_account.serviceaccount.email}"
 }
+{{- end }}
 
+{{ if .Values.create.subnets -}}
 output "{{ .Values.outputKeys.subnetNodes }}" {
   value = "${google_compute_subnetwork.subnetwork-nodes.name}"
 }
+
+output "{{ .Values.outputKeys.subnetNodesSelfLink }}" {
+  value = "${google_compute_subnetwork.subnetwork-nodes.self_link}"
+}
 {{ if .Values.networks.internal -}}
 output "{{ .Values.outputKeys.subnetInternal }}" {
   value = "${google_compute_subnetwork.subnetwork-internal.name}"
 }
+
+output "{{ .Values.outputKeys.subnetInternalSelfLink }}" {
+  value = "${google_compute_subnetwork.subnetwork-internal.self_link}"
+}
+{{- end}}
+{{- if .Values.networks.proxyOnly }}
+
+output "{{ .Values.outputKeys.subnetProxyOnly }}" {
+  value = "${google_compute_subnetwork.subnetwork-proxy-only.name}"
+}
+
+output "{{ .Values.outputKeys.subnetProxyOnlyRole }}" {
+  value = "${google_compute_subnetwork.subnetwork-proxy-only.role}"
+}
+{{- end}}
+{{- if .Values.networks.privateServiceConnect }}
+
+output "{{ .Values.outputKeys.subnetPSC }}" {
+  value = "${google_compute_subnetwork.subnetwork-psc.name}"
+}
+{{- end}}
+{{- if .Values.networks.disasterRecovery }}
+
+output "{{ .Values.outputKeys.subnetDisasterRecovery }}" {
+  value = "${google_compute_subnetwork.subnetwork-disaster-recovery.name}"
+}
+
+output "{{ .Values.outputKeys.subnetDisasterRecoveryRegion }}" {
+  value = "${google_compute_subnetwork.subnetwork-disaster-recovery.region}"
+}
 {{- end}}
+{{- if .Values.networks.egressAppliance }}
+
+output "{{ .Values.outputKeys.subnetEgressAppliance }}" {
+  value = "${google_compute_subnetwork.subnetwork-egress-appliance.name}"
+}
+{{- end}}
+{{- end}}
+{{- if .Values.cloudNAT.enabled }}
+
+output "{{ .Values.outputKeys.natIPs }}" {
+  value = "{{ range $i, $address := .Values.cloudNAT.addresses }}{{ if $i }},{{ end }}${google_compute_address.nat-address-{{ $i }}.address}{{ end }}"
+}
+
+output "{{ .Values.outputKeys.natGateways }}" {
+  value = "${google_compute_router_nat.nat.name}={{ range $i, $address := .Values.cloudNAT.addresses }}{{ if $i }};{{ end }}${google_compute_address.nat-address-{{ $i }}.address}{{ end }}{{ range $gateway := .Values.cloudNAT.gateways }},${google_compute_router_nat.nat-{{ $gateway.name }}.name}={{ range $i, $address := $gateway.addresses }}{{ if $i }};{{ end }}${google_compute_address.nat-{{ $gateway.name }}-address-{{ $i }}.address}{{ end }}{{ end }}"
+}
+{{- end }}
+{{- if .Values.networks.controlPlanePeering }}
+
+output "{{ .Values.outputKeys.controlPlanePeeringRange }}" {
+  value = "${google_compute_global_address.control-plane-peering.address}/${google_compute_global_address.control-plane-peering.prefix_length}"
+}
+{{- end }}
+{{- if .Values.flowLogs.exportDestination }}
+
+output "{{ .Values.outputKeys.flowLogsSink }}" {
+  value = "${google_logging_project_sink.flow-logs.name}"
+}
+{{- end }}
+{{- if .Values.internalAddresses }}
+
+output "{{ .Values.outputKeys.internalAddresses }}" {
+  value = "{{ range $i, $address := .Values.internalAddresses }}{{ if $i }},{{ end }}${google_compute_address.internal-address-{{ $i }}.address}{{ end }}"
+}
+{{- end }}
+{{- if .Values.customRoutes }}
+
+output "{{ .Values.outputKeys.routes }}" {
+  value = "{{ range $i, $route := .Values.customRoutes }}{{ if $i }},{{ end }}${google_compute_route.route-{{ $route.name }}.name}{{ end }}"
+}
+{{- end }}
@@ -50,8 +50,11 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 		infraReconcileOpts = &infrastructure.ReconcilerOptions{
 			IgnoreOperationAnnotation: true,
 		}
-		unprefixedInfraOpts = controllercmd.NewOptionAggregator(infraCtrlOpts, infraReconcileOpts)
-		infraOpts           = controllercmd.PrefixOption("infrastructure-", &unprefixedInfraOpts)
+		infraMTUOpts         = &gcpinfrastructure.MTUOptions{}
+		infraStateObjectOpts = &gcpinfrastructure.StateObjectOptions{}
+		infraFlowLogsOpts    = &gcpinfrastructure.FlowLogsOptions{}
+		unprefixedInfraOpts  = controllercmd.NewOptionAggregator(infraCtrlOpts, infraReconcileOpts, infraMTUOpts, infraStateObjectOpts, infraFlowLogsOpts)
+		infraOpts            = controllercmd.PrefixOption("infrastructure-", &unprefixedInfraOpts)
 
 		aggOption = controllercmd.NewOptionAggregator(restOpts, mgrOpts, infraOpts)
 	)
@@ -79,6 +82,9 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 
 			infraCtrlOpts.Completed().Apply(&gcpinfrastructure.DefaultAddOptions.Controller)
 			infraReconcileOpts.Completed().Apply(&gcpinfrastructure.DefaultAddOptions.IgnoreOperationAnnotation)
+			infraMTUOpts.Completed().Apply(&gcpinfrastructure.DefaultAddOptions.AllowedMTURange)
+			infraStateObjectOpts.Completed().Apply(&gcpinfrastructure.DefaultAddOptions.StateObjectSuffix)
+			infraFlowLogsOpts.Completed().Apply(&gcpinfrastructure.DefaultAddOptions.DefaultFlowLogsAggregationInterval)
 
 			if err := gcpcontroller.AddToManager(mgr); err != nil {
 				controllercmd.LogErrAndExit(err, "Could not add controllers to manager")
@@ -28,6 +28,31 @@ type InfrastructureConfig struct {
 
 	// Networks is the network configuration (VPC, subnets, etc.)
 	Networks NetworkConfig `json:"networks"`
+
+	// ServiceAccount is the configuration of the service account of the infrastructure.
+	// +optional
+	ServiceAccount *ServiceAccountConfig `json:"serviceAccount,omitempty"`
+
+	// OutputKeyOverrides maps the default terraform output keys to custom names, e.g. to interoperate
+	// with external terraform modules.
+	// +optional
+	OutputKeyOverrides map[string]string `json:"outputKeyOverrides,omitempty"`
+
+	// Timeouts are the timeouts of the requests and operations of the google terraform provider.
+	// +optional
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
+
+	// DiskEncryptionKey is the resource name of the Cloud KMS key the boot disks of the nodes are encrypted with
+	// by default, e.g. projects/<project>/locations/<location>/keyRings/<key-ring>/cryptoKeys/<key>.
+	// +optional
+	DiskEncryptionKey string `json:"diskEncryptionKey,omitempty"`
+
+	// StateRefresh indicates whether terraform refreshes the state before applying the configuration. Defaults
+	// to true. Disabling it speeds up the reconciliation of large infrastructures, but changes made outside of
+	// terraform are not detected. It cannot be disabled if a Cloud NAT is configured, as the status would report
+	// stale NAT IPs.
+	// +optional
+	StateRefresh *bool `json:"stateRefresh,omitempty"`
 }
 
 // NetworkConfig holds information about the Kubernetes and infrastructure networks.
@@ -38,8 +63,359 @@ type NetworkConfig struct {
 	// Internal is a private subnet (used for internal load balancers).
 	// +optional
 	Internal *gardencorev1alpha1.CIDR `json:"internal,omitempty"`
+	// InternalPurpose is the purpose of the internal subnet in the status, e.g. for tooling that expects a custom
+	// label. Defaults to PurposeInternal.
+	// +optional
+	InternalPurpose *SubnetPurpose `json:"internalPurpose,omitempty"`
+	// SubnetPurposePrefix is prepended to the purposes of all subnets in the status, including a custom
+	// InternalPurpose, e.g. to avoid collisions with the taxonomy of an organization. Defaults to no prefix.
+	// +optional
+	SubnetPurposePrefix *string `json:"subnetPurposePrefix,omitempty"`
+	// InternalProxyOnly indicates whether the internal subnet is an active proxy-only subnet used by the managed
+	// proxies of internal HTTP(S) load balancers rather than for regular endpoints. It can neither be combined with
+	// an active proxy-only subnet nor with translating the internal subnet via Cloud NAT.
+	// +optional
+	InternalProxyOnly bool `json:"internalProxyOnly,omitempty"`
+	// InternalAddresses is the number of internal IP addresses reserved in the internal subnet, e.g. for internal
+	// load balancers. It can only be set if the internal subnet is set and not proxy-only.
+	// +optional
+	InternalAddresses *int32 `json:"internalAddresses,omitempty"`
 	// Workers is the worker subnet range to create (used for the VMs).
 	Worker gardencorev1alpha1.CIDR `json:"worker"`
+	// ProxyOnly is a proxy-only subnet used by regional managed proxies, e.g. of internal HTTP(S) load balancers.
+	// +optional
+	ProxyOnly *ProxyOnlySubnet `json:"proxyOnly,omitempty"`
+	// PrivateServiceConnect is a subnet for Private Service Connect consumer endpoints.
+	// +optional
+	PrivateServiceConnect *gardencorev1alpha1.CIDR `json:"privateServiceConnect,omitempty"`
+	// DisasterRecovery is a standby subnet in a secondary region for disaster recovery.
+	// +optional
+	DisasterRecovery *DisasterRecoverySubnet `json:"disasterRecovery,omitempty"`
+	// EgressAppliance is a subnet for security appliances that the egress traffic of the network is steered
+	// through, e.g. for inspecting it.
+	// +optional
+	EgressAppliance *EgressApplianceSubnet `json:"egressAppliance,omitempty"`
+	// ControlPlanePeering is the range reserved for peering the VPC with a managed control plane via private
+	// services access. It can only be set if the VPC is created.
+	// +optional
+	ControlPlanePeering *gardencorev1alpha1.CIDR `json:"controlPlanePeering,omitempty"`
+	// ControlPlanePeeringRoutes configures the exchange of custom routes via the control plane peering. It can only
+	// be set if the control plane peering is configured. GCP always exchanges the routes of all subnets.
+	// +optional
+	ControlPlanePeeringRoutes *PeeringRoutes `json:"controlPlanePeeringRoutes,omitempty"`
+	// CloudNAT is the configuration of the Cloud NAT of the network. Cloud NAT is enabled if it is set.
+	// +optional
+	CloudNAT *CloudNAT `json:"cloudNAT,omitempty"`
+	// CloudRouterBGP is the BGP configuration of the Cloud Router, e.g. for hybrid connectivity. It can only be set
+	// if the Cloud Router is created, i.e. if Cloud NAT is enabled for a created VPC.
+	// +optional
+	CloudRouterBGP *CloudRouterBGP `json:"cloudRouterBGP,omitempty"`
+	// AirGapped indicates whether the network has no external connectivity. The subnets then reach the Google APIs
+	// via Private Google Access only and the firewall rule allowing external access is not created. It can neither
+	// be combined with Cloud NAT nor with custom default routes.
+	// +optional
+	AirGapped bool `json:"airGapped,omitempty"`
+	// ServicePerimeter is the resource name of the VPC Service Controls perimeter protecting the project, i.e.
+	// accessPolicies/<policy>/servicePerimeters/<perimeter>. The subnets then reach the Google APIs via Private
+	// Google Access, a route to the restricted VIP and a private DNS zone resolving *.googleapis.com to it. The
+	// project has to be added to the perimeter separately.
+	// +optional
+	ServicePerimeter *string `json:"servicePerimeter,omitempty"`
+	// AliasIPs is the configuration of the alias IP ranges of the nodes subnet. If it is set, the pod and service
+	// networks of the cluster are created as secondary ranges of the nodes subnet.
+	// +optional
+	AliasIPs *AliasIPs `json:"aliasIPs,omitempty"`
+	// Firewall is the configuration of the firewall rules of the network.
+	// +optional
+	Firewall *FirewallConfig `json:"firewall,omitempty"`
+	// FlowLogs is the configuration of the VPC flow logs of the nodes subnet. Flow logs are enabled if it is set.
+	// +optional
+	FlowLogs *FlowLogs `json:"flowLogs,omitempty"`
+	// MTU is the maximum transmission unit of the created VPC in bytes. It can only be set if the VPC is created.
+	// Defaults to the one of GCP.
+	// +optional
+	MTU *int32 `json:"mtu,omitempty"`
+	// VPCDescription is the description of the created VPC. It can only be set if the VPC is created. Changing it
+	// replaces the VPC.
+	// +optional
+	VPCDescription *string `json:"vpcDescription,omitempty"`
+	// CustomRoutes are custom static routes of the network, e.g. to on-premises ranges of hybrid clusters.
+	// +optional
+	CustomRoutes []Route `json:"customRoutes,omitempty"`
+	// NetworkingMode is the layout of the secondary ranges of the nodes subnet. Only NetworkingModeDefault and
+	// NetworkingModeCilium can be set, the alias IP layout is configured with AliasIPs. Defaults to
+	// NetworkingModeDefault.
+	// +optional
+	NetworkingMode *NetworkingMode `json:"networkingMode,omitempty"`
+}
+
+// NetworkingMode is the layout of the secondary ranges of the nodes subnet.
+type NetworkingMode string
+
+const (
+	// NetworkingModeDefault is a NetworkingMode without secondary ranges.
+	NetworkingModeDefault NetworkingMode = "Default"
+	// NetworkingModeAliasIPs is a NetworkingMode in which the pod and service networks are secondary ranges.
+	NetworkingModeAliasIPs NetworkingMode = "AliasIPs"
+	// NetworkingModeCilium is a NetworkingMode in which only the service network is a secondary range, as Cilium
+	// delegates the pod network to the nodes itself.
+	NetworkingModeCilium NetworkingMode = "Cilium"
+)
+
+// Route is a custom static route of a network. Exactly one next hop has to be set.
+type Route struct {
+	// Name is the name of the route, which suffixes the name of the created route.
+	Name string `json:"name"`
+	// Destination is the destination range of the route.
+	Destination gardencorev1alpha1.CIDR `json:"destination"`
+	// NextHopIP is the IP of an instance the route forwards to.
+	// +optional
+	NextHopIP *string `json:"nextHopIP,omitempty"`
+	// NextHopVPNTunnel is the self-link of a VPN tunnel the route forwards to.
+	// +optional
+	NextHopVPNTunnel *string `json:"nextHopVPNTunnel,omitempty"`
+	// NextHopILB is the IP or the self-link of the forwarding rule of an internal load balancer the route
+	// forwards to.
+	// +optional
+	NextHopILB *string `json:"nextHopILB,omitempty"`
+}
+
+// FlowLogs contains the configuration of VPC flow logs.
+type FlowLogs struct {
+	// AggregationInterval is the interval for which the flows of a connection are aggregated into one log entry,
+	// e.g. INTERVAL_5_SEC. Defaults to the one of the provider.
+	// +optional
+	AggregationInterval *string `json:"aggregationInterval,omitempty"`
+	// ExportDestination is the destination the flow logs of the nodes subnet are exported to by a logging sink,
+	// e.g. storage.googleapis.com/my-bucket. The writer identity of the sink has to be granted access to it.
+	// +optional
+	ExportDestination *string `json:"exportDestination,omitempty"`
+	// ExportFilters are additional logging filters of the exported flow logs per subnet purpose. Only the nodes and
+	// the internal subnet are supported. The filter of the nodes subnet narrows its logging sink, while setting a
+	// filter for the internal subnet enables its flow logs and exports them by a separate logging sink. They can
+	// only be set if the export destination is set.
+	// +optional
+	ExportFilters map[SubnetPurpose]string `json:"exportFilters,omitempty"`
+	// BigQueryPartitionedTables indicates whether the flow logs exported to a BigQuery dataset are written to
+	// date-partitioned tables instead of date-sharded ones. It can only be set if the export destination is a
+	// BigQuery dataset.
+	// +optional
+	BigQueryPartitionedTables bool `json:"bigQueryPartitionedTables,omitempty"`
+}
+
+// FirewallConfig contains the configuration of the firewall rules created for the network.
+type FirewallConfig struct {
+	// Unmanaged indicates whether no firewall rules shall be created at all, e.g. if they are managed by a
+	// security team, while the networking is still managed. The externally managed rules then have to allow
+	// all traffic within the cluster networks, the health checks of the Google load balancers to the node ports
+	// as well as egress traffic to the metadata server and via HTTPS to the control plane and the Google APIs,
+	// otherwise the nodes cannot join the cluster. None of the other options can be set in this case.
+	// +optional
+	Unmanaged bool `json:"unmanaged,omitempty"`
+	// DenyAllEgress indicates whether all egress traffic that is not explicitly allowed shall be denied.
+	// Egress traffic required by the cluster itself is always allowed.
+	// +optional
+	DenyAllEgress bool `json:"denyAllEgress,omitempty"`
+	// DisableAllowInternalAccess indicates whether the default rule allowing all traffic within the internal
+	// network range shall not be created, e.g. if the internal traffic is governed by user-managed rules.
+	// +optional
+	DisableAllowInternalAccess bool `json:"disableAllowInternalAccess,omitempty"`
+	// DisableAllowHealthChecks indicates whether the default rule allowing the health checks of the Google load
+	// balancers to the node ports shall not be created, e.g. if they are allowed by user-managed rules.
+	// +optional
+	DisableAllowHealthChecks bool `json:"disableAllowHealthChecks,omitempty"`
+	// PriorityBase is an offset added to the priorities of all created firewall rules. The resulting priorities
+	// must be within the range supported by GCP.
+	// +optional
+	PriorityBase *int32 `json:"priorityBase,omitempty"`
+}
+
+// ProxyOnlySubnet contains the configuration of a proxy-only subnet.
+type ProxyOnlySubnet struct {
+	// CIDR is the range of the proxy-only subnet.
+	CIDR gardencorev1alpha1.CIDR `json:"cidr"`
+	// Role is the role of the proxy-only subnet. Defaults to ACTIVE.
+	// +optional
+	Role *ProxyOnlySubnetRole `json:"role,omitempty"`
+}
+
+// PeeringRoutes contains the configuration of the custom routes exchanged via a VPC network peering.
+type PeeringRoutes struct {
+	// ImportCustomRoutes indicates whether the custom routes of the peer network are imported.
+	// +optional
+	ImportCustomRoutes bool `json:"importCustomRoutes,omitempty"`
+	// ExportCustomRoutes indicates whether the custom routes of the VPC are exported to the peer network.
+	// +optional
+	ExportCustomRoutes bool `json:"exportCustomRoutes,omitempty"`
+}
+
+// DisasterRecoverySubnet contains the configuration of a standby subnet in a secondary region.
+type DisasterRecoverySubnet struct {
+	// Region is the secondary region of the subnet. It has to differ from the region of the infrastructure.
+	Region string `json:"region"`
+	// CIDR is the range of the subnet.
+	CIDR gardencorev1alpha1.CIDR `json:"cidr"`
+}
+
+// EgressApplianceSubnet contains the configuration of a subnet for egress appliances and of the routes steering
+// the egress traffic through them. The routes only apply to the nodes, which carry the name of the cluster as
+// network tag, hence the appliances must not carry it.
+type EgressApplianceSubnet struct {
+	// CIDR is the range of the subnet.
+	CIDR gardencorev1alpha1.CIDR `json:"cidr"`
+	// NextHopIP is the IP within the subnet the egress traffic is steered to, e.g. of an appliance or of an
+	// internal load balancer in front of several appliances.
+	NextHopIP string `json:"nextHopIP"`
+	// Destinations are the ranges whose traffic is steered through the appliances. Defaults to 0.0.0.0/0.
+	// +optional
+	Destinations []gardencorev1alpha1.CIDR `json:"destinations,omitempty"`
+	// Priority is the priority of the routes steering the traffic. Defaults to the one of GCP.
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+}
+
+// ProxyOnlySubnetRole is the role of a proxy-only subnet.
+type ProxyOnlySubnetRole string
+
+const (
+	// ProxyOnlySubnetRoleActive is a ProxyOnlySubnetRole of a subnet currently used by the proxies.
+	ProxyOnlySubnetRoleActive ProxyOnlySubnetRole = "ACTIVE"
+	// ProxyOnlySubnetRoleBackup is a ProxyOnlySubnetRole of a subnet prepared to replace the active one.
+	ProxyOnlySubnetRoleBackup ProxyOnlySubnetRole = "BACKUP"
+)
+
+// AliasIPs contains the configuration of the alias IP ranges of the nodes subnet.
+type AliasIPs struct {
+	// PodsPrefixLength is the prefix length of the pods secondary range, which is carved from the start of the
+	// pod network. Defaults to the prefix length of the pod network.
+	// +optional
+	PodsPrefixLength *int32 `json:"podsPrefixLength,omitempty"`
+	// ServicesPrefixLength is the prefix length of the services secondary range, which is carved from the start of
+	// the service network. Defaults to the prefix length of the service network.
+	// +optional
+	ServicesPrefixLength *int32 `json:"servicesPrefixLength,omitempty"`
+	// NodeCIDRMaskSize is the mask size of the pod CIDR of each node, which is carved from the pods secondary
+	// range. If set, the pods secondary range has to provide a pod CIDR for every address of the worker subnet.
+	// +optional
+	NodeCIDRMaskSize *int32 `json:"nodeCIDRMaskSize,omitempty"`
+}
+
+// CloudRouterBGP contains the BGP configuration of the created Cloud Router.
+type CloudRouterBGP struct {
+	// ASN is the private autonomous system number of the Cloud Router.
+	ASN int64 `json:"asn"`
+	// AdvertiseMode is the mode of the route advertisements of the Cloud Router. Defaults to
+	// BGPAdvertiseModeDefault, which advertises all subnets.
+	// +optional
+	AdvertiseMode *BGPAdvertiseMode `json:"advertiseMode,omitempty"`
+	// AdvertiseAllSubnets indicates whether all subnets are advertised in addition to the AdvertisedIPRanges. It
+	// can only be set if the AdvertiseMode is BGPAdvertiseModeCustom.
+	// +optional
+	AdvertiseAllSubnets bool `json:"advertiseAllSubnets,omitempty"`
+	// AdvertisedIPRanges are the ranges advertised by the Cloud Router. They can only be set if the AdvertiseMode
+	// is BGPAdvertiseModeCustom.
+	// +optional
+	AdvertisedIPRanges []gardencorev1alpha1.CIDR `json:"advertisedIPRanges,omitempty"`
+}
+
+// BGPAdvertiseMode is a mode of the route advertisements of a Cloud Router.
+type BGPAdvertiseMode string
+
+const (
+	// BGPAdvertiseModeDefault is a BGPAdvertiseMode advertising all subnets.
+	BGPAdvertiseModeDefault BGPAdvertiseMode = "DEFAULT"
+	// BGPAdvertiseModeCustom is a BGPAdvertiseMode advertising the configured groups and ranges.
+	BGPAdvertiseModeCustom BGPAdvertiseMode = "CUSTOM"
+)
+
+// CloudNAT contains the configuration of a Cloud NAT.
+type CloudNAT struct {
+	// MinPortsPerVM is the minimum number of ports allocated to a VM. Defaults to 64, or to 32 if dynamic port
+	// allocation is enabled. It has to be a power of two.
+	// +optional
+	MinPortsPerVM *int32 `json:"minPortsPerVM,omitempty"`
+	// MaxPortsPerVM is the maximum number of ports allocated to a VM. It can only be set if dynamic port
+	// allocation is enabled. It has to be a power of two.
+	// +optional
+	MaxPortsPerVM *int32 `json:"maxPortsPerVM,omitempty"`
+	// EnableDynamicPortAllocation indicates whether the number of ports allocated to a VM is scaled between
+	// MinPortsPerVM and MaxPortsPerVM based on its usage.
+	// +optional
+	EnableDynamicPortAllocation *bool `json:"enableDynamicPortAllocation,omitempty"`
+	// Subnets are the purposes of the subnets whose traffic is translated, only PurposeNodes and PurposeInternal
+	// are supported. Defaults to all of them.
+	// +optional
+	Subnets []SubnetPurpose `json:"subnets,omitempty"`
+	// IPCount is the number of external IPs reserved for the NAT gateway, each adding 64512 ports to be allocated
+	// to the VMs. Defaults to 1.
+	// +optional
+	IPCount *int32 `json:"ipCount,omitempty"`
+	// NetworkTier is the network tier of the external IPs reserved for all NAT gateways. Defaults to
+	// NetworkTierPremium.
+	// +optional
+	NetworkTier *NetworkTier `json:"networkTier,omitempty"`
+	// Gateways are additional NAT gateways on the Cloud Router, e.g. to spread the traffic of the subnets across
+	// gateways with their own external IPs for a higher throughput. They share the port allocation of the Cloud
+	// NAT. The subnets of all gateways have to be disjoint, hence Subnets has to be set if gateways are set.
+	// +optional
+	Gateways []CloudNATGateway `json:"gateways,omitempty"`
+}
+
+// NetworkTier is a network tier of external IPs.
+type NetworkTier string
+
+const (
+	// NetworkTierPremium is a NetworkTier routing the traffic through the Google network as far as possible.
+	NetworkTierPremium NetworkTier = "PREMIUM"
+	// NetworkTierStandard is a NetworkTier routing the traffic through the public internet as far as possible.
+	NetworkTierStandard NetworkTier = "STANDARD"
+)
+
+// CloudNATGateway contains the configuration of an additional NAT gateway of a Cloud NAT.
+type CloudNATGateway struct {
+	// Name is the name of the gateway, which suffixes the name of the created NAT gateway.
+	Name string `json:"name"`
+	// Subnets are the purposes of the subnets whose traffic is translated by the gateway, only PurposeNodes and
+	// PurposeInternal are supported.
+	Subnets []SubnetPurpose `json:"subnets"`
+	// IPCount is the number of external IPs reserved for the gateway. Defaults to 1.
+	// +optional
+	IPCount *int32 `json:"ipCount,omitempty"`
+}
+
+// ServiceAccountConfig contains the configuration of the service account created for the infrastructure.
+type ServiceAccountConfig struct {
+	// Create indicates whether a service account shall be created. Defaults to true.
+	// +optional
+	Create *bool `json:"create,omitempty"`
+	// WorkloadIdentityUsers are the Kubernetes service accounts, in the form <namespace>/<name>, that are allowed
+	// to impersonate the created service account via workload identity.
+	// +optional
+	WorkloadIdentityUsers []string `json:"workloadIdentityUsers,omitempty"`
+	// Project is the ID of the project the service account is created in, e.g. if the service accounts of all
+	// clusters are managed in a dedicated project. Defaults to the project of the infrastructure.
+	// +optional
+	Project *string `json:"project,omitempty"`
+	// Email is the email address of a pre-provisioned service account that is reported instead of a created one.
+	// It can only be set if no service account is created.
+	// +optional
+	Email *string `json:"email,omitempty"`
+}
+
+// Timeouts contains the timeouts of the google terraform provider. Unset timeouts default to the ones of the provider.
+type Timeouts struct {
+	// Request is the timeout of a single request to the GCP API.
+	// +optional
+	Request *metav1.Duration `json:"request,omitempty"`
+	// Create is the timeout of creating a network resource.
+	// +optional
+	Create *metav1.Duration `json:"create,omitempty"`
+	// Update is the timeout of updating a network resource.
+	// +optional
+	Update *metav1.Duration `json:"update,omitempty"`
+	// Delete is the timeout of deleting a network resource.
+	// +optional
+	Delete *metav1.Duration `json:"delete,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -53,15 +429,80 @@ type InfrastructureStatus struct {
 
 	// ServiceAccountEmail is the email address of the service account.
 	ServiceAccountEmail string `json:"serviceAccountEmail"`
+
+	// DiskEncryptionKey is the resource name of the Cloud KMS key the boot disks of the nodes are encrypted with.
+	// +optional
+	DiskEncryptionKey string `json:"diskEncryptionKey,omitempty"`
+
+	// WorkloadIdentityUsers are the Kubernetes service accounts bound to the service account via workload identity.
+	// +optional
+	WorkloadIdentityUsers []string `json:"workloadIdentityUsers,omitempty"`
+
+	// LastApplyDuration is the duration of the last successful terraform apply.
+	// +optional
+	LastApplyDuration *metav1.Duration `json:"lastApplyDuration,omitempty"`
+	// LastApplyTime is the time at which the last successful terraform apply finished.
+	// +optional
+	LastApplyTime *metav1.Time `json:"lastApplyTime,omitempty"`
+	// StateSerial is the serial number of the terraform state the status was computed from. It is incremented by
+	// every apply changing the state and allows detecting a stale status.
+	// +optional
+	StateSerial *int64 `json:"stateSerial,omitempty"`
+	// MissingOutputs are the terraform output variables expected for the configuration that are missing in the
+	// state, e.g. after a partially failed apply. The resources they describe may not exist, hence the status is
+	// degraded if it is not empty.
+	// +optional
+	MissingOutputs []string `json:"missingOutputs,omitempty"`
+	// StateObjectSuffix is the suffix of the Terraformer state object the infrastructure was last reconciled with.
+	// It is empty if the state object carries no suffix.
+	// +optional
+	StateObjectSuffix string `json:"stateObjectSuffix,omitempty"`
 }
 
 // NetworkStatus is the current status of the infrastructure networks.
 type NetworkStatus struct {
 	// VPC states the name of the infrastructure VPC.
 	VPC VPC `json:"vpc"`
+	// VPCManaged states whether the VPC has been created and is managed by the infrastructure.
+	// +optional
+	VPCManaged *bool `json:"vpcManaged,omitempty"`
 
 	// Subnets are the subnets that have been created.
 	Subnets []Subnet `json:"subnets"`
+	// Routes are the names of the custom routes that have been created.
+	// +optional
+	Routes []string `json:"routes,omitempty"`
+	// InternalAddresses are the internal IP addresses that have been reserved in the internal subnet.
+	// +optional
+	InternalAddresses []string `json:"internalAddresses,omitempty"`
+	// NetworkingMode is the layout of the secondary ranges of the nodes subnet.
+	// +optional
+	NetworkingMode NetworkingMode `json:"networkingMode,omitempty"`
+	// FlowLogsSink is the name of the logging sink the flow logs are exported to.
+	// +optional
+	FlowLogsSink string `json:"flowLogsSink,omitempty"`
+	// FlowLogsExportDestination is the destination the flow logs are exported to.
+	// +optional
+	FlowLogsExportDestination string `json:"flowLogsExportDestination,omitempty"`
+	// ServicePerimeter is the VPC Service Controls perimeter the private access of the subnets is aligned with.
+	// +optional
+	ServicePerimeter string `json:"servicePerimeter,omitempty"`
+	// NATGateways are the NAT gateways of the Cloud NAT with the external IPs they allocated.
+	// +optional
+	NATGateways []NATGateway `json:"natGateways,omitempty"`
+	// ControlPlanePeeringRange is the range reserved for peering the VPC with a managed control plane.
+	// +optional
+	ControlPlanePeeringRange string `json:"controlPlanePeeringRange,omitempty"`
+	// Worker is the range of the nodes subnet that has been applied. It is empty for statuses written before the
+	// range was recorded.
+	// +optional
+	Worker gardencorev1alpha1.CIDR `json:"worker,omitempty"`
+	// Internal is the range of the internal subnet that has been applied.
+	// +optional
+	Internal *gardencorev1alpha1.CIDR `json:"internal,omitempty"`
+	// InternalProxyOnly indicates whether the applied internal subnet is an active proxy-only subnet.
+	// +optional
+	InternalProxyOnly bool `json:"internalProxyOnly,omitempty"`
 }
 
 // SubnetPurpose is a purpose of a subnet.
@@ -72,18 +513,54 @@ const (
 	PurposeNodes SubnetPurpose = "nodes"
 	// PurposeInternal is a SubnetPurpose for internal use.
 	PurposeInternal SubnetPurpose = "internal"
+	// PurposeProxyOnly is a SubnetPurpose for regional managed proxies.
+	PurposeProxyOnly SubnetPurpose = "proxy-only"
+	// PurposePrivateServiceConnect is a SubnetPurpose for Private Service Connect consumer endpoints.
+	PurposePrivateServiceConnect SubnetPurpose = "private-service-connect"
+	// PurposeDisasterRecovery is a SubnetPurpose for the standby subnet in a secondary region.
+	PurposeDisasterRecovery SubnetPurpose = "disaster-recovery"
+	// PurposeEgressAppliance is a SubnetPurpose for appliances the egress traffic is steered through.
+	PurposeEgressAppliance SubnetPurpose = "egress-appliance"
 )
 
+// NATGateway is a NAT gateway that was created.
+type NATGateway struct {
+	// Name is the name of the NAT gateway.
+	Name string `json:"name"`
+	// IPs are the external IPs the NAT gateway allocated.
+	// +optional
+	IPs []string `json:"ips,omitempty"`
+}
+
 // Subnet is a subnet that was created.
 type Subnet struct {
 	// Name is the name of the subnet.
 	Name string `json:"name"`
 	// Purpose is the purpose for which the subnet was created.
 	Purpose SubnetPurpose `json:"purpose"`
+	// Role is the role of the subnet. It is only set for proxy-only subnets, i.e. for subnets of purpose proxy-only
+	// and for the internal subnet if it is proxy-only.
+	// +optional
+	Role ProxyOnlySubnetRole `json:"role,omitempty"`
+	// SelfLink is the self-link of the subnet. It is only set for subnets of purpose nodes and internal.
+	// +optional
+	SelfLink string `json:"selfLink,omitempty"`
+	// Region is the region of the subnet. It is only set for subnets of purpose disaster-recovery.
+	// +optional
+	Region string `json:"region,omitempty"`
 }
 
 // VPC contains information about the VPC and some related resources.
 type VPC struct {
 	// Name is the VPC name.
 	Name string `json:"name,omitempty"`
+	// CloudRouter indicates whether to use an existing Cloud Router of the VPC.
+	// +optional
+	CloudRouter *CloudRouter `json:"cloudRouter,omitempty"`
+}
+
+// CloudRouter contains information about a Cloud Router.
+type CloudRouter struct {
+	// Name is the Cloud Router name.
+	Name string `json:"name"`
 }
@@ -25,6 +25,7 @@ import (
 
 	gcp "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp"
 	corev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -36,6 +37,96 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AliasIPs)(nil), (*gcp.AliasIPs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AliasIPs_To_gcp_AliasIPs(a.(*AliasIPs), b.(*gcp.AliasIPs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.AliasIPs)(nil), (*AliasIPs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_AliasIPs_To_v1alpha1_AliasIPs(a.(*gcp.AliasIPs), b.(*AliasIPs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CloudNAT)(nil), (*gcp.CloudNAT)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudNAT_To_gcp_CloudNAT(a.(*CloudNAT), b.(*gcp.CloudNAT), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.CloudNAT)(nil), (*CloudNAT)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_CloudNAT_To_v1alpha1_CloudNAT(a.(*gcp.CloudNAT), b.(*CloudNAT), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CloudNATGateway)(nil), (*gcp.CloudNATGateway)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudNATGateway_To_gcp_CloudNATGateway(a.(*CloudNATGateway), b.(*gcp.CloudNATGateway), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.CloudNATGateway)(nil), (*CloudNATGateway)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_CloudNATGateway_To_v1alpha1_CloudNATGateway(a.(*gcp.CloudNATGateway), b.(*CloudNATGateway), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CloudRouter)(nil), (*gcp.CloudRouter)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudRouter_To_gcp_CloudRouter(a.(*CloudRouter), b.(*gcp.CloudRouter), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.CloudRouter)(nil), (*CloudRouter)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_CloudRouter_To_v1alpha1_CloudRouter(a.(*gcp.CloudRouter), b.(*CloudRouter), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CloudRouterBGP)(nil), (*gcp.CloudRouterBGP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudRouterBGP_To_gcp_CloudRouterBGP(a.(*CloudRouterBGP), b.(*gcp.CloudRouterBGP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.CloudRouterBGP)(nil), (*CloudRouterBGP)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_CloudRouterBGP_To_v1alpha1_CloudRouterBGP(a.(*gcp.CloudRouterBGP), b.(*CloudRouterBGP), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DisasterRecoverySubnet)(nil), (*gcp.DisasterRecoverySubnet)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DisasterRecoverySubnet_To_gcp_DisasterRecoverySubnet(a.(*DisasterRecoverySubnet), b.(*gcp.DisasterRecoverySubnet), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.DisasterRecoverySubnet)(nil), (*DisasterRecoverySubnet)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_DisasterRecoverySubnet_To_v1alpha1_DisasterRecoverySubnet(a.(*gcp.DisasterRecoverySubnet), b.(*DisasterRecoverySubnet), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*EgressApplianceSubnet)(nil), (*gcp.EgressApplianceSubnet)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_EgressApplianceSubnet_To_gcp_EgressApplianceSubnet(a.(*EgressApplianceSubnet), b.(*gcp.EgressApplianceSubnet), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.EgressApplianceSubnet)(nil), (*EgressApplianceSubnet)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_EgressApplianceSubnet_To_v1alpha1_EgressApplianceSubnet(a.(*gcp.EgressApplianceSubnet), b.(*EgressApplianceSubnet), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FirewallConfig)(nil), (*gcp.FirewallConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FirewallConfig_To_gcp_FirewallConfig(a.(*FirewallConfig), b.(*gcp.FirewallConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.FirewallConfig)(nil), (*FirewallConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_FirewallConfig_To_v1alpha1_FirewallConfig(a.(*gcp.FirewallConfig), b.(*FirewallConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FlowLogs)(nil), (*gcp.FlowLogs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FlowLogs_To_gcp_FlowLogs(a.(*FlowLogs), b.(*gcp.FlowLogs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.FlowLogs)(nil), (*FlowLogs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_FlowLogs_To_v1alpha1_FlowLogs(a.(*gcp.FlowLogs), b.(*FlowLogs), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*InfrastructureConfig)(nil), (*gcp.InfrastructureConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_InfrastructureConfig_To_gcp_InfrastructureConfig(a.(*InfrastructureConfig), b.(*gcp.InfrastructureConfig), scope)
 	}); err != nil {
@@ -56,6 +147,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*NATGateway)(nil), (*gcp.NATGateway)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_NATGateway_To_gcp_NATGateway(a.(*NATGateway), b.(*gcp.NATGateway), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.NATGateway)(nil), (*NATGateway)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_NATGateway_To_v1alpha1_NATGateway(a.(*gcp.NATGateway), b.(*NATGateway), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NetworkConfig)(nil), (*gcp.NetworkConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_NetworkConfig_To_gcp_NetworkConfig(a.(*NetworkConfig), b.(*gcp.NetworkConfig), scope)
 	}); err != nil {
@@ -76,6 +177,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*PeeringRoutes)(nil), (*gcp.PeeringRoutes)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PeeringRoutes_To_gcp_PeeringRoutes(a.(*PeeringRoutes), b.(*gcp.PeeringRoutes), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.PeeringRoutes)(nil), (*PeeringRoutes)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_PeeringRoutes_To_v1alpha1_PeeringRoutes(a.(*gcp.PeeringRoutes), b.(*PeeringRoutes), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ProxyOnlySubnet)(nil), (*gcp.ProxyOnlySubnet)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ProxyOnlySubnet_To_gcp_ProxyOnlySubnet(a.(*ProxyOnlySubnet), b.(*gcp.ProxyOnlySubnet), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.ProxyOnlySubnet)(nil), (*ProxyOnlySubnet)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_ProxyOnlySubnet_To_v1alpha1_ProxyOnlySubnet(a.(*gcp.ProxyOnlySubnet), b.(*ProxyOnlySubnet), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Route)(nil), (*gcp.Route)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Route_To_gcp_Route(a.(*Route), b.(*gcp.Route), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.Route)(nil), (*Route)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_Route_To_v1alpha1_Route(a.(*gcp.Route), b.(*Route), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ServiceAccountConfig)(nil), (*gcp.ServiceAccountConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ServiceAccountConfig_To_gcp_ServiceAccountConfig(a.(*ServiceAccountConfig), b.(*gcp.ServiceAccountConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.ServiceAccountConfig)(nil), (*ServiceAccountConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_ServiceAccountConfig_To_v1alpha1_ServiceAccountConfig(a.(*gcp.ServiceAccountConfig), b.(*ServiceAccountConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Subnet)(nil), (*gcp.Subnet)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Subnet_To_gcp_Subnet(a.(*Subnet), b.(*gcp.Subnet), scope)
 	}); err != nil {
@@ -86,6 +227,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Timeouts)(nil), (*gcp.Timeouts)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Timeouts_To_gcp_Timeouts(a.(*Timeouts), b.(*gcp.Timeouts), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*gcp.Timeouts)(nil), (*Timeouts)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_gcp_Timeouts_To_v1alpha1_Timeouts(a.(*gcp.Timeouts), b.(*Timeouts), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VPC)(nil), (*gcp.VPC)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_VPC_To_gcp_VPC(a.(*VPC), b.(*gcp.VPC), scope)
 	}); err != nil {
@@ -99,10 +250,243 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha1_AliasIPs_To_gcp_AliasIPs(in *AliasIPs, out *gcp.AliasIPs, s conversion.Scope) error {
+	out.PodsPrefixLength = (*int32)(unsafe.Pointer(in.PodsPrefixLength))
+	out.ServicesPrefixLength = (*int32)(unsafe.Pointer(in.ServicesPrefixLength))
+	out.NodeCIDRMaskSize = (*int32)(unsafe.Pointer(in.NodeCIDRMaskSize))
+	return nil
+}
+
+// Convert_v1alpha1_AliasIPs_To_gcp_AliasIPs is an autogenerated conversion function.
+func Convert_v1alpha1_AliasIPs_To_gcp_AliasIPs(in *AliasIPs, out *gcp.AliasIPs, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AliasIPs_To_gcp_AliasIPs(in, out, s)
+}
+
+func autoConvert_gcp_AliasIPs_To_v1alpha1_AliasIPs(in *gcp.AliasIPs, out *AliasIPs, s conversion.Scope) error {
+	out.PodsPrefixLength = (*int32)(unsafe.Pointer(in.PodsPrefixLength))
+	out.ServicesPrefixLength = (*int32)(unsafe.Pointer(in.ServicesPrefixLength))
+	out.NodeCIDRMaskSize = (*int32)(unsafe.Pointer(in.NodeCIDRMaskSize))
+	return nil
+}
+
+// Convert_gcp_AliasIPs_To_v1alpha1_AliasIPs is an autogenerated conversion function.
+func Convert_gcp_AliasIPs_To_v1alpha1_AliasIPs(in *gcp.AliasIPs, out *AliasIPs, s conversion.Scope) error {
+	return autoConvert_gcp_AliasIPs_To_v1alpha1_AliasIPs(in, out, s)
+}
+
+func autoConvert_v1alpha1_CloudNAT_To_gcp_CloudNAT(in *CloudNAT, out *gcp.CloudNAT, s conversion.Scope) error {
+	out.MinPortsPerVM = (*int32)(unsafe.Pointer(in.MinPortsPerVM))
+	out.MaxPortsPerVM = (*int32)(unsafe.Pointer(in.MaxPortsPerVM))
+	out.EnableDynamicPortAllocation = (*bool)(unsafe.Pointer(in.EnableDynamicPortAllocation))
+	out.Subnets = *(*[]gcp.SubnetPurpose)(unsafe.Pointer(&in.Subnets))
+	out.IPCount = (*int32)(unsafe.Pointer(in.IPCount))
+	out.NetworkTier = (*gcp.NetworkTier)(unsafe.Pointer(in.NetworkTier))
+	out.Gateways = *(*[]gcp.CloudNATGateway)(unsafe.Pointer(&in.Gateways))
+	return nil
+}
+
+// Convert_v1alpha1_CloudNAT_To_gcp_CloudNAT is an autogenerated conversion function.
+func Convert_v1alpha1_CloudNAT_To_gcp_CloudNAT(in *CloudNAT, out *gcp.CloudNAT, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudNAT_To_gcp_CloudNAT(in, out, s)
+}
+
+func autoConvert_gcp_CloudNAT_To_v1alpha1_CloudNAT(in *gcp.CloudNAT, out *CloudNAT, s conversion.Scope) error {
+	out.MinPortsPerVM = (*int32)(unsafe.Pointer(in.MinPortsPerVM))
+	out.MaxPortsPerVM = (*int32)(unsafe.Pointer(in.MaxPortsPerVM))
+	out.EnableDynamicPortAllocation = (*bool)(unsafe.Pointer(in.EnableDynamicPortAllocation))
+	out.Subnets = *(*[]SubnetPurpose)(unsafe.Pointer(&in.Subnets))
+	out.IPCount = (*int32)(unsafe.Pointer(in.IPCount))
+	out.NetworkTier = (*NetworkTier)(unsafe.Pointer(in.NetworkTier))
+	out.Gateways = *(*[]CloudNATGateway)(unsafe.Pointer(&in.Gateways))
+	return nil
+}
+
+// Convert_gcp_CloudNAT_To_v1alpha1_CloudNAT is an autogenerated conversion function.
+func Convert_gcp_CloudNAT_To_v1alpha1_CloudNAT(in *gcp.CloudNAT, out *CloudNAT, s conversion.Scope) error {
+	return autoConvert_gcp_CloudNAT_To_v1alpha1_CloudNAT(in, out, s)
+}
+
+func autoConvert_v1alpha1_CloudNATGateway_To_gcp_CloudNATGateway(in *CloudNATGateway, out *gcp.CloudNATGateway, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Subnets = *(*[]gcp.SubnetPurpose)(unsafe.Pointer(&in.Subnets))
+	out.IPCount = (*int32)(unsafe.Pointer(in.IPCount))
+	return nil
+}
+
+// Convert_v1alpha1_CloudNATGateway_To_gcp_CloudNATGateway is an autogenerated conversion function.
+func Convert_v1alpha1_CloudNATGateway_To_gcp_CloudNATGateway(in *CloudNATGateway, out *gcp.CloudNATGateway, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudNATGateway_To_gcp_CloudNATGateway(in, out, s)
+}
+
+func autoConvert_gcp_CloudNATGateway_To_v1alpha1_CloudNATGateway(in *gcp.CloudNATGateway, out *CloudNATGateway, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Subnets = *(*[]SubnetPurpose)(unsafe.Pointer(&in.Subnets))
+	out.IPCount = (*int32)(unsafe.Pointer(in.IPCount))
+	return nil
+}
+
+// Convert_gcp_CloudNATGateway_To_v1alpha1_CloudNATGateway is an autogenerated conversion function.
+func Convert_gcp_CloudNATGateway_To_v1alpha1_CloudNATGateway(in *gcp.CloudNATGateway, out *CloudNATGateway, s conversion.Scope) error {
+	return autoConvert_gcp_CloudNATGateway_To_v1alpha1_CloudNATGateway(in, out, s)
+}
+
+func autoConvert_v1alpha1_CloudRouter_To_gcp_CloudRouter(in *CloudRouter, out *gcp.CloudRouter, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha1_CloudRouter_To_gcp_CloudRouter is an autogenerated conversion function.
+func Convert_v1alpha1_CloudRouter_To_gcp_CloudRouter(in *CloudRouter, out *gcp.CloudRouter, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudRouter_To_gcp_CloudRouter(in, out, s)
+}
+
+func autoConvert_gcp_CloudRouter_To_v1alpha1_CloudRouter(in *gcp.CloudRouter, out *CloudRouter, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_gcp_CloudRouter_To_v1alpha1_CloudRouter is an autogenerated conversion function.
+func Convert_gcp_CloudRouter_To_v1alpha1_CloudRouter(in *gcp.CloudRouter, out *CloudRouter, s conversion.Scope) error {
+	return autoConvert_gcp_CloudRouter_To_v1alpha1_CloudRouter(in, out, s)
+}
+
+func autoConvert_v1alpha1_CloudRouterBGP_To_gcp_CloudRouterBGP(in *CloudRouterBGP, out *gcp.CloudRouterBGP, s conversion.Scope) error {
+	out.ASN = in.ASN
+	out.AdvertiseMode = (*gcp.BGPAdvertiseMode)(unsafe.Pointer(in.AdvertiseMode))
+	out.AdvertiseAllSubnets = in.AdvertiseAllSubnets
+	out.AdvertisedIPRanges = *(*[]corev1alpha1.CIDR)(unsafe.Pointer(&in.AdvertisedIPRanges))
+	return nil
+}
+
+// Convert_v1alpha1_CloudRouterBGP_To_gcp_CloudRouterBGP is an autogenerated conversion function.
+func Convert_v1alpha1_CloudRouterBGP_To_gcp_CloudRouterBGP(in *CloudRouterBGP, out *gcp.CloudRouterBGP, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudRouterBGP_To_gcp_CloudRouterBGP(in, out, s)
+}
+
+func autoConvert_gcp_CloudRouterBGP_To_v1alpha1_CloudRouterBGP(in *gcp.CloudRouterBGP, out *CloudRouterBGP, s conversion.Scope) error {
+	out.ASN = in.ASN
+	out.AdvertiseMode = (*BGPAdvertiseMode)(unsafe.Pointer(in.AdvertiseMode))
+	out.AdvertiseAllSubnets = in.AdvertiseAllSubnets
+	out.AdvertisedIPRanges = *(*[]corev1alpha1.CIDR)(unsafe.Pointer(&in.AdvertisedIPRanges))
+	return nil
+}
+
+// Convert_gcp_CloudRouterBGP_To_v1alpha1_CloudRouterBGP is an autogenerated conversion function.
+func Convert_gcp_CloudRouterBGP_To_v1alpha1_CloudRouterBGP(in *gcp.CloudRouterBGP, out *CloudRouterBGP, s conversion.Scope) error {
+	return autoConvert_gcp_CloudRouterBGP_To_v1alpha1_CloudRouterBGP(in, out, s)
+}
+
+func autoConvert_v1alpha1_DisasterRecoverySubnet_To_gcp_DisasterRecoverySubnet(in *DisasterRecoverySubnet, out *gcp.DisasterRecoverySubnet, s conversion.Scope) error {
+	out.Region = in.Region
+	out.CIDR = corev1alpha1.CIDR(in.CIDR)
+	return nil
+}
+
+// Convert_v1alpha1_DisasterRecoverySubnet_To_gcp_DisasterRecoverySubnet is an autogenerated conversion function.
+func Convert_v1alpha1_DisasterRecoverySubnet_To_gcp_DisasterRecoverySubnet(in *DisasterRecoverySubnet, out *gcp.DisasterRecoverySubnet, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DisasterRecoverySubnet_To_gcp_DisasterRecoverySubnet(in, out, s)
+}
+
+func autoConvert_gcp_DisasterRecoverySubnet_To_v1alpha1_DisasterRecoverySubnet(in *gcp.DisasterRecoverySubnet, out *DisasterRecoverySubnet, s conversion.Scope) error {
+	out.Region = in.Region
+	out.CIDR = corev1alpha1.CIDR(in.CIDR)
+	return nil
+}
+
+// Convert_gcp_DisasterRecoverySubnet_To_v1alpha1_DisasterRecoverySubnet is an autogenerated conversion function.
+func Convert_gcp_DisasterRecoverySubnet_To_v1alpha1_DisasterRecoverySubnet(in *gcp.DisasterRecoverySubnet, out *DisasterRecoverySubnet, s conversion.Scope) error {
+	return autoConvert_gcp_DisasterRecoverySubnet_To_v1alpha1_DisasterRecoverySubnet(in, out, s)
+}
+
+func autoConvert_v1alpha1_EgressApplianceSubnet_To_gcp_EgressApplianceSubnet(in *EgressApplianceSubnet, out *gcp.EgressApplianceSubnet, s conversion.Scope) error {
+	out.CIDR = corev1alpha1.CIDR(in.CIDR)
+	out.NextHopIP = in.NextHopIP
+	out.Destinations = *(*[]corev1alpha1.CIDR)(unsafe.Pointer(&in.Destinations))
+	out.Priority = (*int32)(unsafe.Pointer(in.Priority))
+	return nil
+}
+
+// Convert_v1alpha1_EgressApplianceSubnet_To_gcp_EgressApplianceSubnet is an autogenerated conversion function.
+func Convert_v1alpha1_EgressApplianceSubnet_To_gcp_EgressApplianceSubnet(in *EgressApplianceSubnet, out *gcp.EgressApplianceSubnet, s conversion.Scope) error {
+	return autoConvert_v1alpha1_EgressApplianceSubnet_To_gcp_EgressApplianceSubnet(in, out, s)
+}
+
+func autoConvert_gcp_EgressApplianceSubnet_To_v1alpha1_EgressApplianceSubnet(in *gcp.EgressApplianceSubnet, out *EgressApplianceSubnet, s conversion.Scope) error {
+	out.CIDR = corev1alpha1.CIDR(in.CIDR)
+	out.NextHopIP = in.NextHopIP
+	out.Destinations = *(*[]corev1alpha1.CIDR)(unsafe.Pointer(&in.Destinations))
+	out.Priority = (*int32)(unsafe.Pointer(in.Priority))
+	return nil
+}
+
+// Convert_gcp_EgressApplianceSubnet_To_v1alpha1_EgressApplianceSubnet is an autogenerated conversion function.
+func Convert_gcp_EgressApplianceSubnet_To_v1alpha1_EgressApplianceSubnet(in *gcp.EgressApplianceSubnet, out *EgressApplianceSubnet, s conversion.Scope) error {
+	return autoConvert_gcp_EgressApplianceSubnet_To_v1alpha1_EgressApplianceSubnet(in, out, s)
+}
+
+func autoConvert_v1alpha1_FirewallConfig_To_gcp_FirewallConfig(in *FirewallConfig, out *gcp.FirewallConfig, s conversion.Scope) error {
+	out.Unmanaged = in.Unmanaged
+	out.DenyAllEgress = in.DenyAllEgress
+	out.DisableAllowInternalAccess = in.DisableAllowInternalAccess
+	out.DisableAllowHealthChecks = in.DisableAllowHealthChecks
+	out.PriorityBase = (*int32)(unsafe.Pointer(in.PriorityBase))
+	return nil
+}
+
+// Convert_v1alpha1_FirewallConfig_To_gcp_FirewallConfig is an autogenerated conversion function.
+func Convert_v1alpha1_FirewallConfig_To_gcp_FirewallConfig(in *FirewallConfig, out *gcp.FirewallConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FirewallConfig_To_gcp_FirewallConfig(in, out, s)
+}
+
+func autoConvert_gcp_FirewallConfig_To_v1alpha1_FirewallConfig(in *gcp.FirewallConfig, out *FirewallConfig, s conversion.Scope) error {
+	out.Unmanaged = in.Unmanaged
+	out.DenyAllEgress = in.DenyAllEgress
+	out.DisableAllowInternalAccess = in.DisableAllowInternalAccess
+	out.DisableAllowHealthChecks = in.DisableAllowHealthChecks
+	out.PriorityBase = (*int32)(unsafe.Pointer(in.PriorityBase))
+	return nil
+}
+
+// Convert_gcp_FirewallConfig_To_v1alpha1_FirewallConfig is an autogenerated conversion function.
+func Convert_gcp_FirewallConfig_To_v1alpha1_FirewallConfig(in *gcp.FirewallConfig, out *FirewallConfig, s conversion.Scope) error {
+	return autoConvert_gcp_FirewallConfig_To_v1alpha1_FirewallConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_FlowLogs_To_gcp_FlowLogs(in *FlowLogs, out *gcp.FlowLogs, s conversion.Scope) error {
+	out.AggregationInterval = (*string)(unsafe.Pointer(in.AggregationInterval))
+	out.ExportDestination = (*string)(unsafe.Pointer(in.ExportDestination))
+	out.ExportFilters = *(*map[gcp.SubnetPurpose]string)(unsafe.Pointer(&in.ExportFilters))
+	out.BigQueryPartitionedTables = in.BigQueryPartitionedTables
+	return nil
+}
+
+// Convert_v1alpha1_FlowLogs_To_gcp_FlowLogs is an autogenerated conversion function.
+func Convert_v1alpha1_FlowLogs_To_gcp_FlowLogs(in *FlowLogs, out *gcp.FlowLogs, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FlowLogs_To_gcp_FlowLogs(in, out, s)
+}
+
+func autoConvert_gcp_FlowLogs_To_v1alpha1_FlowLogs(in *gcp.FlowLogs, out *FlowLogs, s conversion.Scope) error {
+	out.AggregationInterval = (*string)(unsafe.Pointer(in.AggregationInterval))
+	out.ExportDestination = (*string)(unsafe.Pointer(in.ExportDestination))
+	out.ExportFilters = *(*map[SubnetPurpose]string)(unsafe.Pointer(&in.ExportFilters))
+	out.BigQueryPartitionedTables = in.BigQueryPartitionedTables
+	return nil
+}
+
+// Convert_gcp_FlowLogs_To_v1alpha1_FlowLogs is an autogenerated conversion function.
+func Convert_gcp_FlowLogs_To_v1alpha1_FlowLogs(in *gcp.FlowLogs, out *FlowLogs, s conversion.Scope) error {
+	return autoConvert_gcp_FlowLogs_To_v1alpha1_FlowLogs(in, out, s)
+}
+
 func autoConvert_v1alpha1_InfrastructureConfig_To_gcp_InfrastructureConfig(in *InfrastructureConfig, out *gcp.InfrastructureConfig, s conversion.Scope) error {
 	if err := Convert_v1alpha1_NetworkConfig_To_gcp_NetworkConfig(&in.Networks, &out.Networks, s); err != nil {
 		return err
 	}
+	out.ServiceAccount = (*gcp.ServiceAccountConfig)(unsafe.Pointer(in.ServiceAccount))
+	out.OutputKeyOverrides = *(*map[string]string)(unsafe.Pointer(&in.OutputKeyOverrides))
+	out.Timeouts = (*gcp.Timeouts)(unsafe.Pointer(in.Timeouts))
+	out.DiskEncryptionKey = in.DiskEncryptionKey
+	out.StateRefresh = (*bool)(unsafe.Pointer(in.StateRefresh))
 	return nil
 }
 
@@ -115,6 +499,11 @@ func autoConvert_gcp_InfrastructureConfig_To_v1alpha1_InfrastructureConfig(in *g
 	if err := Convert_gcp_NetworkConfig_To_v1alpha1_NetworkConfig(&in.Networks, &out.Networks, s); err != nil {
 		return err
 	}
+	out.ServiceAccount = (*ServiceAccountConfig)(unsafe.Pointer(in.ServiceAccount))
+	out.OutputKeyOverrides = *(*map[string]string)(unsafe.Pointer(&in.OutputKeyOverrides))
+	out.Timeouts = (*Timeouts)(unsafe.Pointer(in.Timeouts))
+	out.DiskEncryptionKey = in.DiskEncryptionKey
+	out.StateRefresh = (*bool)(unsafe.Pointer(in.StateRefresh))
 	return nil
 }
 
@@ -128,6 +517,13 @@ func autoConvert_v1alpha1_InfrastructureStatus_To_gcp_InfrastructureStatus(in *I
 		return err
 	}
 	out.ServiceAccountEmail = in.ServiceAccountEmail
+	out.DiskEncryptionKey = in.DiskEncryptionKey
+	out.WorkloadIdentityUsers = *(*[]string)(unsafe.Pointer(&in.WorkloadIdentityUsers))
+	out.LastApplyDuration = (*v1.Duration)(unsafe.Pointer(in.LastApplyDuration))
+	out.LastApplyTime = (*v1.Time)(unsafe.Pointer(in.LastApplyTime))
+	out.StateSerial = (*int64)(unsafe.Pointer(in.StateSerial))
+	out.MissingOutputs = *(*[]string)(unsafe.Pointer(&in.MissingOutputs))
+	out.StateObjectSuffix = in.StateObjectSuffix
 	return nil
 }
 
@@ -141,6 +537,13 @@ func autoConvert_gcp_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in *g
 		return err
 	}
 	out.ServiceAccountEmail = in.ServiceAccountEmail
+	out.DiskEncryptionKey = in.DiskEncryptionKey
+	out.WorkloadIdentityUsers = *(*[]string)(unsafe.Pointer(&in.WorkloadIdentityUsers))
+	out.LastApplyDuration = (*v1.Duration)(unsafe.Pointer(in.LastApplyDuration))
+	out.LastApplyTime = (*v1.Time)(unsafe.Pointer(in.LastApplyTime))
+	out.StateSerial = (*int64)(unsafe.Pointer(in.StateSerial))
+	out.MissingOutputs = *(*[]string)(unsafe.Pointer(&in.MissingOutputs))
+	out.StateObjectSuffix = in.StateObjectSuffix
 	return nil
 }
 
@@ -149,10 +552,53 @@ func Convert_gcp_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in *gcp.I
 	return autoConvert_gcp_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_NATGateway_To_gcp_NATGateway(in *NATGateway, out *gcp.NATGateway, s conversion.Scope) error {
+	out.Name = in.Name
+	out.IPs = *(*[]string)(unsafe.Pointer(&in.IPs))
+	return nil
+}
+
+// Convert_v1alpha1_NATGateway_To_gcp_NATGateway is an autogenerated conversion function.
+func Convert_v1alpha1_NATGateway_To_gcp_NATGateway(in *NATGateway, out *gcp.NATGateway, s conversion.Scope) error {
+	return autoConvert_v1alpha1_NATGateway_To_gcp_NATGateway(in, out, s)
+}
+
+func autoConvert_gcp_NATGateway_To_v1alpha1_NATGateway(in *gcp.NATGateway, out *NATGateway, s conversion.Scope) error {
+	out.Name = in.Name
+	out.IPs = *(*[]string)(unsafe.Pointer(&in.IPs))
+	return nil
+}
+
+// Convert_gcp_NATGateway_To_v1alpha1_NATGateway is an autogenerated conversion function.
+func Convert_gcp_NATGateway_To_v1alpha1_NATGateway(in *gcp.NATGateway, out *NATGateway, s conversion.Scope) error {
+	return autoConvert_gcp_NATGateway_To_v1alpha1_NATGateway(in, out, s)
+}
+
 func autoConvert_v1alpha1_NetworkConfig_To_gcp_NetworkConfig(in *NetworkConfig, out *gcp.NetworkConfig, s conversion.Scope) error {
 	out.VPC = (*gcp.VPC)(unsafe.Pointer(in.VPC))
 	out.Internal = (*corev1alpha1.CIDR)(unsafe.Pointer(in.Internal))
+	out.InternalPurpose = (*gcp.SubnetPurpose)(unsafe.Pointer(in.InternalPurpose))
+	out.SubnetPurposePrefix = (*string)(unsafe.Pointer(in.SubnetPurposePrefix))
+	out.InternalProxyOnly = in.InternalProxyOnly
+	out.InternalAddresses = (*int32)(unsafe.Pointer(in.InternalAddresses))
 	out.Worker = corev1alpha1.CIDR(in.Worker)
+	out.ProxyOnly = (*gcp.ProxyOnlySubnet)(unsafe.Pointer(in.ProxyOnly))
+	out.PrivateServiceConnect = (*corev1alpha1.CIDR)(unsafe.Pointer(in.PrivateServiceConnect))
+	out.DisasterRecovery = (*gcp.DisasterRecoverySubnet)(unsafe.Pointer(in.DisasterRecovery))
+	out.EgressAppliance = (*gcp.EgressApplianceSubnet)(unsafe.Pointer(in.EgressAppliance))
+	out.ControlPlanePeering = (*corev1alpha1.CIDR)(unsafe.Pointer(in.ControlPlanePeering))
+	out.ControlPlanePeeringRoutes = (*gcp.PeeringRoutes)(unsafe.Pointer(in.ControlPlanePeeringRoutes))
+	out.CloudNAT = (*gcp.CloudNAT)(unsafe.Pointer(in.CloudNAT))
+	out.CloudRouterBGP = (*gcp.CloudRouterBGP)(unsafe.Pointer(in.CloudRouterBGP))
+	out.AirGapped = in.AirGapped
+	out.ServicePerimeter = (*string)(unsafe.Pointer(in.ServicePerimeter))
+	out.AliasIPs = (*gcp.AliasIPs)(unsafe.Pointer(in.AliasIPs))
+	out.Firewall = (*gcp.FirewallConfig)(unsafe.Pointer(in.Firewall))
+	out.FlowLogs = (*gcp.FlowLogs)(unsafe.Pointer(in.FlowLogs))
+	out.MTU = (*int32)(unsafe.Pointer(in.MTU))
+	out.VPCDescription = (*string)(unsafe.Pointer(in.VPCDescription))
+	out.CustomRoutes = *(*[]gcp.Route)(unsafe.Pointer(&in.CustomRoutes))
+	out.NetworkingMode = (*gcp.NetworkingMode)(unsafe.Pointer(in.NetworkingMode))
 	return nil
 }
 
@@ -164,7 +610,28 @@ func Convert_v1alpha1_NetworkConfig_To_gcp_NetworkConfig(in *NetworkConfig, out
 func autoConvert_gcp_NetworkConfig_To_v1alpha1_NetworkConfig(in *gcp.NetworkConfig, out *NetworkConfig, s conversion.Scope) error {
 	out.VPC = (*VPC)(unsafe.Pointer(in.VPC))
 	out.Internal = (*corev1alpha1.CIDR)(unsafe.Pointer(in.Internal))
+	out.InternalPurpose = (*SubnetPurpose)(unsafe.Pointer(in.InternalPurpose))
+	out.SubnetPurposePrefix = (*string)(unsafe.Pointer(in.SubnetPurposePrefix))
+	out.InternalProxyOnly = in.InternalProxyOnly
+	out.InternalAddresses = (*int32)(unsafe.Pointer(in.InternalAddresses))
 	out.Worker = corev1alpha1.CIDR(in.Worker)
+	out.ProxyOnly = (*ProxyOnlySubnet)(unsafe.Pointer(in.ProxyOnly))
+	out.PrivateServiceConnect = (*corev1alpha1.CIDR)(unsafe.Pointer(in.PrivateServiceConnect))
+	out.DisasterRecovery = (*DisasterRecoverySubnet)(unsafe.Pointer(in.DisasterRecovery))
+	out.EgressAppliance = (*EgressApplianceSubnet)(unsafe.Pointer(in.EgressAppliance))
+	out.ControlPlanePeering = (*corev1alpha1.CIDR)(unsafe.Pointer(in.ControlPlanePeering))
+	out.ControlPlanePeeringRoutes = (*PeeringRoutes)(unsafe.Pointer(in.ControlPlanePeeringRoutes))
+	out.CloudNAT = (*CloudNAT)(unsafe.Pointer(in.CloudNAT))
+	out.CloudRouterBGP = (*CloudRouterBGP)(unsafe.Pointer(in.CloudRouterBGP))
+	out.AirGapped = in.AirGapped
+	out.ServicePerimeter = (*string)(unsafe.Pointer(in.ServicePerimeter))
+	out.AliasIPs = (*AliasIPs)(unsafe.Pointer(in.AliasIPs))
+	out.Firewall = (*FirewallConfig)(unsafe.Pointer(in.Firewall))
+	out.FlowLogs = (*FlowLogs)(unsafe.Pointer(in.FlowLogs))
+	out.MTU = (*int32)(unsafe.Pointer(in.MTU))
+	out.VPCDescription = (*string)(unsafe.Pointer(in.VPCDescription))
+	out.CustomRoutes = *(*[]Route)(unsafe.Pointer(&in.CustomRoutes))
+	out.NetworkingMode = (*NetworkingMode)(unsafe.Pointer(in.NetworkingMode))
 	return nil
 }
 
@@ -177,7 +644,19 @@ func autoConvert_v1alpha1_NetworkStatus_To_gcp_NetworkStatus(in *NetworkStatus,
 	if err := Convert_v1alpha1_VPC_To_gcp_VPC(&in.VPC, &out.VPC, s); err != nil {
 		return err
 	}
+	out.VPCManaged = (*bool)(unsafe.Pointer(in.VPCManaged))
 	out.Subnets = *(*[]gcp.Subnet)(unsafe.Pointer(&in.Subnets))
+	out.Routes = *(*[]string)(unsafe.Pointer(&in.Routes))
+	out.InternalAddresses = *(*[]string)(unsafe.Pointer(&in.InternalAddresses))
+	out.NetworkingMode = gcp.NetworkingMode(in.NetworkingMode)
+	out.FlowLogsSink = in.FlowLogsSink
+	out.FlowLogsExportDestination = in.FlowLogsExportDestination
+	out.ServicePerimeter = in.ServicePerimeter
+	out.NATGateways = *(*[]gcp.NATGateway)(unsafe.Pointer(&in.NATGateways))
+	out.ControlPlanePeeringRange = in.ControlPlanePeeringRange
+	out.Worker = corev1alpha1.CIDR(in.Worker)
+	out.Internal = (*corev1alpha1.CIDR)(unsafe.Pointer(in.Internal))
+	out.InternalProxyOnly = in.InternalProxyOnly
 	return nil
 }
 
@@ -190,7 +669,19 @@ func autoConvert_gcp_NetworkStatus_To_v1alpha1_NetworkStatus(in *gcp.NetworkStat
 	if err := Convert_gcp_VPC_To_v1alpha1_VPC(&in.VPC, &out.VPC, s); err != nil {
 		return err
 	}
+	out.VPCManaged = (*bool)(unsafe.Pointer(in.VPCManaged))
 	out.Subnets = *(*[]Subnet)(unsafe.Pointer(&in.Subnets))
+	out.Routes = *(*[]string)(unsafe.Pointer(&in.Routes))
+	out.InternalAddresses = *(*[]string)(unsafe.Pointer(&in.InternalAddresses))
+	out.NetworkingMode = NetworkingMode(in.NetworkingMode)
+	out.FlowLogsSink = in.FlowLogsSink
+	out.FlowLogsExportDestination = in.FlowLogsExportDestination
+	out.ServicePerimeter = in.ServicePerimeter
+	out.NATGateways = *(*[]NATGateway)(unsafe.Pointer(&in.NATGateways))
+	out.ControlPlanePeeringRange = in.ControlPlanePeeringRange
+	out.Worker = corev1alpha1.CIDR(in.Worker)
+	out.Internal = (*corev1alpha1.CIDR)(unsafe.Pointer(in.Internal))
+	out.InternalProxyOnly = in.InternalProxyOnly
 	return nil
 }
 
@@ -199,9 +690,110 @@ func Convert_gcp_NetworkStatus_To_v1alpha1_NetworkStatus(in *gcp.NetworkStatus,
 	return autoConvert_gcp_NetworkStatus_To_v1alpha1_NetworkStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_PeeringRoutes_To_gcp_PeeringRoutes(in *PeeringRoutes, out *gcp.PeeringRoutes, s conversion.Scope) error {
+	out.ImportCustomRoutes = in.ImportCustomRoutes
+	out.ExportCustomRoutes = in.ExportCustomRoutes
+	return nil
+}
+
+// Convert_v1alpha1_PeeringRoutes_To_gcp_PeeringRoutes is an autogenerated conversion function.
+func Convert_v1alpha1_PeeringRoutes_To_gcp_PeeringRoutes(in *PeeringRoutes, out *gcp.PeeringRoutes, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PeeringRoutes_To_gcp_PeeringRoutes(in, out, s)
+}
+
+func autoConvert_gcp_PeeringRoutes_To_v1alpha1_PeeringRoutes(in *gcp.PeeringRoutes, out *PeeringRoutes, s conversion.Scope) error {
+	out.ImportCustomRoutes = in.ImportCustomRoutes
+	out.ExportCustomRoutes = in.ExportCustomRoutes
+	return nil
+}
+
+// Convert_gcp_PeeringRoutes_To_v1alpha1_PeeringRoutes is an autogenerated conversion function.
+func Convert_gcp_PeeringRoutes_To_v1alpha1_PeeringRoutes(in *gcp.PeeringRoutes, out *PeeringRoutes, s conversion.Scope) error {
+	return autoConvert_gcp_PeeringRoutes_To_v1alpha1_PeeringRoutes(in, out, s)
+}
+
+func autoConvert_v1alpha1_ProxyOnlySubnet_To_gcp_ProxyOnlySubnet(in *ProxyOnlySubnet, out *gcp.ProxyOnlySubnet, s conversion.Scope) error {
+	out.CIDR = corev1alpha1.CIDR(in.CIDR)
+	out.Role = (*gcp.ProxyOnlySubnetRole)(unsafe.Pointer(in.Role))
+	return nil
+}
+
+// Convert_v1alpha1_ProxyOnlySubnet_To_gcp_ProxyOnlySubnet is an autogenerated conversion function.
+func Convert_v1alpha1_ProxyOnlySubnet_To_gcp_ProxyOnlySubnet(in *ProxyOnlySubnet, out *gcp.ProxyOnlySubnet, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ProxyOnlySubnet_To_gcp_ProxyOnlySubnet(in, out, s)
+}
+
+func autoConvert_gcp_ProxyOnlySubnet_To_v1alpha1_ProxyOnlySubnet(in *gcp.ProxyOnlySubnet, out *ProxyOnlySubnet, s conversion.Scope) error {
+	out.CIDR = corev1alpha1.CIDR(in.CIDR)
+	out.Role = (*ProxyOnlySubnetRole)(unsafe.Pointer(in.Role))
+	return nil
+}
+
+// Convert_gcp_ProxyOnlySubnet_To_v1alpha1_ProxyOnlySubnet is an autogenerated conversion function.
+func Convert_gcp_ProxyOnlySubnet_To_v1alpha1_ProxyOnlySubnet(in *gcp.ProxyOnlySubnet, out *ProxyOnlySubnet, s conversion.Scope) error {
+	return autoConvert_gcp_ProxyOnlySubnet_To_v1alpha1_ProxyOnlySubnet(in, out, s)
+}
+
+func autoConvert_v1alpha1_Route_To_gcp_Route(in *Route, out *gcp.Route, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Destination = corev1alpha1.CIDR(in.Destination)
+	out.NextHopIP = (*string)(unsafe.Pointer(in.NextHopIP))
+	out.NextHopVPNTunnel = (*string)(unsafe.Pointer(in.NextHopVPNTunnel))
+	out.NextHopILB = (*string)(unsafe.Pointer(in.NextHopILB))
+	return nil
+}
+
+// Convert_v1alpha1_Route_To_gcp_Route is an autogenerated conversion function.
+func Convert_v1alpha1_Route_To_gcp_Route(in *Route, out *gcp.Route, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Route_To_gcp_Route(in, out, s)
+}
+
+func autoConvert_gcp_Route_To_v1alpha1_Route(in *gcp.Route, out *Route, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Destination = corev1alpha1.CIDR(in.Destination)
+	out.NextHopIP = (*string)(unsafe.Pointer(in.NextHopIP))
+	out.NextHopVPNTunnel = (*string)(unsafe.Pointer(in.NextHopVPNTunnel))
+	out.NextHopILB = (*string)(unsafe.Pointer(in.NextHopILB))
+	return nil
+}
+
+// Convert_gcp_Route_To_v1alpha1_Route is an autogenerated conversion function.
+func Convert_gcp_Route_To_v1alpha1_Route(in *gcp.Route, out *Route, s conversion.Scope) error {
+	return autoConvert_gcp_Route_To_v1alpha1_Route(in, out, s)
+}
+
+func autoConvert_v1alpha1_ServiceAccountConfig_To_gcp_ServiceAccountConfig(in *ServiceAccountConfig, out *gcp.ServiceAccountConfig, s conversion.Scope) error {
+	out.Create = (*bool)(unsafe.Pointer(in.Create))
+	out.WorkloadIdentityUsers = *(*[]string)(unsafe.Pointer(&in.WorkloadIdentityUsers))
+	out.Project = (*string)(unsafe.Pointer(in.Project))
+	out.Email = (*string)(unsafe.Pointer(in.Email))
+	return nil
+}
+
+// Convert_v1alpha1_ServiceAccountConfig_To_gcp_ServiceAccountConfig is an autogenerated conversion function.
+func Convert_v1alpha1_ServiceAccountConfig_To_gcp_ServiceAccountConfig(in *ServiceAccountConfig, out *gcp.ServiceAccountConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ServiceAccountConfig_To_gcp_ServiceAccountConfig(in, out, s)
+}
+
+func autoConvert_gcp_ServiceAccountConfig_To_v1alpha1_ServiceAccountConfig(in *gcp.ServiceAccountConfig, out *ServiceAccountConfig, s conversion.Scope) error {
+	out.Create = (*bool)(unsafe.Pointer(in.Create))
+	out.WorkloadIdentityUsers = *(*[]string)(unsafe.Pointer(&in.WorkloadIdentityUsers))
+	out.Project = (*string)(unsafe.Pointer(in.Project))
+	out.Email = (*string)(unsafe.Pointer(in.Email))
+	return nil
+}
+
+// Convert_gcp_ServiceAccountConfig_To_v1alpha1_ServiceAccountConfig is an autogenerated conversion function.
+func Convert_gcp_ServiceAccountConfig_To_v1alpha1_ServiceAccountConfig(in *gcp.ServiceAccountConfig, out *ServiceAccountConfig, s conversion.Scope) error {
+	return autoConvert_gcp_ServiceAccountConfig_To_v1alpha1_ServiceAccountConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_Subnet_To_gcp_Subnet(in *Subnet, out *gcp.Subnet, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Purpose = gcp.SubnetPurpose(in.Purpose)
+	out.Role = gcp.ProxyOnlySubnetRole(in.Role)
+	out.SelfLink = in.SelfLink
+	out.Region = in.Region
 	return nil
 }
 
@@ -213,6 +805,9 @@ func Convert_v1alpha1_Subnet_To_gcp_Subnet(in *Subnet, out *gcp.Subnet, s conver
 func autoConvert_gcp_Subnet_To_v1alpha1_Subnet(in *gcp.Subnet, out *Subnet, s conversion.Scope) error {
 	out.Purpose = SubnetPurpose(in.Purpose)
 	out.Name = in.Name
+	out.Role = ProxyOnlySubnetRole(in.Role)
+	out.SelfLink = in.SelfLink
+	out.Region = in.Region
 	return nil
 }
 
@@ -221,8 +816,35 @@ func Convert_gcp_Subnet_To_v1alpha1_Subnet(in *gcp.Subnet, out *Subnet, s conver
 	return autoConvert_gcp_Subnet_To_v1alpha1_Subnet(in, out, s)
 }
 
+func autoConvert_v1alpha1_Timeouts_To_gcp_Timeouts(in *Timeouts, out *gcp.Timeouts, s conversion.Scope) error {
+	out.Request = (*v1.Duration)(unsafe.Pointer(in.Request))
+	out.Create = (*v1.Duration)(unsafe.Pointer(in.Create))
+	out.Update = (*v1.Duration)(unsafe.Pointer(in.Update))
+	out.Delete = (*v1.Duration)(unsafe.Pointer(in.Delete))
+	return nil
+}
+
+// Convert_v1alpha1_Timeouts_To_gcp_Timeouts is an autogenerated conversion function.
+func Convert_v1alpha1_Timeouts_To_gcp_Timeouts(in *Timeouts, out *gcp.Timeouts, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Timeouts_To_gcp_Timeouts(in, out, s)
+}
+
+func autoConvert_gcp_Timeouts_To_v1alpha1_Timeouts(in *gcp.Timeouts, out *Timeouts, s conversion.Scope) error {
+	out.Request = (*v1.Duration)(unsafe.Pointer(in.Request))
+	out.Create = (*v1.Duration)(unsafe.Pointer(in.Create))
+	out.Update = (*v1.Duration)(unsafe.Pointer(in.Update))
+	out.Delete = (*v1.Duration)(unsafe.Pointer(in.Delete))
+	return nil
+}
+
+// Convert_gcp_Timeouts_To_v1alpha1_Timeouts is an autogenerated conversion function.
+func Convert_gcp_Timeouts_To_v1alpha1_Timeouts(in *gcp.Timeouts, out *Timeouts, s conversion.Scope) error {
+	return autoConvert_gcp_Timeouts_To_v1alpha1_Timeouts(in, out, s)
+}
+
 func autoConvert_v1alpha1_VPC_To_gcp_VPC(in *VPC, out *gcp.VPC, s conversion.Scope) error {
 	out.Name = in.Name
+	out.CloudRouter = (*gcp.CloudRouter)(unsafe.Pointer(in.CloudRouter))
 	return nil
 }
 
@@ -233,6 +855,7 @@ func Convert_v1alpha1_VPC_To_gcp_VPC(in *VPC, out *gcp.VPC, s conversion.Scope)
 
 func autoConvert_gcp_VPC_To_v1alpha1_VPC(in *gcp.VPC, out *VPC, s conversion.Scope) error {
 	out.Name = in.Name
+	out.CloudRouter = (*CloudRouter)(unsafe.Pointer(in.CloudRouter))
 	return nil
 }
 
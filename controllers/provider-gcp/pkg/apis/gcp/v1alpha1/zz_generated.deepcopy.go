@@ -22,14 +22,285 @@ package v1alpha1
 
 import (
 	corev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AliasIPs) DeepCopyInto(out *AliasIPs) {
+	*out = *in
+	if in.PodsPrefixLength != nil {
+		in, out := &in.PodsPrefixLength, &out.PodsPrefixLength
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ServicesPrefixLength != nil {
+		in, out := &in.ServicesPrefixLength, &out.ServicesPrefixLength
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeCIDRMaskSize != nil {
+		in, out := &in.NodeCIDRMaskSize, &out.NodeCIDRMaskSize
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AliasIPs.
+func (in *AliasIPs) DeepCopy() *AliasIPs {
+	if in == nil {
+		return nil
+	}
+	out := new(AliasIPs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudNAT) DeepCopyInto(out *CloudNAT) {
+	*out = *in
+	if in.MinPortsPerVM != nil {
+		in, out := &in.MinPortsPerVM, &out.MinPortsPerVM
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPortsPerVM != nil {
+		in, out := &in.MaxPortsPerVM, &out.MaxPortsPerVM
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EnableDynamicPortAllocation != nil {
+		in, out := &in.EnableDynamicPortAllocation, &out.EnableDynamicPortAllocation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]SubnetPurpose, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPCount != nil {
+		in, out := &in.IPCount, &out.IPCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NetworkTier != nil {
+		in, out := &in.NetworkTier, &out.NetworkTier
+		*out = new(NetworkTier)
+		**out = **in
+	}
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = make([]CloudNATGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudNAT.
+func (in *CloudNAT) DeepCopy() *CloudNAT {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudNAT)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudNATGateway) DeepCopyInto(out *CloudNATGateway) {
+	*out = *in
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]SubnetPurpose, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPCount != nil {
+		in, out := &in.IPCount, &out.IPCount
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudNATGateway.
+func (in *CloudNATGateway) DeepCopy() *CloudNATGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudNATGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudRouter) DeepCopyInto(out *CloudRouter) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudRouter.
+func (in *CloudRouter) DeepCopy() *CloudRouter {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudRouter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudRouterBGP) DeepCopyInto(out *CloudRouterBGP) {
+	*out = *in
+	if in.AdvertiseMode != nil {
+		in, out := &in.AdvertiseMode, &out.AdvertiseMode
+		*out = new(BGPAdvertiseMode)
+		**out = **in
+	}
+	if in.AdvertisedIPRanges != nil {
+		in, out := &in.AdvertisedIPRanges, &out.AdvertisedIPRanges
+		*out = make([]corev1alpha1.CIDR, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudRouterBGP.
+func (in *CloudRouterBGP) DeepCopy() *CloudRouterBGP {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudRouterBGP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisasterRecoverySubnet) DeepCopyInto(out *DisasterRecoverySubnet) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisasterRecoverySubnet.
+func (in *DisasterRecoverySubnet) DeepCopy() *DisasterRecoverySubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(DisasterRecoverySubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressApplianceSubnet) DeepCopyInto(out *EgressApplianceSubnet) {
+	*out = *in
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]corev1alpha1.CIDR, len(*in))
+		copy(*out, *in)
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressApplianceSubnet.
+func (in *EgressApplianceSubnet) DeepCopy() *EgressApplianceSubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressApplianceSubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallConfig) DeepCopyInto(out *FirewallConfig) {
+	*out = *in
+	if in.PriorityBase != nil {
+		in, out := &in.PriorityBase, &out.PriorityBase
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallConfig.
+func (in *FirewallConfig) DeepCopy() *FirewallConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowLogs) DeepCopyInto(out *FlowLogs) {
+	*out = *in
+	if in.AggregationInterval != nil {
+		in, out := &in.AggregationInterval, &out.AggregationInterval
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExportDestination != nil {
+		in, out := &in.ExportDestination, &out.ExportDestination
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExportFilters != nil {
+		in, out := &in.ExportFilters, &out.ExportFilters
+		*out = make(map[SubnetPurpose]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowLogs.
+func (in *FlowLogs) DeepCopy() *FlowLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InfrastructureConfig) DeepCopyInto(out *InfrastructureConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.Networks.DeepCopyInto(&out.Networks)
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccountConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OutputKeyOverrides != nil {
+		in, out := &in.OutputKeyOverrides, &out.OutputKeyOverrides
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(Timeouts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StateRefresh != nil {
+		in, out := &in.StateRefresh, &out.StateRefresh
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -56,6 +327,30 @@ func (in *InfrastructureStatus) DeepCopyInto(out *InfrastructureStatus) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.Networks.DeepCopyInto(&out.Networks)
+	if in.WorkloadIdentityUsers != nil {
+		in, out := &in.WorkloadIdentityUsers, &out.WorkloadIdentityUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastApplyDuration != nil {
+		in, out := &in.LastApplyDuration, &out.LastApplyDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LastApplyTime != nil {
+		in, out := &in.LastApplyTime, &out.LastApplyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StateSerial != nil {
+		in, out := &in.StateSerial, &out.StateSerial
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MissingOutputs != nil {
+		in, out := &in.MissingOutputs, &out.MissingOutputs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -77,19 +372,137 @@ func (in *InfrastructureStatus) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NATGateway) DeepCopyInto(out *NATGateway) {
+	*out = *in
+	if in.IPs != nil {
+		in, out := &in.IPs, &out.IPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NATGateway.
+func (in *NATGateway) DeepCopy() *NATGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(NATGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkConfig) DeepCopyInto(out *NetworkConfig) {
 	*out = *in
 	if in.VPC != nil {
 		in, out := &in.VPC, &out.VPC
 		*out = new(VPC)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Internal != nil {
 		in, out := &in.Internal, &out.Internal
 		*out = new(corev1alpha1.CIDR)
 		**out = **in
 	}
+	if in.InternalPurpose != nil {
+		in, out := &in.InternalPurpose, &out.InternalPurpose
+		*out = new(SubnetPurpose)
+		**out = **in
+	}
+	if in.SubnetPurposePrefix != nil {
+		in, out := &in.SubnetPurposePrefix, &out.SubnetPurposePrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.InternalAddresses != nil {
+		in, out := &in.InternalAddresses, &out.InternalAddresses
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProxyOnly != nil {
+		in, out := &in.ProxyOnly, &out.ProxyOnly
+		*out = new(ProxyOnlySubnet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrivateServiceConnect != nil {
+		in, out := &in.PrivateServiceConnect, &out.PrivateServiceConnect
+		*out = new(corev1alpha1.CIDR)
+		**out = **in
+	}
+	if in.DisasterRecovery != nil {
+		in, out := &in.DisasterRecovery, &out.DisasterRecovery
+		*out = new(DisasterRecoverySubnet)
+		**out = **in
+	}
+	if in.EgressAppliance != nil {
+		in, out := &in.EgressAppliance, &out.EgressAppliance
+		*out = new(EgressApplianceSubnet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlanePeering != nil {
+		in, out := &in.ControlPlanePeering, &out.ControlPlanePeering
+		*out = new(corev1alpha1.CIDR)
+		**out = **in
+	}
+	if in.ControlPlanePeeringRoutes != nil {
+		in, out := &in.ControlPlanePeeringRoutes, &out.ControlPlanePeeringRoutes
+		*out = new(PeeringRoutes)
+		**out = **in
+	}
+	if in.CloudNAT != nil {
+		in, out := &in.CloudNAT, &out.CloudNAT
+		*out = new(CloudNAT)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudRouterBGP != nil {
+		in, out := &in.CloudRouterBGP, &out.CloudRouterBGP
+		*out = new(CloudRouterBGP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServicePerimeter != nil {
+		in, out := &in.ServicePerimeter, &out.ServicePerimeter
+		*out = new(string)
+		**out = **in
+	}
+	if in.AliasIPs != nil {
+		in, out := &in.AliasIPs, &out.AliasIPs
+		*out = new(AliasIPs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Firewall != nil {
+		in, out := &in.Firewall, &out.Firewall
+		*out = new(FirewallConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FlowLogs != nil {
+		in, out := &in.FlowLogs, &out.FlowLogs
+		*out = new(FlowLogs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VPCDescription != nil {
+		in, out := &in.VPCDescription, &out.VPCDescription
+		*out = new(string)
+		**out = **in
+	}
+	if in.CustomRoutes != nil {
+		in, out := &in.CustomRoutes, &out.CustomRoutes
+		*out = make([]Route, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkingMode != nil {
+		in, out := &in.NetworkingMode, &out.NetworkingMode
+		*out = new(NetworkingMode)
+		**out = **in
+	}
 	return
 }
 
@@ -106,12 +519,39 @@ func (in *NetworkConfig) DeepCopy() *NetworkConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
 	*out = *in
-	out.VPC = in.VPC
+	in.VPC.DeepCopyInto(&out.VPC)
+	if in.VPCManaged != nil {
+		in, out := &in.VPCManaged, &out.VPCManaged
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Subnets != nil {
 		in, out := &in.Subnets, &out.Subnets
 		*out = make([]Subnet, len(*in))
 		copy(*out, *in)
 	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InternalAddresses != nil {
+		in, out := &in.InternalAddresses, &out.InternalAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NATGateways != nil {
+		in, out := &in.NATGateways, &out.NATGateways
+		*out = make([]NATGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Internal != nil {
+		in, out := &in.Internal, &out.Internal
+		*out = new(corev1alpha1.CIDR)
+		**out = **in
+	}
 	return
 }
 
@@ -125,6 +565,110 @@ func (in *NetworkStatus) DeepCopy() *NetworkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PeeringRoutes) DeepCopyInto(out *PeeringRoutes) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PeeringRoutes.
+func (in *PeeringRoutes) DeepCopy() *PeeringRoutes {
+	if in == nil {
+		return nil
+	}
+	out := new(PeeringRoutes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyOnlySubnet) DeepCopyInto(out *ProxyOnlySubnet) {
+	*out = *in
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(ProxyOnlySubnetRole)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyOnlySubnet.
+func (in *ProxyOnlySubnet) DeepCopy() *ProxyOnlySubnet {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyOnlySubnet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Route) DeepCopyInto(out *Route) {
+	*out = *in
+	if in.NextHopIP != nil {
+		in, out := &in.NextHopIP, &out.NextHopIP
+		*out = new(string)
+		**out = **in
+	}
+	if in.NextHopVPNTunnel != nil {
+		in, out := &in.NextHopVPNTunnel, &out.NextHopVPNTunnel
+		*out = new(string)
+		**out = **in
+	}
+	if in.NextHopILB != nil {
+		in, out := &in.NextHopILB, &out.NextHopILB
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Route.
+func (in *Route) DeepCopy() *Route {
+	if in == nil {
+		return nil
+	}
+	out := new(Route)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountConfig) DeepCopyInto(out *ServiceAccountConfig) {
+	*out = *in
+	if in.Create != nil {
+		in, out := &in.Create, &out.Create
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WorkloadIdentityUsers != nil {
+		in, out := &in.WorkloadIdentityUsers, &out.WorkloadIdentityUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Project != nil {
+		in, out := &in.Project, &out.Project
+		*out = new(string)
+		**out = **in
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountConfig.
+func (in *ServiceAccountConfig) DeepCopy() *ServiceAccountConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Subnet) DeepCopyInto(out *Subnet) {
 	*out = *in
@@ -141,9 +685,50 @@ func (in *Subnet) DeepCopy() *Subnet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Timeouts) DeepCopyInto(out *Timeouts) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Create != nil {
+		in, out := &in.Create, &out.Create
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Update != nil {
+		in, out := &in.Update, &out.Update
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Delete != nil {
+		in, out := &in.Delete, &out.Delete
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Timeouts.
+func (in *Timeouts) DeepCopy() *Timeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(Timeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VPC) DeepCopyInto(out *VPC) {
 	*out = *in
+	if in.CloudRouter != nil {
+		in, out := &in.CloudRouter, &out.CloudRouter
+		*out = new(CloudRouter)
+		**out = **in
+	}
 	return
 }
 
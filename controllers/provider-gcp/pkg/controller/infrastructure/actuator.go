@@ -16,8 +16,10 @@ package infrastructure
 
 import (
 	"context"
+	"time"
 
 	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
+	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal"
 	infrainternal "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/infrastructure"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	"github.com/gardener/gardener-extensions/pkg/controller/infrastructure"
@@ -26,21 +28,73 @@ import (
 	"github.com/gardener/gardener/pkg/chartrenderer"
 	"github.com/gardener/gardener/pkg/operation/terraformer"
 
+	"github.com/go-logr/logr"
+
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/log"
 )
 
 type actuator struct {
+	logger logr.Logger
+
 	client        client.Client
 	restConfig    *rest.Config
 	chartRenderer chartrenderer.Interface
+
+	allowedMTURange   infrainternal.MTURange
+	stateObjectSuffix string
+
+	defaultFlowLogsAggregationInterval string
+}
+
+// NewActuator creates a new infrastructure.Actuator that only allows MTUs within the given range. The given
+// stateObjectSuffix is appended to the key of the Terraformer state object, it has to be validated with
+// infrainternal.ValidateStateObjectSuffix. The given defaultFlowLogsAggregationInterval is applied to flow logs
+// enabled without an aggregation interval, unless it is empty.
+func NewActuator(
+	allowedMTURange infrainternal.MTURange,
+	stateObjectSuffix string,
+	defaultFlowLogsAggregationInterval string,
+) infrastructure.Actuator {
+	return &actuator{
+		logger:                             log.Log.WithName("gcp-infrastructure-actuator"),
+		allowedMTURange:                    allowedMTURange,
+		stateObjectSuffix:                  stateObjectSuffix,
+		defaultFlowLogsAggregationInterval: defaultFlowLogsAggregationInterval,
+	}
+}
+
+// newTerraformer creates the Terraformer of the given Infrastructure, whose state object carries the state object
+// suffix of the actuator. The state of an Infrastructure reconciled with another suffix so far is migrated first.
+func (a *actuator) newTerraformer(
+	ctx context.Context,
+	serviceAccount *internal.ServiceAccount,
+	infra *extensionsv1alpha1.Infrastructure,
+	refresh bool,
+) (*terraformer.Terraformer, error) {
+	previousSuffix, err := previousStateObjectSuffix(infra)
+	if err != nil {
+		return nil, err
+	}
+	if err := infrainternal.MigrateTerraformerState(ctx, a.client, infra.Namespace, infra.Name, infrainternal.TerraformerPurpose, previousSuffix, a.stateObjectSuffix); err != nil {
+		return nil, err
+	}
+
+	purpose := infrainternal.TerraformerPurposeWithSuffix(infrainternal.TerraformerPurpose, a.stateObjectSuffix)
+	return internal.NewTerraformer(a.restConfig, serviceAccount, purpose, infra.Namespace, infra.Name, refresh)
 }
 
-// NewActuator creates a new infrastructure.Actuator.
-func NewActuator() infrastructure.Actuator {
-	return &actuator{}
+// previousStateObjectSuffix returns the state object suffix the given Infrastructure was last reconciled with. It is
+// empty if the Infrastructure has not been reconciled yet or was reconciled before the suffix was recorded.
+func previousStateObjectSuffix(infra *extensionsv1alpha1.Infrastructure) (string, error) {
+	status, err := internal.InfrastructureStatusFromInfrastructure(infra)
+	if err != nil || status == nil {
+		return "", err
+	}
+	return status.StateObjectSuffix, nil
 }
 
 // InjectClient implements inject.Client.
@@ -67,11 +121,21 @@ func (a *actuator) updateProviderStatus(
 	tf *terraformer.Terraformer,
 	infra *extensionsv1alpha1.Infrastructure,
 	config *gcpv1alpha1.InfrastructureConfig,
+	applyDuration time.Duration,
+	applyTime time.Time,
 ) error {
-	status, err := infrainternal.ComputeStatus(tf, config)
+	status, err := infrainternal.ComputeFullStatus(tf, config)
+	if err != nil {
+		return err
+	}
+	infrainternal.RecordApplyMetrics(status, applyDuration, applyTime)
+
+	serial, err := infrainternal.ReadStateSerial(tf)
 	if err != nil {
 		return err
 	}
+	status.StateSerial = &serial
+	status.StateObjectSuffix = a.stateObjectSuffix
 
 	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, a.client, infra, func() error {
 		infra.Status.ProviderStatus = &runtime.RawExtension{Object: status}
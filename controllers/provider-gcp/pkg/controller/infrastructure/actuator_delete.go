@@ -81,7 +81,12 @@ func (a *actuator) Delete(ctx context.Context, infra *extensionsv1alpha1.Infrast
 		return err
 	}
 
-	tf, err := internal.NewTerraformer(a.restConfig, serviceAccount, infrastructure.TerraformerPurpose, infra.Namespace, infra.Name)
+	previousSuffix, err := previousStateObjectSuffix(infra)
+	if err != nil {
+		return err
+	}
+
+	tf, err := a.newTerraformer(ctx, serviceAccount, infra, true)
 	if err != nil {
 		return err
 	}
@@ -111,12 +116,21 @@ func (a *actuator) Delete(ctx context.Context, infra *extensionsv1alpha1.Infrast
 				DoIf(configExists),
 		})
 
-		_ = g.Add(flow.Task{
+		destroyInfrastructure = g.Add(flow.Task{
 			Name:         "Destroying Shoot infrastructure",
 			Fn:           flow.SimpleTaskFn(tf.Destroy),
 			Dependencies: flow.NewTaskIDs(destroyKubernetesFirewallRules, destroyKubernetesRoutes),
 		})
 
+		_ = g.Add(flow.Task{
+			Name: "Deleting the Terraformer objects of the previous state object suffix",
+			Fn: flow.TaskFn(func(ctx context.Context) error {
+				return infrastructure.DeleteTerraformerObjects(ctx, a.client, infra.Namespace, infra.Name, infrastructure.TerraformerPurposeWithSuffix(infrastructure.TerraformerPurpose, previousSuffix))
+			}).
+				DoIf(previousSuffix != a.stateObjectSuffix),
+			Dependencies: flow.NewTaskIDs(destroyInfrastructure),
+		})
+
 		f = g.Compile()
 	)
 
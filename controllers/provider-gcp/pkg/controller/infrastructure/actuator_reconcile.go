@@ -17,11 +17,15 @@ package infrastructure
 import (
 	"context"
 	"fmt"
+	"time"
+
 	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal"
+	gcpclient "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/client"
 	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/infrastructure"
 	"github.com/gardener/gardener-extensions/pkg/controller"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/operation/terraformer"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 // Reconcile implements infrastructure.Actuator.
@@ -30,22 +34,60 @@ func (a *actuator) Reconcile(ctx context.Context, infra *extensionsv1alpha1.Infr
 	if err != nil {
 		return err
 	}
+	infrastructure.ApplyDefaultFlowLogsAggregationInterval(config, a.defaultFlowLogsAggregationInterval)
+
+	if errs := infrastructure.ValidateMTU(&config.Networks, a.allowedMTURange, field.NewPath("networks")); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	if err := infrastructure.ValidateNetworkingConsistency(config, cluster); err != nil {
+		return err
+	}
+
+	status, err := internal.InfrastructureStatusFromInfrastructure(infra)
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		if errs := infrastructure.ValidateInternalSubnetCapacity(&config.Networks, infrastructure.DefaultMinInternalSubnetAddresses, field.NewPath("networks")); len(errs) > 0 {
+			return errs.ToAggregate()
+		}
+	}
+	if oldNetworks := infrastructure.PreviousNetworkConfig(status); oldNetworks != nil {
+		if errs := infrastructure.ValidateNetworkConfigUpdate(&config.Networks, oldNetworks, field.NewPath("networks")); len(errs) > 0 {
+			return errs.ToAggregate()
+		}
+	}
+	if infrastructure.IsVPCModeTransition(config, status) {
+		a.logger.Info("VPC switches between being created and being referenced, the previous VPC may be destroyed",
+			"infrastructure", fmt.Sprintf("%s/%s", infra.Namespace, infra.Name), "previousVPC", status.Networks.VPC.Name)
+	}
 
 	serviceAccount, err := infrastructure.GetServiceAccountFromInfrastructure(ctx, a.client, infra)
 	if err != nil {
 		return err
 	}
 
+	if config.Networks.VPC != nil {
+		gcpClient, err := gcpclient.NewFromServiceAccount(ctx, serviceAccount.Raw)
+		if err != nil {
+			return err
+		}
+		if err := infrastructure.CheckVPCSubnetMode(ctx, gcpClient, serviceAccount.ProjectID, config); err != nil {
+			return err
+		}
+	}
+
 	terraformFiles, err := infrastructure.RenderTerraformerChart(a.chartRenderer, infra, serviceAccount, config, cluster)
 	if err != nil {
 		return err
 	}
 
-	tf, err := internal.NewTerraformer(a.restConfig, serviceAccount, infrastructure.TerraformerPurpose, infra.Namespace, infra.Name)
+	tf, err := a.newTerraformer(ctx, serviceAccount, infra, infrastructure.StateRefresh(config))
 	if err != nil {
 		return err
 	}
 
+	applyStart := time.Now()
 	err = tf.
 		InitializeWith(terraformer.DefaultInitializer(a.client, terraformFiles.Main, terraformFiles.Variables, terraformFiles.TFVars)).
 		Apply()
@@ -53,5 +95,7 @@ func (a *actuator) Reconcile(ctx context.Context, infra *extensionsv1alpha1.Infr
 		return fmt.Errorf("failed to update the provider: %v", err)
 	}
 
-	return a.updateProviderStatus(ctx, tf, infra, config)
+	applyTime := time.Now()
+
+	return a.updateProviderStatus(ctx, tf, infra, config, applyTime.Sub(applyStart), applyTime)
 }
@@ -16,6 +16,7 @@ package infrastructure
 
 import (
 	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/gcp"
+	infrainternal "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/infrastructure"
 	"github.com/gardener/gardener-extensions/pkg/controller/infrastructure"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -24,7 +25,9 @@ import (
 
 var (
 	// DefaultAddOptions are the default AddOptions for AddToManager.
-	DefaultAddOptions = AddOptions{}
+	DefaultAddOptions = AddOptions{
+		AllowedMTURange: infrainternal.DefaultMTURange,
+	}
 )
 
 // AddOptions are options to apply when adding the gcp infrastructure controller to the manager.
@@ -33,13 +36,29 @@ type AddOptions struct {
 	Controller controller.Options
 	// IgnoreOperationAnnotation specifies whether to ignore the operation annotation or not.
 	IgnoreOperationAnnotation bool
+	// AllowedMTURange is the range of MTUs allowed for the VPCs.
+	AllowedMTURange infrainternal.MTURange
+	// StateObjectSuffix is appended to the key of the Terraformer state object, e.g. to identify the version of
+	// the extension. The suffix each Infrastructure was last reconciled with is recorded in its status, and its
+	// state is migrated to the state object with the new suffix when the suffix changes.
+	StateObjectSuffix string
+	// DefaultFlowLogsAggregationInterval is the aggregation interval of flow logs enabled without one, e.g.
+	// INTERVAL_15_MIN to minimize the cost of the flow logs. If it is empty, the default of GCP applies.
+	DefaultFlowLogsAggregationInterval string
 }
 
 // AddToManagerWithOptions adds a controller with the given AddOptions to the given manager.
 // The opts.Reconciler is being set with a newly instantiated actuator.
 func AddToManagerWithOptions(mgr manager.Manager, options AddOptions) error {
+	if err := infrainternal.ValidateStateObjectSuffix(options.StateObjectSuffix); err != nil {
+		return err
+	}
+	if err := infrainternal.ValidateDefaultFlowLogsAggregationInterval(options.DefaultFlowLogsAggregationInterval); err != nil {
+		return err
+	}
+
 	return infrastructure.Add(mgr, infrastructure.AddArgs{
-		Actuator:          infrastructure.OperationAnnotationWrapper(NewActuator()),
+		Actuator:          infrastructure.OperationAnnotationWrapper(NewActuator(options.AllowedMTURange, options.StateObjectSuffix, options.DefaultFlowLogsAggregationInterval)),
 		ControllerOptions: options.Controller,
 		Predicates:        infrastructure.DefaultPredicates(mgr.GetClient(), gcp.Type, options.IgnoreOperationAnnotation),
 	})
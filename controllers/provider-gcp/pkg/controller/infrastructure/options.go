@@ -0,0 +1,159 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+
+	infrainternal "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/infrastructure"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	// MTUMinFlag is the name of the command line flag to specify the smallest allowed MTU.
+	MTUMinFlag = "mtu-min"
+	// MTUMaxFlag is the name of the command line flag to specify the largest allowed MTU.
+	MTUMaxFlag = "mtu-max"
+	// StateObjectSuffixFlag is the name of the command line flag to specify the suffix of the Terraformer state
+	// object.
+	StateObjectSuffixFlag = "state-object-suffix"
+	// DefaultFlowLogsAggregationIntervalFlag is the name of the command line flag to specify the aggregation
+	// interval of flow logs enabled without one.
+	DefaultFlowLogsAggregationIntervalFlag = "default-flow-logs-aggregation-interval"
+)
+
+// MTUOptions are command line options to narrow down the MTUs allowed for the VPCs.
+type MTUOptions struct {
+	// Min is the smallest allowed MTU.
+	Min int32
+	// Max is the largest allowed MTU.
+	Max int32
+
+	config *MTUConfig
+}
+
+// AddFlags implements Flagger.AddFlags.
+func (m *MTUOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.Int32Var(&m.Min, MTUMinFlag, infrainternal.DefaultMTURange.Min, "Smallest MTU allowed for the VPCs.")
+	fs.Int32Var(&m.Max, MTUMaxFlag, infrainternal.DefaultMTURange.Max, "Largest MTU allowed for the VPCs.")
+}
+
+// Complete implements Completer.Complete.
+func (m *MTUOptions) Complete() error {
+	if !infrainternal.DefaultMTURange.Contains(m.Min) || !infrainternal.DefaultMTURange.Contains(m.Max) {
+		return fmt.Errorf("allowed MTU range [%d, %d] must be within [%d, %d]", m.Min, m.Max, infrainternal.DefaultMTURange.Min, infrainternal.DefaultMTURange.Max)
+	}
+	if m.Min > m.Max {
+		return fmt.Errorf("smallest allowed MTU %d is greater than the largest allowed MTU %d", m.Min, m.Max)
+	}
+
+	m.config = &MTUConfig{infrainternal.MTURange{Min: m.Min, Max: m.Max}}
+	return nil
+}
+
+// Completed returns the completed MTUConfig. Only call this if `Complete` was successful.
+func (m *MTUOptions) Completed() *MTUConfig {
+	return m.config
+}
+
+// MTUConfig is a completed MTU configuration.
+type MTUConfig struct {
+	// AllowedMTURange is the range of MTUs allowed for the VPCs.
+	AllowedMTURange infrainternal.MTURange
+}
+
+// Apply sets the values of this MTUConfig in the given MTURange.
+func (m *MTUConfig) Apply(allowed *infrainternal.MTURange) {
+	*allowed = m.AllowedMTURange
+}
+
+// StateObjectOptions are command line options for the Terraformer state objects of the Infrastructures.
+type StateObjectOptions struct {
+	// Suffix is appended to the key of the Terraformer state object.
+	Suffix string
+
+	config *StateObjectConfig
+}
+
+// AddFlags implements Flagger.AddFlags.
+func (s *StateObjectOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&s.Suffix, StateObjectSuffixFlag, "", "Suffix appended to the key of the Terraformer state object, e.g. to identify the version of the extension.")
+}
+
+// Complete implements Completer.Complete.
+func (s *StateObjectOptions) Complete() error {
+	if err := infrainternal.ValidateStateObjectSuffix(s.Suffix); err != nil {
+		return err
+	}
+
+	s.config = &StateObjectConfig{s.Suffix}
+	return nil
+}
+
+// Completed returns the completed StateObjectConfig. Only call this if `Complete` was successful.
+func (s *StateObjectOptions) Completed() *StateObjectConfig {
+	return s.config
+}
+
+// StateObjectConfig is a completed Terraformer state object configuration.
+type StateObjectConfig struct {
+	// Suffix is appended to the key of the Terraformer state object.
+	Suffix string
+}
+
+// Apply sets the values of this StateObjectConfig in the given suffix.
+func (s *StateObjectConfig) Apply(suffix *string) {
+	*suffix = s.Suffix
+}
+
+// FlowLogsOptions are command line options for the flow logs of the VPCs.
+type FlowLogsOptions struct {
+	// DefaultAggregationInterval is the aggregation interval of flow logs enabled without one.
+	DefaultAggregationInterval string
+
+	config *FlowLogsConfig
+}
+
+// AddFlags implements Flagger.AddFlags.
+func (f *FlowLogsOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&f.DefaultAggregationInterval, DefaultFlowLogsAggregationIntervalFlag, "", fmt.Sprintf("Aggregation interval of flow logs enabled without one, one of %v. Defaults to the one of GCP.", infrainternal.FlowLogsAggregationIntervals))
+}
+
+// Complete implements Completer.Complete.
+func (f *FlowLogsOptions) Complete() error {
+	if err := infrainternal.ValidateDefaultFlowLogsAggregationInterval(f.DefaultAggregationInterval); err != nil {
+		return err
+	}
+
+	f.config = &FlowLogsConfig{f.DefaultAggregationInterval}
+	return nil
+}
+
+// Completed returns the completed FlowLogsConfig. Only call this if `Complete` was successful.
+func (f *FlowLogsOptions) Completed() *FlowLogsConfig {
+	return f.config
+}
+
+// FlowLogsConfig is a completed flow logs configuration.
+type FlowLogsConfig struct {
+	// DefaultAggregationInterval is the aggregation interval of flow logs enabled without one.
+	DefaultAggregationInterval string
+}
+
+// Apply sets the values of this FlowLogsConfig in the given default aggregation interval.
+func (f *FlowLogsConfig) Apply(interval *string) {
+	*interval = f.DefaultAggregationInterval
+}
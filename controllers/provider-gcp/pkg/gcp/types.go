@@ -21,4 +21,8 @@ const (
 
 	// Type is the type of resources managed by the GCP actuator.
 	Type = "gcp"
+
+	// NetworkingModeAnnotation is the annotation of a shoot declaring the networking mode its network plugin
+	// expects from the infrastructure.
+	NetworkingModeAnnotation = "gcp.provider.extensions.gardener.cloud/networking-mode"
 )
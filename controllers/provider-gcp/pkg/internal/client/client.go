@@ -35,6 +35,10 @@ type routesService struct {
 	routesService *compute.RoutesService
 }
 
+type networksService struct {
+	networksService *compute.NetworksService
+}
+
 type firewallsListCall struct {
 	firewallsListCall *compute.FirewallsListCall
 }
@@ -43,6 +47,10 @@ type routesListCall struct {
 	routesListCall *compute.RoutesListCall
 }
 
+type networksGetCall struct {
+	networksGetCall *compute.NetworksGetCall
+}
+
 type firewallsDeleteCall struct {
 	firewallsDeleteCall *compute.FirewallsDeleteCall
 }
@@ -82,6 +90,11 @@ func (c *client) Routes() RoutesService {
 	return &routesService{c.service.Routes}
 }
 
+// Networks implements Interface.
+func (c *client) Networks() NetworksService {
+	return &networksService{c.service.Networks}
+}
+
 // List implements FirewallsService.
 func (f *firewallsService) List(projectID string) FirewallsListCall {
 	return &firewallsListCall{f.firewallsService.List(projectID)}
@@ -131,3 +144,18 @@ func (c *firewallsDeleteCall) Do(opts ...googleapi.CallOption) (*compute.Operati
 func (c *routesDeleteCall) Do(opts ...googleapi.CallOption) (*compute.Operation, error) {
 	return c.routesDeleteCall.Do(opts...)
 }
+
+// Get implements NetworksService.
+func (n *networksService) Get(projectID, network string) NetworksGetCall {
+	return &networksGetCall{n.networksService.Get(projectID, network)}
+}
+
+// Context implements NetworksGetCall.
+func (c *networksGetCall) Context(ctx context.Context) NetworksGetCall {
+	return &networksGetCall{c.networksGetCall.Context(ctx)}
+}
+
+// Do implements NetworksGetCall.
+func (c *networksGetCall) Do(opts ...googleapi.CallOption) (*compute.Network, error) {
+	return c.networksGetCall.Do(opts...)
+}
@@ -27,6 +27,8 @@ type Interface interface {
 	Firewalls() FirewallsService
 	// Routes retrieves the GCP routes service.
 	Routes() RoutesService
+	// Networks retrieves the GCP networks service.
+	Networks() NetworksService
 }
 
 // FirewallsService is the interface for the GCP firewalls service.
@@ -45,6 +47,12 @@ type RoutesService interface {
 	Delete(projectID, route string) RoutesDeleteCall
 }
 
+// NetworksService is the interface for the GCP networks service.
+type NetworksService interface {
+	// Get initiates a NetworksGetCall.
+	Get(projectID, network string) NetworksGetCall
+}
+
 // FirewallsListCall is a list call to the firewalls service.
 type FirewallsListCall interface {
 	// Pages runs the given function on the paginated result of listing the firewalls.
@@ -72,3 +80,11 @@ type RoutesDeleteCall interface {
 	// Context sets the context for the deletion call.
 	Context(context.Context) RoutesDeleteCall
 }
+
+// NetworksGetCall is a get call to the networks service.
+type NetworksGetCall interface {
+	// Do executes the get call.
+	Do(opts ...googleapi.CallOption) (*compute.Network, error)
+	// Context sets the context for the get call.
+	Context(context.Context) NetworksGetCall
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+	"strings"
+
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
+	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal"
+	"github.com/gardener/gardener-extensions/pkg/controller"
+)
+
+// DescribeProvisioning returns a human-readable summary of the resources that will be provisioned
+// for the given InfrastructureConfig, e.g. for reviewing configuration changes.
+func DescribeProvisioning(config *gcpv1alpha1.InfrastructureConfig, account *internal.ServiceAccount, cluster *controller.Cluster) string {
+	var parts []string
+
+	if config.Networks.VPC != nil {
+		parts = append(parts, fmt.Sprintf("Use existing VPC '%s'", config.Networks.VPC.Name))
+	} else {
+		parts = append(parts, fmt.Sprintf("Create VPC '%s'", cluster.Shoot.Status.TechnicalID))
+	}
+
+	parts = append(parts, fmt.Sprintf("nodes subnet %s", config.Networks.Worker))
+	if config.Networks.Internal != nil {
+		parts = append(parts, fmt.Sprintf("internal subnet %s", *config.Networks.Internal))
+	}
+	if config.Networks.CloudNAT != nil {
+		parts = append(parts, "Cloud NAT")
+	}
+	if createServiceAccount(config) {
+		parts = append(parts, "1 service account")
+	}
+
+	return fmt.Sprintf("%s in project '%s'", strings.Join(parts, ", "), account.ProjectID)
+}
+
+// DescribeDeletion returns a human-readable summary of the resources of the given InfrastructureStatus that will
+// be destroyed when deleting the infrastructure and of the user-owned resources referenced by the given
+// InfrastructureConfig that will be preserved, e.g. for reviewing a deletion beforehand.
+func DescribeDeletion(config *gcpv1alpha1.InfrastructureConfig, status *gcpv1alpha1.InfrastructureStatus) string {
+	var destroyed, preserved []string
+
+	vpc := status.Networks.VPC
+	if createVPC(config) {
+		destroyed = append(destroyed, fmt.Sprintf("VPC '%s'", vpc.Name))
+	} else {
+		preserved = append(preserved, fmt.Sprintf("VPC '%s'", vpc.Name))
+	}
+	for _, subnet := range status.Networks.Subnets {
+		destroyed = append(destroyed, fmt.Sprintf("subnet '%s'", subnet.Name))
+	}
+	for _, route := range status.Networks.Routes {
+		destroyed = append(destroyed, fmt.Sprintf("route '%s'", route))
+	}
+	for _, address := range status.Networks.InternalAddresses {
+		destroyed = append(destroyed, fmt.Sprintf("internal address %s", address))
+	}
+	if vpc.CloudRouter != nil {
+		if createVPC(config) {
+			destroyed = append(destroyed, fmt.Sprintf("Cloud Router '%s'", vpc.CloudRouter.Name))
+		} else {
+			preserved = append(preserved, fmt.Sprintf("Cloud Router '%s'", vpc.CloudRouter.Name))
+		}
+	}
+	if config.Networks.Firewall != nil && config.Networks.Firewall.Unmanaged {
+		preserved = append(preserved, "firewall rules")
+	} else {
+		destroyed = append(destroyed, "firewall rules")
+	}
+	if status.Networks.FlowLogsSink != "" {
+		destroyed = append(destroyed, fmt.Sprintf("logging sink '%s'", status.Networks.FlowLogsSink))
+	}
+	if status.Networks.ControlPlanePeeringRange != "" {
+		destroyed = append(destroyed, fmt.Sprintf("control plane peering range %s", status.Networks.ControlPlanePeeringRange))
+	}
+	if status.ServiceAccountEmail != "" {
+		if createServiceAccount(config) {
+			destroyed = append(destroyed, fmt.Sprintf("service account '%s'", status.ServiceAccountEmail))
+		} else {
+			preserved = append(preserved, fmt.Sprintf("service account '%s'", status.ServiceAccountEmail))
+		}
+	}
+
+	var parts []string
+	if len(destroyed) > 0 {
+		parts = append(parts, fmt.Sprintf("destroy %s", strings.Join(destroyed, ", ")))
+	}
+	if len(preserved) > 0 {
+		parts = append(parts, fmt.Sprintf("preserve %s", strings.Join(preserved, ", ")))
+	}
+	summary := strings.Join(parts, "; ")
+	if len(summary) == 0 {
+		return summary
+	}
+	return strings.ToUpper(summary[:1]) + summary[1:]
+}
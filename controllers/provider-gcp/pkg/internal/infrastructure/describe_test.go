@@ -0,0 +1,126 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
+	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal"
+	"github.com/gardener/gardener-extensions/pkg/controller"
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Describe", func() {
+	var (
+		config         *gcpv1alpha1.InfrastructureConfig
+		cluster        *controller.Cluster
+		serviceAccount *internal.ServiceAccount
+	)
+
+	BeforeEach(func() {
+		internalCIDR := gardencorev1alpha1.CIDR("192.168.0.0/16")
+
+		config = &gcpv1alpha1.InfrastructureConfig{
+			Networks: gcpv1alpha1.NetworkConfig{
+				Internal: &internalCIDR,
+				Worker:   gardencorev1alpha1.CIDR("10.1.0.0/16"),
+			},
+		}
+
+		cluster = &controller.Cluster{
+			Shoot: &gardenv1beta1.Shoot{
+				Status: gardenv1beta1.ShootStatus{
+					TechnicalID: "shoot--foo--bar",
+				},
+			},
+		}
+
+		serviceAccount = &internal.ServiceAccount{ProjectID: "project"}
+	})
+
+	Describe("#DescribeProvisioning", func() {
+		It("should describe the VPC, subnets and service account to create", func() {
+			Expect(DescribeProvisioning(config, serviceAccount, cluster)).To(Equal(
+				"Create VPC 'shoot--foo--bar', nodes subnet 10.1.0.0/16, internal subnet 192.168.0.0/16, 1 service account in project 'project'",
+			))
+		})
+
+		It("should describe an existing VPC without internal subnet", func() {
+			config.Networks.VPC = &gcpv1alpha1.VPC{Name: "vpc"}
+			config.Networks.Internal = nil
+
+			Expect(DescribeProvisioning(config, serviceAccount, cluster)).To(Equal(
+				"Use existing VPC 'vpc', nodes subnet 10.1.0.0/16, 1 service account in project 'project'",
+			))
+		})
+	})
+
+	Describe("#DescribeDeletion", func() {
+		var status *gcpv1alpha1.InfrastructureStatus
+
+		BeforeEach(func() {
+			status = &gcpv1alpha1.InfrastructureStatus{
+				Networks: gcpv1alpha1.NetworkStatus{
+					VPC: gcpv1alpha1.VPC{Name: "shoot--foo--bar"},
+					Subnets: []gcpv1alpha1.Subnet{
+						{Name: "shoot--foo--bar-nodes", Purpose: gcpv1alpha1.PurposeNodes},
+						{Name: "shoot--foo--bar-internal", Purpose: gcpv1alpha1.PurposeInternal},
+					},
+				},
+				ServiceAccountEmail: "shoot--foo--bar@project.iam.gserviceaccount.com",
+			}
+		})
+
+		It("should describe a created VPC and service account as destroyed", func() {
+			Expect(DescribeDeletion(config, status)).To(Equal(
+				"Destroy VPC 'shoot--foo--bar', subnet 'shoot--foo--bar-nodes', subnet 'shoot--foo--bar-internal', firewall rules, service account 'shoot--foo--bar@project.iam.gserviceaccount.com'",
+			))
+		})
+
+		It("should describe a user-owned VPC and Cloud Router as preserved", func() {
+			config.Networks.VPC = &gcpv1alpha1.VPC{Name: "vpc", CloudRouter: &gcpv1alpha1.CloudRouter{Name: "router"}}
+			status.Networks.VPC = *config.Networks.VPC
+
+			Expect(DescribeDeletion(config, status)).To(Equal(
+				"Destroy subnet 'shoot--foo--bar-nodes', subnet 'shoot--foo--bar-internal', firewall rules, service account 'shoot--foo--bar@project.iam.gserviceaccount.com'; preserve VPC 'vpc', Cloud Router 'router'",
+			))
+		})
+
+		It("should describe unmanaged firewall rules and a pre-provisioned service account as preserved", func() {
+			createServiceAccount := false
+			config.ServiceAccount = &gcpv1alpha1.ServiceAccountConfig{Create: &createServiceAccount}
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{Unmanaged: true}
+
+			Expect(DescribeDeletion(config, status)).To(Equal(
+				"Destroy VPC 'shoot--foo--bar', subnet 'shoot--foo--bar-nodes', subnet 'shoot--foo--bar-internal'; preserve firewall rules, service account 'shoot--foo--bar@project.iam.gserviceaccount.com'",
+			))
+		})
+
+		It("should only describe the preserved resources if nothing is destroyed", func() {
+			createServiceAccount := false
+			config.ServiceAccount = &gcpv1alpha1.ServiceAccountConfig{Create: &createServiceAccount}
+			config.Networks.VPC = &gcpv1alpha1.VPC{Name: "vpc"}
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{Unmanaged: true}
+			status.Networks.VPC = *config.Networks.VPC
+			status.Networks.Subnets = nil
+
+			Expect(DescribeDeletion(config, status)).To(Equal(
+				"Preserve VPC 'vpc', firewall rules, service account 'shoot--foo--bar@project.iam.gserviceaccount.com'",
+			))
+		})
+	})
+})
@@ -16,12 +16,20 @@ package infrastructure
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
 	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal"
 	gcpclient "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/client"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/operation/terraformer"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -112,7 +120,79 @@ func CleanupKubernetesRoutes(ctx context.Context, client gcpclient.Interface, pr
 	return DeleteRoutes(ctx, client, projectID, routeNames)
 }
 
+// CheckVPCSubnetMode checks that the existing VPC referenced by the given InfrastructureConfig is in custom subnet
+// mode. The subnets of the infrastructure cannot be created alongside the ones GCP creates in each region of an
+// auto mode VPC. Nothing is checked if the VPC is created.
+func CheckVPCSubnetMode(ctx context.Context, client gcpclient.Interface, projectID string, config *gcpv1alpha1.InfrastructureConfig) error {
+	if config.Networks.VPC == nil {
+		return nil
+	}
+
+	name := config.Networks.VPC.Name
+	network, err := client.Networks().Get(projectID, name).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("could not get VPC %q: %v", name, err)
+	}
+	if network.AutoCreateSubnetworks {
+		return fmt.Errorf("VPC %q is in auto subnet mode, which conflicts with the subnets of the infrastructure; "+
+			"convert it to custom subnet mode first, e.g. with 'gcloud compute networks update %s --switch-to-custom-subnet-mode'", name, name)
+	}
+	return nil
+}
+
 // GetServiceAccountFromInfrastructure retrieves the ServiceAccount from the Secret referenced in the given Infrastructure.
 func GetServiceAccountFromInfrastructure(ctx context.Context, c client.Client, config *extensionsv1alpha1.Infrastructure) (*internal.ServiceAccount, error) {
 	return internal.GetServiceAccount(ctx, c, config.Spec.SecretRef.Namespace, config.Spec.SecretRef.Name)
 }
+
+// MigrateTerraformerState copies the terraform state of the Terraformer of the given purpose with the previous suffix
+// for the Infrastructure with the given name to the state object of the purpose with the given suffix, unless the
+// latter already holds a state. The objects of the previous suffix are deleted once the state has been copied. This
+// keeps track of the resources of existing Infrastructures when the state object suffix changes.
+func MigrateTerraformerState(ctx context.Context, c client.Client, namespace, name, purpose, previousSuffix, suffix string) error {
+	if previousSuffix == suffix {
+		return nil
+	}
+
+	stateName := TerraformerStateName(name, TerraformerPurposeWithSuffix(purpose, suffix))
+	currentState := &corev1.ConfigMap{}
+	if err := c.Get(ctx, kutil.Key(namespace, stateName), currentState); err == nil {
+		if len(currentState.Data[terraformer.StateKey]) > 0 {
+			return nil
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	previousPurpose := TerraformerPurposeWithSuffix(purpose, previousSuffix)
+	previousState := &corev1.ConfigMap{}
+	if err := c.Get(ctx, kutil.Key(namespace, TerraformerStateName(name, previousPurpose)), previousState); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if len(previousState.Data[terraformer.StateKey]) == 0 {
+		return nil
+	}
+
+	if _, err := terraformer.CreateOrUpdateStateConfigMap(ctx, c, namespace, stateName, previousState.Data[terraformer.StateKey]); err != nil {
+		return err
+	}
+	return DeleteTerraformerObjects(ctx, c, namespace, name, previousPurpose)
+}
+
+// DeleteTerraformerObjects deletes the state and configuration ConfigMaps and the variables Secret of the
+// Terraformer of the given purpose for the Infrastructure with the given name, if they exist.
+func DeleteTerraformerObjects(ctx context.Context, c client.Client, namespace, name, purpose string) error {
+	for _, obj := range []runtime.Object{
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: TerraformerStateName(name, purpose)}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: TerraformerConfigName(name, purpose)}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: TerraformerVariablesName(name, purpose)}},
+	} {
+		if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
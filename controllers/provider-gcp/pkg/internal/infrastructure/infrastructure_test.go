@@ -18,11 +18,20 @@ import (
 	"context"
 	"fmt"
 
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
 	mockgcpclient "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/mock/client"
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+	"github.com/gardener/gardener/pkg/operation/terraformer"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("Infrastructure", func() {
@@ -155,4 +164,189 @@ var _ = Describe("Infrastructure", func() {
 			Expect(DeleteRoutes(ctx, client, projectID, routeNames)).To(Succeed())
 		})
 	})
+
+	Describe("#CheckVPCSubnetMode", func() {
+		var (
+			ctx       = context.TODO()
+			projectID = "foo"
+			vpcName   = "vpc"
+
+			client          *mockgcpclient.MockInterface
+			networks        *mockgcpclient.MockNetworksService
+			networksGetCall *mockgcpclient.MockNetworksGetCall
+			config          *gcpv1alpha1.InfrastructureConfig
+		)
+
+		BeforeEach(func() {
+			client = mockgcpclient.NewMockInterface(ctrl)
+			networks = mockgcpclient.NewMockNetworksService(ctrl)
+			networksGetCall = mockgcpclient.NewMockNetworksGetCall(ctrl)
+			config = &gcpv1alpha1.InfrastructureConfig{
+				Networks: gcpv1alpha1.NetworkConfig{VPC: &gcpv1alpha1.VPC{Name: vpcName}},
+			}
+		})
+
+		expectGetNetwork := func(network *compute.Network) {
+			gomock.InOrder(
+				client.EXPECT().Networks().Return(networks),
+				networks.EXPECT().Get(projectID, vpcName).Return(networksGetCall),
+				networksGetCall.EXPECT().Context(ctx).Return(networksGetCall),
+				networksGetCall.EXPECT().Do().Return(network, nil),
+			)
+		}
+
+		It("should accept a VPC in custom subnet mode", func() {
+			expectGetNetwork(&compute.Network{Name: vpcName, AutoCreateSubnetworks: false})
+
+			Expect(CheckVPCSubnetMode(ctx, client, projectID, config)).To(Succeed())
+		})
+
+		It("should reject a VPC in auto subnet mode", func() {
+			expectGetNetwork(&compute.Network{Name: vpcName, AutoCreateSubnetworks: true})
+
+			err := CheckVPCSubnetMode(ctx, client, projectID, config)
+
+			Expect(err).To(MatchError(ContainSubstring("auto subnet mode")))
+			Expect(err).To(MatchError(ContainSubstring("--switch-to-custom-subnet-mode")))
+		})
+
+		It("should not check a created VPC", func() {
+			config.Networks.VPC = nil
+
+			Expect(CheckVPCSubnetMode(ctx, client, projectID, config)).To(Succeed())
+		})
+	})
+
+	Describe("#MigrateTerraformerState", func() {
+		var (
+			ctx = context.TODO()
+			c   *mockclient.MockClient
+
+			notFound = apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "")
+		)
+
+		BeforeEach(func() {
+			c = mockclient.NewMockClient(ctrl)
+		})
+
+		expectGetState := func(name, state string, err error) *gomock.Call {
+			return c.EXPECT().Get(ctx, kutil.Key("foo", name), gomock.AssignableToTypeOf(&corev1.ConfigMap{})).
+				DoAndReturn(func(_ context.Context, _ client.ObjectKey, configMap *corev1.ConfigMap) error {
+					if err != nil {
+						return err
+					}
+					configMap.Data = map[string]string{terraformer.StateKey: state}
+					return nil
+				})
+		}
+
+		expectDeleteObjects := func(purpose string) []*gomock.Call {
+			return []*gomock.Call{
+				c.EXPECT().Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar." + purpose + ".tf-state"}}),
+				c.EXPECT().Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar." + purpose + ".tf-config"}}),
+				c.EXPECT().Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar." + purpose + ".tf-vars"}}),
+			}
+		}
+
+		expectCopyState := func(name, state string) *gomock.Call {
+			return c.EXPECT().Create(ctx, gomock.AssignableToTypeOf(&corev1.ConfigMap{})).
+				DoAndReturn(func(_ context.Context, configMap *corev1.ConfigMap) error {
+					Expect(configMap.Name).To(Equal(name))
+					Expect(configMap.Data).To(HaveKeyWithValue(terraformer.StateKey, state))
+					return nil
+				})
+		}
+
+		It("should move the state without suffix to the state object with suffix", func() {
+			gomock.InOrder(append([]*gomock.Call{
+				expectGetState("bar.infra-v1.tf-state", "", notFound),
+				expectGetState("bar.infra.tf-state", "{}", nil),
+				expectGetState("bar.infra-v1.tf-state", "", notFound),
+				expectCopyState("bar.infra-v1.tf-state", "{}"),
+			}, expectDeleteObjects("infra")...)...)
+
+			Expect(MigrateTerraformerState(ctx, c, "foo", "bar", TerraformerPurpose, "", "v1")).To(Succeed())
+		})
+
+		It("should move the state of the previous suffix to the state object with the new suffix", func() {
+			gomock.InOrder(append([]*gomock.Call{
+				expectGetState("bar.infra-v2.tf-state", "", notFound),
+				expectGetState("bar.infra-v1.tf-state", `{"serial": 3}`, nil),
+				expectGetState("bar.infra-v2.tf-state", "", notFound),
+				expectCopyState("bar.infra-v2.tf-state", `{"serial": 3}`),
+			}, expectDeleteObjects("infra-v1")...)...)
+
+			Expect(MigrateTerraformerState(ctx, c, "foo", "bar", TerraformerPurpose, "v1", "v2")).To(Succeed())
+		})
+
+		It("should move the state of a suffix back to the state object without suffix", func() {
+			gomock.InOrder(append([]*gomock.Call{
+				expectGetState("bar.infra.tf-state", "", notFound),
+				expectGetState("bar.infra-v1.tf-state", "{}", nil),
+				expectGetState("bar.infra.tf-state", "", notFound),
+				expectCopyState("bar.infra.tf-state", "{}"),
+			}, expectDeleteObjects("infra-v1")...)...)
+
+			Expect(MigrateTerraformerState(ctx, c, "foo", "bar", TerraformerPurpose, "v1", "")).To(Succeed())
+		})
+
+		It("should keep an existing state of the state object with the new suffix", func() {
+			expectGetState("bar.infra-v2.tf-state", "{}", nil)
+
+			Expect(MigrateTerraformerState(ctx, c, "foo", "bar", TerraformerPurpose, "v1", "v2")).To(Succeed())
+		})
+
+		It("should not migrate anything without previous state", func() {
+			gomock.InOrder(
+				expectGetState("bar.infra-v2.tf-state", "", notFound),
+				expectGetState("bar.infra-v1.tf-state", "", notFound),
+			)
+
+			Expect(MigrateTerraformerState(ctx, c, "foo", "bar", TerraformerPurpose, "v1", "v2")).To(Succeed())
+		})
+
+		It("should not migrate anything if the suffix did not change", func() {
+			Expect(MigrateTerraformerState(ctx, c, "foo", "bar", TerraformerPurpose, "v1", "v1")).To(Succeed())
+		})
+
+		It("should fail if the objects of the previous suffix cannot be deleted", func() {
+			gomock.InOrder(
+				expectGetState("bar.infra-v2.tf-state", "", notFound),
+				expectGetState("bar.infra-v1.tf-state", "{}", nil),
+				expectGetState("bar.infra-v2.tf-state", "", notFound),
+				expectCopyState("bar.infra-v2.tf-state", "{}"),
+				c.EXPECT().Delete(ctx, gomock.AssignableToTypeOf(&corev1.ConfigMap{})).Return(fmt.Errorf("error")),
+			)
+
+			Expect(MigrateTerraformerState(ctx, c, "foo", "bar", TerraformerPurpose, "v1", "v2")).To(MatchError("error"))
+		})
+	})
+
+	Describe("#DeleteTerraformerObjects", func() {
+		var (
+			ctx = context.TODO()
+			c   *mockclient.MockClient
+		)
+
+		BeforeEach(func() {
+			c = mockclient.NewMockClient(ctrl)
+		})
+
+		It("should delete the state, configuration and variables of the Terraformer", func() {
+			gomock.InOrder(
+				c.EXPECT().Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar.infra-v1.tf-state"}}),
+				c.EXPECT().Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar.infra-v1.tf-config"}}),
+				c.EXPECT().Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "bar.infra-v1.tf-vars"}}),
+			)
+
+			Expect(DeleteTerraformerObjects(ctx, c, "foo", "bar", "infra-v1")).To(Succeed())
+		})
+
+		It("should tolerate objects that do not exist", func() {
+			notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "")
+			c.EXPECT().Delete(ctx, gomock.Any()).Return(notFound).Times(3)
+
+			Expect(DeleteTerraformerObjects(ctx, c, "foo", "bar", "infra")).To(Succeed())
+		})
+	})
 })
@@ -0,0 +1,260 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/operation/common"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+const (
+	// MaxResourceNameLength is the maximum length of a GCP resource name.
+	MaxResourceNameLength = 63
+	// MaxServiceAccountIDLength is the maximum length of the ID of a GCP service account.
+	MaxServiceAccountIDLength = 30
+	// MaxStateObjectSuffixLength is the maximum length of the suffix of the Terraformer state object.
+	MaxStateObjectSuffixLength = 16
+
+	// resourceNameHashLength is the length of the hash that replaces the truncated part of a namespace.
+	resourceNameHashLength = 5
+)
+
+var (
+	invalidResourceNameCharsRegex = regexp.MustCompile(`[^a-z0-9-]`)
+	resourceNameSuffixRegex       = regexp.MustCompile(`^([a-z0-9]([-a-z0-9]*[a-z0-9])?)?$`)
+
+	// resourceNameSuffixes maps the keys of the resourceNames chart values to the suffixes of the resource names.
+	resourceNameSuffixes = map[string]string{
+		"network":                 "",
+		"subnetNodes":             "nodes",
+		"subnetInternal":          "internal",
+		"podsRange":               "pods",
+		"servicesRange":           "services",
+		"subnetProxyOnly":         "proxy-only",
+		"subnetPSC":               "psc",
+		"subnetDisasterRecovery":  "disaster-recovery",
+		"subnetEgressAppliance":   "egress-appliance",
+		"cloudRouter":             "cloud-router",
+		"cloudNAT":                "cloud-nat",
+		"controlPlanePeering":     "control-plane-peering",
+		"flowLogsSink":            "flow-logs-sink",
+		"flowLogsSinkInternal":    "flow-logs-sink-internal",
+		"restrictedAPIsRoute":     "restricted-apis",
+		"restrictedAPIsZone":      "restricted-apis",
+		"allowInternalAccess":     "allow-internal-access",
+		"allowExternalAccess":     "allow-external-access",
+		"allowHealthChecks":       "allow-health-checks",
+		"denyAllEgress":           "deny-all-egress",
+		"allowClusterEgress":      "allow-cluster-egress",
+		"allowMetadataEgress":     "allow-metadata-egress",
+		"allowControlPlaneEgress": "allow-control-plane-egress",
+	}
+
+	// subnetResourceNameKeys maps the subnet purposes to the keys of the names of their subnets in the
+	// resourceNames chart values.
+	subnetResourceNameKeys = map[gcpv1alpha1.SubnetPurpose]string{
+		gcpv1alpha1.PurposeNodes:                 "subnetNodes",
+		gcpv1alpha1.PurposeInternal:              "subnetInternal",
+		gcpv1alpha1.PurposeProxyOnly:             "subnetProxyOnly",
+		gcpv1alpha1.PurposePrivateServiceConnect: "subnetPSC",
+		gcpv1alpha1.PurposeDisasterRecovery:      "subnetDisasterRecovery",
+		gcpv1alpha1.PurposeEgressAppliance:       "subnetEgressAppliance",
+	}
+
+	// resourceNameMaxLengths maps the keys of resource names to the maximum length of their resource type if it
+	// differs from MaxResourceNameLength.
+	resourceNameMaxLengths = map[string]int{
+		"serviceAccount": MaxServiceAccountIDLength,
+	}
+)
+
+// ResourceName computes a GCP resource name from the given namespace and suffix. The namespace is sanitized to
+// consist of lowercase letters, digits and '-' only and to start with a letter. If the resulting name exceeds
+// MaxResourceNameLength, the namespace is truncated and a hash of it is appended to keep the name unique.
+// Names that are valid already are returned unchanged.
+func ResourceName(namespace, suffix string) (string, error) {
+	if !resourceNameSuffixRegex.MatchString(suffix) {
+		return "", fmt.Errorf("invalid resource name suffix %q", suffix)
+	}
+
+	name := invalidResourceNameCharsRegex.ReplaceAllString(strings.ToLower(namespace), "-")
+	name = strings.TrimLeft(name, "-0123456789")
+	if name == "" {
+		return "", fmt.Errorf("cannot compute a resource name from namespace %q", namespace)
+	}
+
+	if suffix != "" {
+		suffix = "-" + suffix
+	}
+
+	if maxLength := MaxResourceNameLength - len(suffix); len(name) > maxLength {
+		prefixLength := maxLength - resourceNameHashLength - 1
+		if prefixLength <= 0 {
+			return "", fmt.Errorf("resource name suffix %q is too long", suffix)
+		}
+
+		hash := sha256.Sum256([]byte(namespace))
+		name = fmt.Sprintf("%s-%s", strings.TrimRight(name[:prefixLength], "-"), hex.EncodeToString(hash[:])[:resourceNameHashLength])
+	}
+
+	if suffix == "" {
+		name = strings.TrimRight(name, "-")
+	}
+	return name + suffix, nil
+}
+
+// SubnetName computes the canonical name of the subnet of the given purpose that the GCP Terraformer chart creates
+// for the given namespace. It is an error if no subnet of the given purpose is created by the chart.
+func SubnetName(namespace string, purpose gcpv1alpha1.SubnetPurpose) (string, error) {
+	key, ok := subnetResourceNameKeys[purpose]
+	if !ok {
+		return "", fmt.Errorf("no subnet of purpose %q is created", purpose)
+	}
+	return ResourceName(namespace, resourceNameSuffixes[key])
+}
+
+// ValidateStateObjectSuffix validates that the given suffix of the Terraformer state object is safe to be used in
+// the names of the Terraformer ConfigMaps, Secrets and Pods. It must be empty or consist of lowercase letters,
+// digits and '-' only, start and end with a letter or digit and not exceed MaxStateObjectSuffixLength.
+func ValidateStateObjectSuffix(suffix string) error {
+	if !resourceNameSuffixRegex.MatchString(suffix) {
+		return fmt.Errorf("state object suffix %q must consist of lowercase letters, digits and '-' and start and end with a letter or digit", suffix)
+	}
+	if len(suffix) > MaxStateObjectSuffixLength {
+		return fmt.Errorf("state object suffix %q exceeds the maximum length of %d", suffix, MaxStateObjectSuffixLength)
+	}
+	return nil
+}
+
+// TerraformerPurposeWithSuffix returns the given Terraformer purpose with the given state object suffix appended.
+// The Terraformer names its state ConfigMap `<name>.<purpose>.tf-state`, hence the suffix becomes part of the key
+// of the state object. An empty suffix keeps the purpose unchanged.
+func TerraformerPurposeWithSuffix(purpose, suffix string) string {
+	if suffix == "" {
+		return purpose
+	}
+	return purpose + "-" + suffix
+}
+
+// TerraformerStateName returns the name of the state ConfigMap of the Terraformer of the given purpose for the
+// Infrastructure with the given name.
+func TerraformerStateName(name, purpose string) string {
+	return fmt.Sprintf("%s.%s%s", name, purpose, common.TerraformerStateSuffix)
+}
+
+// TerraformerConfigName returns the name of the configuration ConfigMap of the Terraformer of the given purpose for
+// the Infrastructure with the given name.
+func TerraformerConfigName(name, purpose string) string {
+	return fmt.Sprintf("%s.%s%s", name, purpose, common.TerraformerConfigSuffix)
+}
+
+// TerraformerVariablesName returns the name of the variables Secret of the Terraformer of the given purpose for the
+// Infrastructure with the given name.
+func TerraformerVariablesName(name, purpose string) string {
+	return fmt.Sprintf("%s.%s%s", name, purpose, common.TerraformerVariablesSuffix)
+}
+
+// ValidateResourceNameLengths validates that none of the given resource names, keyed like the resourceNames chart
+// values, exceeds the maximum length of its resource type. It returns an error naming every offending resource.
+func ValidateResourceNameLengths(names map[string]string) error {
+	keys := make([]string, 0, len(names))
+	for key := range names {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		maxLength, ok := resourceNameMaxLengths[key]
+		if !ok {
+			maxLength = MaxResourceNameLength
+		}
+		if name := names[key]; len(name) > maxLength {
+			errs = append(errs, fmt.Errorf("name %q of resource %s exceeds the maximum length of %d characters", name, key, maxLength))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// ResourceNameCollision is a resource name derived for several Infrastructures.
+type ResourceNameCollision struct {
+	// Resource is the key of the resource name, like the keys of the resourceNames chart values.
+	Resource string
+	// Name is the derived resource name.
+	Name string
+	// Infrastructures are the keys <namespace>/<name> of the Infrastructures the name is derived for.
+	Infrastructures []string
+}
+
+// DetectResourceNameCollisions returns the resource names that are derived for more than one of the given
+// Infrastructures, e.g. for namespaces that only differ in sanitized characters. The Infrastructures are expected
+// to be in the same project, in which colliding names would make them manage the same resources. The collisions
+// are sorted by resource and name.
+func DetectResourceNameCollisions(infras []*extensionsv1alpha1.Infrastructure) ([]ResourceNameCollision, error) {
+	type resourceName struct {
+		resource string
+		name     string
+	}
+
+	infrasByName := make(map[resourceName][]string)
+	for _, infra := range infras {
+		names, err := computeResourceNames(infra.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		for resource, name := range names {
+			key := resourceName{resource, name}
+			infrasByName[key] = append(infrasByName[key], infra.Namespace+"/"+infra.Name)
+		}
+	}
+
+	var collisions []ResourceNameCollision
+	for key, keys := range infrasByName {
+		if len(keys) > 1 {
+			sort.Strings(keys)
+			collisions = append(collisions, ResourceNameCollision{Resource: key.resource, Name: key.name, Infrastructures: keys})
+		}
+	}
+	sort.Slice(collisions, func(i, j int) bool {
+		if collisions[i].Resource != collisions[j].Resource {
+			return collisions[i].Resource < collisions[j].Resource
+		}
+		return collisions[i].Name < collisions[j].Name
+	})
+	return collisions, nil
+}
+
+// computeResourceNames computes the names of all resources of the GCP Terraformer chart for the given namespace.
+func computeResourceNames(namespace string) (map[string]string, error) {
+	names := make(map[string]string, len(resourceNameSuffixes))
+	for key, suffix := range resourceNameSuffixes {
+		name, err := ResourceName(namespace, suffix)
+		if err != nil {
+			return nil, err
+		}
+		names[key] = name
+	}
+	return names, nil
+}
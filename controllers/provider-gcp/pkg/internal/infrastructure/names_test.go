@@ -0,0 +1,198 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"context"
+	"strings"
+
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/gardener/gardener/pkg/logger"
+	"github.com/gardener/gardener/pkg/operation/terraformer"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Names", func() {
+	Describe("#ResourceName", func() {
+		It("should keep a valid namespace unchanged", func() {
+			Expect(ResourceName("shoot--foo--bar", "nodes")).To(Equal("shoot--foo--bar-nodes"))
+		})
+
+		It("should sanitize a namespace with invalid characters", func() {
+			Expect(ResourceName("1Shoot--Foo.Bar_", "")).To(Equal("shoot--foo-bar"))
+		})
+
+		It("should truncate a namespace exceeding the maximum length", func() {
+			namespace := "shoot--" + strings.Repeat("a", 60)
+
+			name, err := ResourceName(namespace, "allow-internal-access")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(HaveLen(MaxResourceNameLength))
+			Expect(name).To(HavePrefix("shoot--aaa"))
+			Expect(name).To(HaveSuffix("-allow-internal-access"))
+		})
+
+		It("should compute different names for truncated namespaces with a common prefix", func() {
+			prefix := "shoot--" + strings.Repeat("a", 60)
+
+			name1, err := ResourceName(prefix+"b", "nodes")
+			Expect(err).NotTo(HaveOccurred())
+			name2, err := ResourceName(prefix+"c", "nodes")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(name1).NotTo(Equal(name2))
+		})
+
+		It("should fail for a namespace without letters", func() {
+			_, err := ResourceName("--123", "nodes")
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#ValidateResourceNameLengths", func() {
+		It("should accept the names computed for a long namespace", func() {
+			names, err := computeResourceNames("shoot--" + strings.Repeat("a", 80))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ValidateResourceNameLengths(names)).To(Succeed())
+		})
+
+		It("should fail for a service account ID derived from a long namespace", func() {
+			namespace := "shoot--" + strings.Repeat("a", 30)
+
+			err := ValidateResourceNameLengths(map[string]string{
+				"network":        "shoot--foo--bar",
+				"serviceAccount": namespace,
+			})
+
+			Expect(err).To(MatchError(ContainSubstring("resource serviceAccount")))
+			Expect(err).NotTo(MatchError(ContainSubstring("resource network")))
+		})
+
+		It("should fail for a name exceeding the maximum length of GCP resource names", func() {
+			err := ValidateResourceNameLengths(map[string]string{
+				"subnetNodes": strings.Repeat("a", MaxResourceNameLength+1),
+			})
+
+			Expect(err).To(MatchError(ContainSubstring("resource subnetNodes")))
+		})
+	})
+
+	Describe("#DetectResourceNameCollisions", func() {
+		newInfrastructure := func(namespace, name string) *extensionsv1alpha1.Infrastructure {
+			return &extensionsv1alpha1.Infrastructure{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+		}
+
+		It("should not report distinct namespaces", func() {
+			collisions, err := DetectResourceNameCollisions([]*extensionsv1alpha1.Infrastructure{
+				newInfrastructure("shoot--foo--bar", "bar"),
+				newInfrastructure("shoot--foo--baz", "baz"),
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(collisions).To(BeEmpty())
+		})
+
+		It("should report the names derived from namespaces only differing in sanitized characters", func() {
+			collisions, err := DetectResourceNameCollisions([]*extensionsv1alpha1.Infrastructure{
+				newInfrastructure("shoot--foo--bar.baz", "infra"),
+				newInfrastructure("shoot--foo--bar_baz", "infra"),
+			})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(collisions).To(HaveLen(len(resourceNameSuffixes)))
+			Expect(collisions).To(ContainElement(ResourceNameCollision{
+				Resource:        "subnetNodes",
+				Name:            "shoot--foo--bar-baz-nodes",
+				Infrastructures: []string{"shoot--foo--bar.baz/infra", "shoot--foo--bar_baz/infra"},
+			}))
+		})
+
+		It("should fail for a namespace no names can be derived from", func() {
+			_, err := DetectResourceNameCollisions([]*extensionsv1alpha1.Infrastructure{newInfrastructure("--123", "infra")})
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#SubnetName", func() {
+		It("should compute the name of the subnet of the given purpose", func() {
+			Expect(SubnetName("shoot--foo--bar", gcpv1alpha1.PurposeNodes)).To(Equal("shoot--foo--bar-nodes"))
+			Expect(SubnetName("shoot--foo--bar", gcpv1alpha1.PurposeInternal)).To(Equal("shoot--foo--bar-internal"))
+			Expect(SubnetName("shoot--foo--bar", gcpv1alpha1.PurposePrivateServiceConnect)).To(Equal("shoot--foo--bar-psc"))
+		})
+
+		It("should fail for an unknown purpose", func() {
+			_, err := SubnetName("shoot--foo--bar", gcpv1alpha1.SubnetPurpose("foo"))
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#ValidateStateObjectSuffix", func() {
+		It("should allow an empty suffix", func() {
+			Expect(ValidateStateObjectSuffix("")).To(Succeed())
+		})
+
+		It("should allow a safe suffix", func() {
+			Expect(ValidateStateObjectSuffix("v1-2")).To(Succeed())
+		})
+
+		It("should forbid unsafe characters", func() {
+			Expect(ValidateStateObjectSuffix("v1.2")).NotTo(Succeed())
+			Expect(ValidateStateObjectSuffix("V1")).NotTo(Succeed())
+			Expect(ValidateStateObjectSuffix("-v1")).NotTo(Succeed())
+		})
+
+		It("should forbid a suffix exceeding the maximum length", func() {
+			Expect(ValidateStateObjectSuffix(strings.Repeat("a", MaxStateObjectSuffixLength+1))).NotTo(Succeed())
+		})
+	})
+
+	Describe("#TerraformerPurposeWithSuffix", func() {
+		It("should keep the purpose for an empty suffix", func() {
+			Expect(TerraformerPurposeWithSuffix(TerraformerPurpose, "")).To(Equal(TerraformerPurpose))
+		})
+
+		It("should propagate the suffix into the key of the state object", func() {
+			ctrl := gomock.NewController(GinkgoT())
+			defer ctrl.Finish()
+
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(gomock.Any(), kutil.Key("foo", "bar.infra-v1.tf-state"), gomock.AssignableToTypeOf(&corev1.ConfigMap{})).
+				DoAndReturn(func(_ context.Context, _ client.ObjectKey, configMap *corev1.ConfigMap) error {
+					configMap.Data = map[string]string{terraformer.StateKey: "{}"}
+					return nil
+				})
+
+			tf := terraformer.New(logger.NewLogger("info"), c, nil, TerraformerPurposeWithSuffix(TerraformerPurpose, "v1"), "foo", "bar", "")
+
+			state, err := tf.GetState()
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(state)).To(Equal("{}"))
+		})
+	})
+})
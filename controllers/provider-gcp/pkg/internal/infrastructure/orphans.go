@@ -0,0 +1,95 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"strings"
+
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// OrphanKindSubnet is the kind of orphaned subnets.
+	OrphanKindSubnet = "subnet"
+	// OrphanKindRoute is the kind of orphaned custom routes.
+	OrphanKindRoute = "route"
+	// OrphanKindServiceAccount is the kind of an orphaned service account.
+	OrphanKindServiceAccount = "serviceAccount"
+)
+
+// Orphan is a resource of an InfrastructureStatus that is no longer part of the InfrastructureConfig.
+type Orphan struct {
+	// Kind is the kind of the resource.
+	Kind string
+	// Name is the name of the resource.
+	Name string
+}
+
+// DetectOrphans returns the resources present in the given InfrastructureStatus that are no longer configured in
+// the given InfrastructureConfig of an Infrastructure in the given namespace, e.g. an internal subnet that was
+// removed from the config. Reconciling the config destroys them, hence the controller can warn about them
+// beforehand.
+func DetectOrphans(namespace string, config *gcpv1alpha1.InfrastructureConfig, status *gcpv1alpha1.InfrastructureStatus) []Orphan {
+	var orphans []Orphan
+
+	configuredPurposes := map[gcpv1alpha1.SubnetPurpose]bool{
+		gcpv1alpha1.PurposeNodes:                 true,
+		gcpv1alpha1.PurposeInternal:              config.Networks.Internal != nil,
+		gcpv1alpha1.PurposeProxyOnly:             config.Networks.ProxyOnly != nil,
+		gcpv1alpha1.PurposePrivateServiceConnect: config.Networks.PrivateServiceConnect != nil,
+		gcpv1alpha1.PurposeDisasterRecovery:      config.Networks.DisasterRecovery != nil,
+		gcpv1alpha1.PurposeEgressAppliance:       config.Networks.EgressAppliance != nil,
+	}
+	if purpose := config.Networks.InternalPurpose; purpose != nil {
+		configuredPurposes[*purpose] = config.Networks.Internal != nil
+	}
+	for _, subnet := range status.Networks.Subnets {
+		purpose := subnet.Purpose
+		if prefix := config.Networks.SubnetPurposePrefix; prefix != nil {
+			purpose = gcpv1alpha1.SubnetPurpose(strings.TrimPrefix(string(purpose), *prefix))
+		}
+		if !configuredPurposes[purpose] {
+			orphans = append(orphans, Orphan{Kind: OrphanKindSubnet, Name: subnet.Name})
+		}
+	}
+
+	routeNames := configuredRouteResourceNames(namespace, configuredRoutes(&config.Networks))
+	for _, route := range status.Networks.Routes {
+		if !routeNames.Has(route) {
+			orphans = append(orphans, Orphan{Kind: OrphanKindRoute, Name: route})
+		}
+	}
+
+	if status.ServiceAccountEmail != "" && !createServiceAccount(config) {
+		orphans = append(orphans, Orphan{Kind: OrphanKindServiceAccount, Name: status.ServiceAccountEmail})
+	}
+
+	return orphans
+}
+
+// configuredRouteResourceNames returns the resource names of the given routes of an Infrastructure in the given
+// namespace, see customRouteValues. Routes whose names do not yield a valid resource name are never created, hence
+// they are omitted.
+func configuredRouteResourceNames(namespace string, routes []gcpv1alpha1.Route) sets.String {
+	names := sets.NewString()
+	for _, route := range routes {
+		if name, err := ResourceName(namespace, "route-"+route.Name); err == nil {
+			names.Insert(name)
+		}
+	}
+	return names
+}
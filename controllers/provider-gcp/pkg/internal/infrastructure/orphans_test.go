@@ -0,0 +1,106 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Orphans", func() {
+	Describe("#DetectOrphans", func() {
+		var (
+			config *gcpv1alpha1.InfrastructureConfig
+			status *gcpv1alpha1.InfrastructureStatus
+		)
+
+		BeforeEach(func() {
+			internalCIDR := gardencorev1alpha1.CIDR("192.168.0.0/16")
+
+			config = &gcpv1alpha1.InfrastructureConfig{
+				Networks: gcpv1alpha1.NetworkConfig{
+					Internal:     &internalCIDR,
+					Worker:       gardencorev1alpha1.CIDR("10.1.0.0/16"),
+					CustomRoutes: []gcpv1alpha1.Route{{Name: "on-prem"}},
+				},
+			}
+			status = &gcpv1alpha1.InfrastructureStatus{
+				Networks: gcpv1alpha1.NetworkStatus{
+					Subnets: []gcpv1alpha1.Subnet{
+						{Purpose: gcpv1alpha1.PurposeNodes, Name: "foo-nodes"},
+						{Purpose: gcpv1alpha1.PurposeInternal, Name: "foo-internal"},
+					},
+					Routes: []string{"foo-route-on-prem"},
+				},
+				ServiceAccountEmail: "foo@project.iam.gserviceaccount.com",
+			}
+		})
+
+		It("should not detect orphans if all resources are configured", func() {
+			Expect(DetectOrphans("foo", config, status)).To(BeEmpty())
+		})
+
+		It("should detect the internal subnet as orphan if it is removed from the config", func() {
+			config.Networks.Internal = nil
+
+			Expect(DetectOrphans("foo", config, status)).To(Equal([]Orphan{
+				{Kind: OrphanKindSubnet, Name: "foo-internal"},
+			}))
+		})
+
+		It("should respect a custom purpose label of the internal subnet", func() {
+			purpose := gcpv1alpha1.SubnetPurpose("ilb")
+			config.Networks.InternalPurpose = &purpose
+			status.Networks.Subnets[1].Purpose = purpose
+
+			Expect(DetectOrphans("foo", config, status)).To(BeEmpty())
+		})
+
+		It("should respect the prefix of the subnet purposes", func() {
+			prefix := "gardener-"
+			config.Networks.SubnetPurposePrefix = &prefix
+			config.Networks.Internal = nil
+			for i := range status.Networks.Subnets {
+				status.Networks.Subnets[i].Purpose = gcpv1alpha1.SubnetPurpose(prefix) + status.Networks.Subnets[i].Purpose
+			}
+
+			Expect(DetectOrphans("foo", config, status)).To(Equal([]Orphan{
+				{Kind: OrphanKindSubnet, Name: "foo-internal"},
+			}))
+		})
+
+		It("should detect removed routes and a service account that is no longer created", func() {
+			create := false
+			config.Networks.CustomRoutes = nil
+			config.ServiceAccount = &gcpv1alpha1.ServiceAccountConfig{Create: &create}
+
+			Expect(DetectOrphans("foo", config, status)).To(Equal([]Orphan{
+				{Kind: OrphanKindRoute, Name: "foo-route-on-prem"},
+				{Kind: OrphanKindServiceAccount, Name: "foo@project.iam.gserviceaccount.com"},
+			}))
+		})
+
+		It("should detect a route whose resource name only ends like the one of a configured route", func() {
+			config.Networks.CustomRoutes = []gcpv1alpha1.Route{{Name: "on-prem"}}
+			status.Networks.Routes = []string{"foo-route-on-prem", "foo-route-legacy-route-on-prem"}
+
+			Expect(DetectOrphans("foo", config, status)).To(Equal([]Orphan{
+				{Kind: OrphanKindRoute, Name: "foo-route-legacy-route-on-prem"},
+			}))
+		})
+	})
+})
@@ -0,0 +1,213 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var (
+	// subnetPermissions are the permissions required to manage the subnets, which are always created.
+	subnetPermissions = []string{
+		"compute.networks.get",
+		"compute.subnetworks.create",
+		"compute.subnetworks.delete",
+		"compute.subnetworks.get",
+		"compute.subnetworks.update",
+	}
+	// firewallPermissions are the permissions required to manage the firewall rules, which are always created.
+	firewallPermissions = []string{
+		"compute.firewalls.create",
+		"compute.firewalls.delete",
+		"compute.firewalls.get",
+		"compute.firewalls.update",
+		"compute.networks.updatePolicy",
+	}
+	// vpcPermissions are the permissions required to manage a created VPC.
+	vpcPermissions = []string{
+		"compute.networks.create",
+		"compute.networks.delete",
+	}
+	// serviceAccountPermissions are the permissions required to manage a created service account.
+	serviceAccountPermissions = []string{
+		"iam.serviceAccounts.create",
+		"iam.serviceAccounts.delete",
+		"iam.serviceAccounts.get",
+	}
+	// workloadIdentityPermissions are the permissions required to bind Kubernetes service accounts to a created
+	// service account via workload identity.
+	workloadIdentityPermissions = []string{
+		"iam.serviceAccounts.getIamPolicy",
+		"iam.serviceAccounts.setIamPolicy",
+	}
+	// cloudRouterPermissions are the permissions required to manage a created Cloud Router.
+	cloudRouterPermissions = []string{
+		"compute.routers.create",
+		"compute.routers.delete",
+		"compute.routers.get",
+		"compute.routers.update",
+	}
+	// cloudNATPermissions are the permissions required to manage Cloud NAT, which is configured on a Cloud Router,
+	// including the external addresses reserved for it.
+	cloudNATPermissions = []string{
+		"compute.addresses.create",
+		"compute.addresses.delete",
+		"compute.addresses.get",
+		"compute.addresses.use",
+		"compute.routers.get",
+		"compute.routers.update",
+	}
+	// routePermissions are the permissions required to manage custom routes.
+	routePermissions = []string{
+		"compute.networks.updatePolicy",
+		"compute.routes.create",
+		"compute.routes.delete",
+		"compute.routes.get",
+	}
+	// controlPlanePeeringPermissions are the permissions required to reserve the control plane peering range
+	// and to peer the created VPC with the service producer network, including the exchange of its routes.
+	controlPlanePeeringPermissions = []string{
+		"compute.globalAddresses.create",
+		"compute.globalAddresses.delete",
+		"compute.globalAddresses.get",
+		"compute.networks.addPeering",
+		"compute.networks.removePeering",
+		"compute.networks.updatePeering",
+		"servicenetworking.services.addPeering",
+	}
+	// internalAddressPermissions are the permissions required to reserve internal addresses in the internal subnet.
+	internalAddressPermissions = []string{
+		"compute.addresses.create",
+		"compute.addresses.delete",
+		"compute.addresses.get",
+		"compute.subnetworks.use",
+	}
+	// flowLogsSinkPermissions are the permissions required to manage the logging sink the flow logs are
+	// exported to.
+	flowLogsSinkPermissions = []string{
+		"logging.sinks.create",
+		"logging.sinks.delete",
+		"logging.sinks.get",
+		"logging.sinks.update",
+	}
+	// restrictedAPIsDNSPermissions are the permissions required to manage the private DNS zone resolving the Google
+	// APIs to the restricted VIP of a service perimeter.
+	restrictedAPIsDNSPermissions = []string{
+		"dns.changes.create",
+		"dns.changes.get",
+		"dns.managedZones.create",
+		"dns.managedZones.delete",
+		"dns.managedZones.get",
+		"dns.managedZones.update",
+		"dns.networks.bindPrivateDNSZone",
+		"dns.resourceRecordSets.create",
+		"dns.resourceRecordSets.delete",
+		"dns.resourceRecordSets.list",
+		"dns.resourceRecordSets.update",
+	}
+)
+
+const (
+	// CustomRoleID is the ID of the generated IAM custom role.
+	CustomRoleID = "gardener.infrastructure"
+	// CustomRoleTitle is the title of the generated IAM custom role.
+	CustomRoleTitle = "Gardener Infrastructure"
+)
+
+const (
+	// ServiceNetworkingAPI is the API used to peer the VPC with the control plane.
+	ServiceNetworkingAPI = "servicenetworking.googleapis.com"
+	// IAMCredentialsAPI is the API used to impersonate the service account via workload identity.
+	IAMCredentialsAPI = "iamcredentials.googleapis.com"
+)
+
+// CustomRole is the definition of an IAM custom role.
+type CustomRole struct {
+	// ID is the ID of the role.
+	ID string
+	// Title is the human-readable title of the role.
+	Title string
+	// Permissions are the sorted permissions included in the role.
+	Permissions []string
+}
+
+// RequiredIAMPermissions returns the sorted IAM permissions the service account of the Terraformer needs to
+// create the infrastructure of the given InfrastructureConfig.
+func RequiredIAMPermissions(config *gcpv1alpha1.InfrastructureConfig) []string {
+	permissions := sets.NewString(subnetPermissions...)
+	permissions.Insert(firewallPermissions...)
+
+	if createVPC(config) {
+		permissions.Insert(vpcPermissions...)
+	}
+	if createServiceAccount(config) {
+		permissions.Insert(serviceAccountPermissions...)
+		if len(workloadIdentityUsers(config)) > 0 {
+			permissions.Insert(workloadIdentityPermissions...)
+		}
+	}
+	if config.Networks.CloudNAT != nil {
+		if createVPC(config) {
+			permissions.Insert(cloudRouterPermissions...)
+		}
+		permissions.Insert(cloudNATPermissions...)
+	}
+	if routeCount(config) > 0 {
+		permissions.Insert(routePermissions...)
+	}
+	if config.Networks.ControlPlanePeering != nil {
+		permissions.Insert(controlPlanePeeringPermissions...)
+	}
+	if flowLogsExportDestination(config) != nil {
+		permissions.Insert(flowLogsSinkPermissions...)
+	}
+	if config.Networks.ServicePerimeter != nil {
+		permissions.Insert(restrictedAPIsDNSPermissions...)
+	}
+	if internalAddressCount(config) > 0 {
+		permissions.Insert(internalAddressPermissions...)
+	}
+
+	return permissions.List()
+}
+
+// GenerateCustomRole returns the definition of an IAM custom role that includes exactly the permissions
+// RequiredIAMPermissions returns for the given InfrastructureConfig.
+func GenerateCustomRole(config *gcpv1alpha1.InfrastructureConfig) *CustomRole {
+	return &CustomRole{
+		ID:          CustomRoleID,
+		Title:       CustomRoleTitle,
+		Permissions: RequiredIAMPermissions(config),
+	}
+}
+
+// APIsToDisableOnDelete returns the sorted APIs that are only used by the features enabled in the given
+// InfrastructureConfig and that operators may hence consider disabling when deleting the infrastructure. It is
+// advisory only, as other workloads in the project may use them as well. The APIs every infrastructure relies on,
+// like the Compute Engine API, are never returned.
+func APIsToDisableOnDelete(config *gcpv1alpha1.InfrastructureConfig) []string {
+	apis := sets.NewString()
+
+	if config.Networks.ControlPlanePeering != nil {
+		apis.Insert(ServiceNetworkingAPI)
+	}
+	if createServiceAccount(config) && len(workloadIdentityUsers(config)) > 0 {
+		apis.Insert(IAMCredentialsAPI)
+	}
+
+	return apis.List()
+}
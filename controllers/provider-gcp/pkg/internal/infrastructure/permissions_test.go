@@ -0,0 +1,167 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
+	. "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/infrastructure"
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Permissions", func() {
+	Describe("#RequiredIAMPermissions", func() {
+		var config *gcpv1alpha1.InfrastructureConfig
+
+		BeforeEach(func() {
+			config = &gcpv1alpha1.InfrastructureConfig{
+				Networks: gcpv1alpha1.NetworkConfig{
+					VPC:    &gcpv1alpha1.VPC{Name: "vpc"},
+					Worker: "10.250.0.0/16",
+				},
+			}
+		})
+
+		It("should only require the subnet, firewall and service account permissions for an existing VPC", func() {
+			permissions := RequiredIAMPermissions(config)
+
+			Expect(permissions).To(ContainElement("compute.subnetworks.create"))
+			Expect(permissions).To(ContainElement("compute.firewalls.create"))
+			Expect(permissions).To(ContainElement("iam.serviceAccounts.create"))
+			Expect(permissions).NotTo(ContainElement("compute.networks.create"))
+			Expect(permissions).NotTo(ContainElement("compute.routers.update"))
+		})
+
+		It("should require the network permissions if the VPC is created", func() {
+			config.Networks.VPC = nil
+
+			Expect(RequiredIAMPermissions(config)).To(ContainElement("compute.networks.create"))
+		})
+
+		It("should require the router permissions if Cloud NAT is enabled", func() {
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+
+			permissions := RequiredIAMPermissions(config)
+
+			Expect(permissions).To(ContainElement("compute.routers.create"))
+			Expect(permissions).To(ContainElement("compute.routers.update"))
+		})
+
+		It("should only require updating the existing Cloud Router of an existing VPC for Cloud NAT", func() {
+			config.Networks.VPC.CloudRouter = &gcpv1alpha1.CloudRouter{Name: "router"}
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+
+			permissions := RequiredIAMPermissions(config)
+
+			Expect(permissions).To(ContainElement("compute.routers.update"))
+			Expect(permissions).NotTo(ContainElement("compute.routers.create"))
+		})
+
+		It("should require the address permissions for the reserved Cloud NAT addresses", func() {
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+
+			permissions := RequiredIAMPermissions(config)
+
+			Expect(permissions).To(ContainElement("compute.addresses.create"))
+			Expect(permissions).To(ContainElement("compute.addresses.use"))
+		})
+
+		It("should require the IAM policy permissions for workload identity users", func() {
+			config.ServiceAccount = &gcpv1alpha1.ServiceAccountConfig{WorkloadIdentityUsers: []string{"kube-system/foo"}}
+
+			Expect(RequiredIAMPermissions(config)).To(ContainElement("iam.serviceAccounts.setIamPolicy"))
+		})
+
+		It("should require the route permissions for custom routes", func() {
+			config.Networks.CustomRoutes = []gcpv1alpha1.Route{{Name: "on-prem"}}
+
+			Expect(RequiredIAMPermissions(config)).To(ContainElement("compute.routes.create"))
+		})
+
+		It("should require the logging sink permissions for exported flow logs", func() {
+			destination := "storage.googleapis.com/flow-logs"
+			config.Networks.FlowLogs = &gcpv1alpha1.FlowLogs{ExportDestination: &destination}
+
+			Expect(RequiredIAMPermissions(config)).To(ContainElement("logging.sinks.create"))
+		})
+
+		It("should require the DNS permissions for a service perimeter", func() {
+			servicePerimeter := "accessPolicies/123456/servicePerimeters/shoots"
+			config.Networks.ServicePerimeter = &servicePerimeter
+
+			Expect(RequiredIAMPermissions(config)).To(ContainElement("dns.networks.bindPrivateDNSZone"))
+		})
+
+		It("should not require the service account permissions if no service account is created", func() {
+			create := false
+			config.ServiceAccount = &gcpv1alpha1.ServiceAccountConfig{Create: &create}
+
+			Expect(RequiredIAMPermissions(config)).NotTo(ContainElement("iam.serviceAccounts.create"))
+		})
+	})
+
+	Describe("#GenerateCustomRole", func() {
+		var config *gcpv1alpha1.InfrastructureConfig
+
+		BeforeEach(func() {
+			config = &gcpv1alpha1.InfrastructureConfig{
+				Networks: gcpv1alpha1.NetworkConfig{
+					Worker: "10.250.0.0/16",
+				},
+			}
+		})
+
+		It("should include exactly the required permissions", func() {
+			role := GenerateCustomRole(config)
+
+			Expect(role.ID).To(Equal(CustomRoleID))
+			Expect(role.Title).To(Equal(CustomRoleTitle))
+			Expect(role.Permissions).To(Equal(RequiredIAMPermissions(config)))
+		})
+
+		It("should include the Cloud NAT permissions if Cloud NAT is enabled", func() {
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+
+			Expect(GenerateCustomRole(config).Permissions).To(ContainElement("compute.routers.update"))
+		})
+	})
+
+	Describe("#APIsToDisableOnDelete", func() {
+		var config *gcpv1alpha1.InfrastructureConfig
+
+		BeforeEach(func() {
+			config = &gcpv1alpha1.InfrastructureConfig{
+				Networks: gcpv1alpha1.NetworkConfig{
+					Worker: "10.250.0.0/16",
+				},
+			}
+		})
+
+		It("should not return any API for the default features", func() {
+			Expect(APIsToDisableOnDelete(config)).To(BeEmpty())
+		})
+
+		It("should return the APIs of the enabled features", func() {
+			peering := gardencorev1alpha1.CIDR("10.5.0.0/20")
+			config.Networks.ControlPlanePeering = &peering
+			config.ServiceAccount = &gcpv1alpha1.ServiceAccountConfig{WorkloadIdentityUsers: []string{"default/foo"}}
+
+			Expect(APIsToDisableOnDelete(config)).To(Equal([]string{IAMCredentialsAPI, ServiceNetworkingAPI}))
+		})
+	})
+})
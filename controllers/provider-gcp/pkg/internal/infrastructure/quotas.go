@@ -0,0 +1,129 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// QuotaNetworks is the GCP quota metric of the VPC networks of a project.
+	QuotaNetworks = "NETWORKS"
+	// QuotaSubnetworks is the GCP quota metric of the subnetworks of a project.
+	QuotaSubnetworks = "SUBNETWORKS"
+	// QuotaRouters is the GCP quota metric of the Cloud Routers of a project.
+	QuotaRouters = "ROUTERS"
+	// QuotaRoutes is the GCP quota metric of the routes of a project.
+	QuotaRoutes = "ROUTES"
+	// QuotaFirewalls is the GCP quota metric of the firewall rules of a project.
+	QuotaFirewalls = "FIREWALLS"
+	// QuotaInUseAddresses is the GCP quota metric of the external addresses in use in a region.
+	QuotaInUseAddresses = "IN_USE_ADDRESSES"
+	// QuotaStaticAddresses is the GCP quota metric of the reserved external addresses in a region.
+	QuotaStaticAddresses = "STATIC_ADDRESSES"
+	// QuotaInternalAddresses is the GCP quota metric of the reserved internal addresses in a region.
+	QuotaInternalAddresses = "INTERNAL_ADDRESSES"
+	// QuotaGlobalInternalAddresses is the GCP quota metric of the reserved global internal addresses of a project.
+	QuotaGlobalInternalAddresses = "GLOBAL_INTERNAL_ADDRESSES"
+
+	// DefaultMaxFirewallRules is the default limit of the firewall rules of a GCP project.
+	DefaultMaxFirewallRules = 100
+)
+
+// RequiredQuotas returns the number of resources the infrastructure of the given InfrastructureConfig creates per
+// GCP quota metric, e.g. for comparing them against the available quotas of the project before applying it. Quota
+// metrics no resource is created for are omitted.
+func RequiredQuotas(config *gcpv1alpha1.InfrastructureConfig) map[string]int {
+	quotas := map[string]int{
+		QuotaSubnetworks: len(subnetPurposes(&config.Networks)),
+	}
+
+	if createVPC(config) {
+		quotas[QuotaNetworks] = 1
+	}
+	if config.Networks.CloudNAT != nil {
+		if createVPC(config) {
+			quotas[QuotaRouters] = 1
+		}
+		quotas[QuotaInUseAddresses] = natAddressCount(config.Networks.CloudNAT)
+		quotas[QuotaStaticAddresses] = natAddressCount(config.Networks.CloudNAT)
+	}
+	if n := routeCount(config); n > 0 {
+		quotas[QuotaRoutes] = n
+	}
+	if n := firewallRuleCount(config); n > 0 {
+		quotas[QuotaFirewalls] = n
+	}
+	if n := internalAddressCount(config); n > 0 {
+		quotas[QuotaInternalAddresses] = int(n)
+	}
+	if config.Networks.ControlPlanePeering != nil {
+		quotas[QuotaGlobalInternalAddresses] = 1
+	}
+
+	return quotas
+}
+
+// ValidateFirewallRuleCount validates that the firewall rules created for the given InfrastructureConfig together
+// with the given number of other firewall rules, e.g. user-managed rules of an existing VPC, do not exceed the
+// given maximum, e.g. the FIREWALLS quota of the project or DefaultMaxFirewallRules.
+func ValidateFirewallRuleCount(config *gcpv1alpha1.InfrastructureConfig, otherRules, maxRules int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if total := firewallRuleCount(config) + otherRules; total > maxRules {
+		allErrs = append(allErrs, field.Invalid(fldPath, total, fmt.Sprintf("the %d created and %d other firewall rules exceed the maximum of %d", firewallRuleCount(config), otherRules, maxRules)))
+	}
+
+	return allErrs
+}
+
+// subnetPurposes returns the purposes of the subnets created for the given NetworkConfig.
+func subnetPurposes(networks *gcpv1alpha1.NetworkConfig) []gcpv1alpha1.SubnetPurpose {
+	purposes := []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeNodes}
+	if networks.Internal != nil {
+		purposes = append(purposes, gcpv1alpha1.PurposeInternal)
+	}
+	if networks.ProxyOnly != nil {
+		purposes = append(purposes, gcpv1alpha1.PurposeProxyOnly)
+	}
+	if networks.PrivateServiceConnect != nil {
+		purposes = append(purposes, gcpv1alpha1.PurposePrivateServiceConnect)
+	}
+	if networks.DisasterRecovery != nil {
+		purposes = append(purposes, gcpv1alpha1.PurposeDisasterRecovery)
+	}
+	if networks.EgressAppliance != nil {
+		purposes = append(purposes, gcpv1alpha1.PurposeEgressAppliance)
+	}
+	return purposes
+}
+
+// natAddressCount returns the number of external addresses reserved for the NAT gateways of the given CloudNAT.
+func natAddressCount(cloudNAT *gcpv1alpha1.CloudNAT) int {
+	n := int(natIPCount(cloudNAT.IPCount))
+	for _, gateway := range cloudNAT.Gateways {
+		n += int(natIPCount(gateway.IPCount))
+	}
+	return n
+}
+
+// firewallRuleCount returns the number of firewall rules created for the given InfrastructureConfig.
+func firewallRuleCount(config *gcpv1alpha1.InfrastructureConfig) int {
+	return len(firewallRuleResources(config))
+}
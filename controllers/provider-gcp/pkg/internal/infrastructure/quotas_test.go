@@ -0,0 +1,153 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure_test
+
+import (
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
+	. "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/infrastructure"
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Quotas", func() {
+	Describe("#RequiredQuotas", func() {
+		var config *gcpv1alpha1.InfrastructureConfig
+
+		BeforeEach(func() {
+			config = &gcpv1alpha1.InfrastructureConfig{
+				Networks: gcpv1alpha1.NetworkConfig{
+					VPC:    &gcpv1alpha1.VPC{Name: "vpc"},
+					Worker: "10.250.0.0/16",
+				},
+			}
+		})
+
+		It("should only require the subnet and firewall quotas for an existing VPC", func() {
+			Expect(RequiredQuotas(config)).To(Equal(map[string]int{
+				QuotaSubnetworks: 1,
+				QuotaFirewalls:   3,
+			}))
+		})
+
+		It("should require the network quota if the VPC is created", func() {
+			config.Networks.VPC = nil
+			internal := gardencorev1alpha1.CIDR("10.251.0.0/24")
+			config.Networks.Internal = &internal
+
+			quotas := RequiredQuotas(config)
+
+			Expect(quotas).To(HaveKeyWithValue(QuotaNetworks, 1))
+			Expect(quotas).To(HaveKeyWithValue(QuotaSubnetworks, 2))
+		})
+
+		It("should require the router and address quotas if Cloud NAT is enabled", func() {
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+
+			quotas := RequiredQuotas(config)
+
+			Expect(quotas).To(HaveKeyWithValue(QuotaRouters, 1))
+			Expect(quotas).To(HaveKeyWithValue(QuotaInUseAddresses, 1))
+		})
+
+		It("should only require the address quota for Cloud NAT on the existing Cloud Router of an existing VPC", func() {
+			config.Networks.VPC.CloudRouter = &gcpv1alpha1.CloudRouter{Name: "router"}
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+
+			quotas := RequiredQuotas(config)
+
+			Expect(quotas).NotTo(HaveKey(QuotaRouters))
+			Expect(quotas).To(HaveKeyWithValue(QuotaInUseAddresses, 1))
+		})
+
+		It("should require an address per NAT gateway", func() {
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{
+				Subnets:  []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeNodes},
+				Gateways: []gcpv1alpha1.CloudNATGateway{{Name: "internal", Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeInternal}}},
+			}
+
+			quotas := RequiredQuotas(config)
+
+			Expect(quotas).To(HaveKeyWithValue(QuotaInUseAddresses, 2))
+			Expect(quotas).To(HaveKeyWithValue(QuotaStaticAddresses, 2))
+		})
+
+		It("should require the configured number of addresses per NAT gateway", func() {
+			var ipCount, gatewayIPCount int32 = 2, 3
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{
+				IPCount:  &ipCount,
+				Subnets:  []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeNodes},
+				Gateways: []gcpv1alpha1.CloudNATGateway{{Name: "internal", Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeInternal}, IPCount: &gatewayIPCount}},
+			}
+
+			quotas := RequiredQuotas(config)
+
+			Expect(quotas).To(HaveKeyWithValue(QuotaInUseAddresses, 5))
+			Expect(quotas).To(HaveKeyWithValue(QuotaStaticAddresses, 5))
+		})
+
+		It("should count the firewall rules denying all egress traffic", func() {
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{DenyAllEgress: true, DisableAllowHealthChecks: true}
+
+			Expect(RequiredQuotas(config)).To(HaveKeyWithValue(QuotaFirewalls, 6))
+		})
+
+		It("should not require the firewall quota if the firewall is unmanaged", func() {
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{Unmanaged: true}
+
+			Expect(RequiredQuotas(config)).NotTo(HaveKey(QuotaFirewalls))
+		})
+	})
+
+	Describe("#ValidateFirewallRuleCount", func() {
+		var (
+			config  *gcpv1alpha1.InfrastructureConfig
+			fldPath *field.Path
+		)
+
+		BeforeEach(func() {
+			config = &gcpv1alpha1.InfrastructureConfig{
+				Networks: gcpv1alpha1.NetworkConfig{
+					Worker:   "10.250.0.0/16",
+					Firewall: &gcpv1alpha1.FirewallConfig{DenyAllEgress: true},
+				},
+			}
+			fldPath = field.NewPath("networks", "firewall")
+		})
+
+		It("should accept rules meeting the limit", func() {
+			Expect(ValidateFirewallRuleCount(config, DefaultMaxFirewallRules-7, DefaultMaxFirewallRules, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject rules exceeding the limit", func() {
+			errs := ValidateFirewallRuleCount(config, DefaultMaxFirewallRules-6, DefaultMaxFirewallRules, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("networks.firewall"))
+			Expect(errs[0].BadValue).To(Equal(DefaultMaxFirewallRules + 1))
+		})
+
+		It("should not count the rules of an unmanaged firewall", func() {
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{Unmanaged: true}
+
+			Expect(ValidateFirewallRuleCount(config, DefaultMaxFirewallRules, DefaultMaxFirewallRules, fldPath)).To(BeEmpty())
+		})
+	})
+})
@@ -15,7 +15,16 @@
 package infrastructure
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
 	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal"
@@ -27,11 +36,22 @@ import (
 	"github.com/gardener/gardener/pkg/operation/terraformer"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
 )
 
 const (
 	// DefaultVPCName is the default VPC terraform name.
 	DefaultVPCName = "${google_compute_network.network.name}"
+	// DefaultCloudRouterName is the default Cloud Router terraform name.
+	DefaultCloudRouterName = "${google_compute_router.router.name}"
+
+	// TFVarsFileName is the name of the file containing the terraform variables rendered by the chart.
+	TFVarsFileName = "terraform.tfvars"
+	// tfVarsFileExtension is the extension of files containing terraform variables.
+	tfVarsFileExtension = ".tfvars"
 
 	// TerraformerPurpose is the terraformer infrastructure purpose.
 	TerraformerPurpose = "infra"
@@ -44,70 +64,1029 @@ const (
 	TerraformerOutputKeySubnetNodes = "subnet_nodes"
 	// TerraformerOutputKeySubnetInternal is the name of the subnet_internal terraform output variable.
 	TerraformerOutputKeySubnetInternal = "subnet_internal"
+	// TerraformerOutputKeySubnetProxyOnly is the name of the subnet_proxy_only terraform output variable.
+	TerraformerOutputKeySubnetProxyOnly = "subnet_proxy_only"
+	// TerraformerOutputKeySubnetProxyOnlyRole is the name of the subnet_proxy_only_role terraform output variable.
+	TerraformerOutputKeySubnetProxyOnlyRole = "subnet_proxy_only_role"
+	// TerraformerOutputKeySubnetPSC is the name of the subnet_psc terraform output variable.
+	TerraformerOutputKeySubnetPSC = "subnet_psc"
+	// TerraformerOutputKeySubnetDisasterRecovery is the name of the subnet_disaster_recovery terraform output
+	// variable.
+	TerraformerOutputKeySubnetDisasterRecovery = "subnet_disaster_recovery"
+	// TerraformerOutputKeySubnetDisasterRecoveryRegion is the name of the subnet_disaster_recovery_region terraform
+	// output variable.
+	TerraformerOutputKeySubnetDisasterRecoveryRegion = "subnet_disaster_recovery_region"
+	// TerraformerOutputKeySubnetEgressAppliance is the name of the subnet_egress_appliance terraform output
+	// variable.
+	TerraformerOutputKeySubnetEgressAppliance = "subnet_egress_appliance"
+	// TerraformerOutputKeySubnetNodesSelfLink is the name of the subnet_nodes_self_link terraform output variable.
+	TerraformerOutputKeySubnetNodesSelfLink = "subnet_nodes_self_link"
+	// TerraformerOutputKeySubnetInternalSelfLink is the name of the subnet_internal_self_link terraform output variable.
+	TerraformerOutputKeySubnetInternalSelfLink = "subnet_internal_self_link"
+	// TerraformerOutputKeyNATIPs is the name of the nat_ips terraform output variable. It only covers the IPs of
+	// the primary NAT gateway.
+	TerraformerOutputKeyNATIPs = "nat_ips"
+	// TerraformerOutputKeyNATGateways is the name of the nat_gateways terraform output variable.
+	TerraformerOutputKeyNATGateways = "nat_gateways"
+	// TerraformerOutputKeyRoutes is the name of the routes terraform output variable.
+	TerraformerOutputKeyRoutes = "routes"
+	// TerraformerOutputKeyFlowLogsSink is the name of the flow_logs_sink terraform output variable.
+	TerraformerOutputKeyFlowLogsSink = "flow_logs_sink"
+	// TerraformerOutputKeyControlPlanePeeringRange is the name of the control_plane_peering_range terraform output
+	// variable.
+	TerraformerOutputKeyControlPlanePeeringRange = "control_plane_peering_range"
+	// TerraformerOutputKeyInternalAddresses is the name of the internal_addresses terraform output variable.
+	TerraformerOutputKeyInternalAddresses = "internal_addresses"
 )
 
+// RestrictedGoogleAPIsRange is the range of the restricted VIP of the Google APIs supporting VPC Service Controls.
+// See https://cloud.google.com/vpc-service-controls/docs/set-up-private-connectivity.
+const RestrictedGoogleAPIsRange = "199.36.153.4/30"
+
+// RestrictedGoogleAPIsAddresses are the addresses of the restricted VIP which restricted.googleapis.com resolves to
+// in the private DNS zone of a service perimeter.
+var RestrictedGoogleAPIsAddresses = []string{"199.36.153.4", "199.36.153.5", "199.36.153.6", "199.36.153.7"}
+
+// DefaultEgressApplianceDestination is the destination of the route steering the egress traffic through the
+// egress appliances if no destinations are configured.
+const DefaultEgressApplianceDestination gardencorev1alpha1.CIDR = "0.0.0.0/0"
+
 var (
 	// ChartsPath is the path to the charts
 	ChartsPath = filepath.Join("controllers", "provider-gcp", "charts")
 	// InternalChartsPath is the path to the internal charts
 	InternalChartsPath = filepath.Join(ChartsPath, "internal")
 
-	// StatusTypeMeta is the TypeMeta of the GCP InfrastructureStatus
-	StatusTypeMeta = metav1.TypeMeta{
-		APIVersion: gcpv1alpha1.SchemeGroupVersion.String(),
-		Kind:       "InfrastructureStatus",
+	// terraformStringEscaper escapes a string to be embedded into a terraform string literal.
+	terraformStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "${", "$${")
+
+	// HealthCheckSourceRanges are the ranges the health checks of the Google load balancers originate from.
+	// See https://cloud.google.com/load-balancing/docs/health-check-concepts#ip-ranges.
+	HealthCheckSourceRanges = []string{
+		"35.191.0.0/16",
+		"209.85.204.0/22",
+		"209.85.152.0/22",
+		"130.211.0.0/22",
+	}
+
+	// TerraformerOutputKeys are the default names of all terraform output variables.
+	TerraformerOutputKeys = []string{
+		TerraformerOutputKeyVPCName,
+		TerraformerOutputKeyServiceAccountEmail,
+		TerraformerOutputKeySubnetNodes,
+		TerraformerOutputKeySubnetInternal,
+		TerraformerOutputKeySubnetProxyOnly,
+		TerraformerOutputKeySubnetProxyOnlyRole,
+		TerraformerOutputKeySubnetPSC,
+		TerraformerOutputKeySubnetDisasterRecovery,
+		TerraformerOutputKeySubnetDisasterRecoveryRegion,
+		TerraformerOutputKeySubnetEgressAppliance,
+		TerraformerOutputKeySubnetNodesSelfLink,
+		TerraformerOutputKeySubnetInternalSelfLink,
+		TerraformerOutputKeyNATIPs,
+		TerraformerOutputKeyNATGateways,
+		TerraformerOutputKeyRoutes,
+		TerraformerOutputKeyFlowLogsSink,
+		TerraformerOutputKeyControlPlanePeeringRange,
+		TerraformerOutputKeyInternalAddresses,
+	}
+
+	// StatusGroupVersion is the version of the GCP InfrastructureStatus computed by StatusFromTerraformState.
+	StatusGroupVersion = gcpv1alpha1.SchemeGroupVersion
+
+	// StatusTypeMeta is the TypeMeta of the GCP InfrastructureStatus of the default StatusGroupVersion.
+	StatusTypeMeta = StatusTypeMetaForVersion(gcpv1alpha1.SchemeGroupVersion)
+)
+
+// StatusTypeMetaForVersion returns the TypeMeta of the GCP InfrastructureStatus of the given version.
+func StatusTypeMetaForVersion(gv schema.GroupVersion) metav1.TypeMeta {
+	return metav1.TypeMeta{
+		APIVersion: gv.String(),
+		Kind:       "InfrastructureStatus",
+	}
+}
+
+// getK8SNetworks gets the K8SNetworks from the given controller.Cluster. It is an error if the cluster has no
+// GCP shoot.
+func getK8SNetworks(cluster *controller.Cluster) (*gardencorev1alpha1.K8SNetworks, error) {
+	switch {
+	case cluster == nil:
+		return nil, fmt.Errorf("cluster is nil")
+	case cluster.Shoot == nil:
+		return nil, fmt.Errorf("cluster has no shoot")
+	case cluster.Shoot.Spec.Cloud.GCP == nil:
+		return nil, fmt.Errorf("shoot %s/%s has no GCP cloud configuration", cluster.Shoot.Namespace, cluster.Shoot.Name)
+	}
+	return &cluster.Shoot.Spec.Cloud.GCP.Networks.K8SNetworks, nil
+}
+
+// createVPC determines whether a VPC shall be created for the given InfrastructureConfig.
+func createVPC(config *gcpv1alpha1.InfrastructureConfig) bool {
+	return config.Networks.VPC == nil
+}
+
+// StateRefresh determines whether terraform shall refresh the state before applying the configuration of the
+// given InfrastructureConfig.
+func StateRefresh(config *gcpv1alpha1.InfrastructureConfig) bool {
+	return config.StateRefresh == nil || *config.StateRefresh
+}
+
+// createServiceAccount determines whether a service account shall be created for the given InfrastructureConfig.
+func createServiceAccount(config *gcpv1alpha1.InfrastructureConfig) bool {
+	return config.ServiceAccount == nil || config.ServiceAccount.Create == nil || *config.ServiceAccount.Create
+}
+
+// carveCIDR carves the range with the given prefix length from the start of the given CIDR. The CIDR is returned
+// unchanged if no prefix length is given.
+func carveCIDR(cidr gardencorev1alpha1.CIDR, prefixLength *int32) (*gardencorev1alpha1.CIDR, error) {
+	if prefixLength == nil {
+		return &cidr, nil
+	}
+
+	_, network, err := net.ParseCIDR(string(cidr))
+	if err != nil {
+		return nil, err
+	}
+	_, bits := network.Mask.Size()
+	mask := net.CIDRMask(int(*prefixLength), bits)
+
+	carved := gardencorev1alpha1.CIDR((&net.IPNet{IP: network.IP.Mask(mask), Mask: mask}).String())
+	return &carved, nil
+}
+
+// networkingMode returns the effective NetworkingMode of the given InfrastructureConfig.
+func networkingMode(config *gcpv1alpha1.InfrastructureConfig) gcpv1alpha1.NetworkingMode {
+	switch {
+	case config.Networks.AliasIPs != nil:
+		return gcpv1alpha1.NetworkingModeAliasIPs
+	case config.Networks.NetworkingMode != nil:
+		return *config.Networks.NetworkingMode
+	default:
+		return gcpv1alpha1.NetworkingModeDefault
+	}
+}
+
+// managedResourceNames returns the given resource names extended by the ID of the service account created for the
+// given InfrastructureConfig, which is the sanitized name of the cluster like the name of the network.
+func managedResourceNames(config *gcpv1alpha1.InfrastructureConfig, resourceNames map[string]string) map[string]string {
+	names := make(map[string]string, len(resourceNames)+1)
+	for key, name := range resourceNames {
+		names[key] = name
+	}
+	if createServiceAccount(config) {
+		names["serviceAccount"] = resourceNames["network"]
+	}
+	return names
+}
+
+// secondaryRanges returns the names of the secondary ranges requested for the subnets of the given
+// InfrastructureConfig, keyed by the purpose of the subnet.
+func secondaryRanges(config *gcpv1alpha1.InfrastructureConfig, resourceNames map[string]string) map[gcpv1alpha1.SubnetPurpose][]string {
+	var nodes []string
+	if config.Networks.AliasIPs != nil {
+		nodes = append(nodes, resourceNames["podsRange"])
+	}
+	if mode := networkingMode(config); mode == gcpv1alpha1.NetworkingModeAliasIPs || mode == gcpv1alpha1.NetworkingModeCilium {
+		nodes = append(nodes, resourceNames["servicesRange"])
+	}
+	return map[gcpv1alpha1.SubnetPurpose][]string{gcpv1alpha1.PurposeNodes: nodes}
+}
+
+// cloudNATSubnets returns the purposes of the subnets the Cloud NAT of the given NetworkConfig is applied to. If
+// none are selected, it is applied to all subnets it supports.
+func cloudNATSubnets(networks *gcpv1alpha1.NetworkConfig) []string {
+	var purposes []gcpv1alpha1.SubnetPurpose
+	switch {
+	case len(networks.CloudNAT.Subnets) > 0:
+		purposes = append(purposes, networks.CloudNAT.Subnets...)
+		sortSubnetPurposes(purposes)
+	case networks.Internal != nil && !networks.InternalProxyOnly:
+		purposes = []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeNodes, gcpv1alpha1.PurposeInternal}
+	default:
+		purposes = []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeNodes}
+	}
+	return subnetPurposeStrings(purposes)
+}
+
+// subnetPurposeStrings converts the given subnet purposes to strings.
+func subnetPurposeStrings(purposes []gcpv1alpha1.SubnetPurpose) []string {
+	subnets := make([]string, 0, len(purposes))
+	for _, purpose := range purposes {
+		subnets = append(subnets, string(purpose))
+	}
+	return subnets
+}
+
+// serviceAccountProject returns the project the service account of the given InfrastructureConfig is created in
+// if it differs from the project of the infrastructure.
+func serviceAccountProject(config *gcpv1alpha1.InfrastructureConfig) *string {
+	if config.ServiceAccount == nil {
+		return nil
+	}
+	return config.ServiceAccount.Project
+}
+
+// serviceAccountEmail returns the email of the pre-provisioned service account of the given InfrastructureConfig,
+// or nil if there is none.
+func serviceAccountEmail(config *gcpv1alpha1.InfrastructureConfig) *string {
+	if config.ServiceAccount == nil {
+		return nil
+	}
+	return config.ServiceAccount.Email
+}
+
+// flowLogsExportDestination returns the destination the flow logs of the given InfrastructureConfig are
+// exported to, or nil if they are not exported.
+func flowLogsExportDestination(config *gcpv1alpha1.InfrastructureConfig) *string {
+	if config.Networks.FlowLogs == nil {
+		return nil
+	}
+	return config.Networks.FlowLogs.ExportDestination
+}
+
+// ApplyDefaultFlowLogsAggregationInterval sets the aggregation interval of the flow logs of the given
+// InfrastructureConfig to the given default of the provider if flow logs are enabled without one. An empty default
+// keeps the one of GCP.
+func ApplyDefaultFlowLogsAggregationInterval(config *gcpv1alpha1.InfrastructureConfig, interval string) {
+	if flowLogs := config.Networks.FlowLogs; flowLogs != nil && flowLogs.AggregationInterval == nil && interval != "" {
+		flowLogs.AggregationInterval = &interval
+	}
+}
+
+// internalAddressCount returns the number of internal addresses reserved for the given InfrastructureConfig.
+func internalAddressCount(config *gcpv1alpha1.InfrastructureConfig) int32 {
+	if config.Networks.InternalAddresses == nil {
+		return 0
+	}
+	return *config.Networks.InternalAddresses
+}
+
+// workloadIdentityUsers returns the Kubernetes service accounts bound to the service account of the given
+// InfrastructureConfig via workload identity.
+func workloadIdentityUsers(config *gcpv1alpha1.InfrastructureConfig) []string {
+	if config.ServiceAccount == nil {
+		return nil
+	}
+	return config.ServiceAccount.WorkloadIdentityUsers
+}
+
+// WorkloadIdentityMember computes the IAM member of the given Kubernetes service account, in the form
+// <namespace>/<name>, within the workload identity pool of the given project.
+func WorkloadIdentityMember(projectID, user string) string {
+	return fmt.Sprintf("serviceAccount:%s.svc.id.goog[%s]", projectID, user)
+}
+
+// proxyOnlySubnetRole returns the role of the given ProxyOnlySubnet, defaulting to ACTIVE.
+func proxyOnlySubnetRole(subnet *gcpv1alpha1.ProxyOnlySubnet) gcpv1alpha1.ProxyOnlySubnetRole {
+	if subnet.Role == nil {
+		return gcpv1alpha1.ProxyOnlySubnetRoleActive
+	}
+	return *subnet.Role
+}
+
+// getRegion gets the region of the given Infrastructure. If the Infrastructure does not specify a region,
+// it falls back to the region of the CloudProfile of the given controller.Cluster, if the CloudProfile
+// defines exactly one.
+func getRegion(infra *extensionsv1alpha1.Infrastructure, cluster *controller.Cluster) (string, error) {
+	if infra.Spec.Region != "" {
+		return infra.Spec.Region, nil
+	}
+
+	if regions := cloudProfileRegions(cluster.CloudProfile); regions.Len() == 1 {
+		return regions.List()[0], nil
+	}
+
+	return "", fmt.Errorf("infrastructure %s/%s specifies no region and the cloud profile does not define a unique one", infra.Namespace, infra.Name)
+}
+
+// OutputKeys computes the names of the terraform output variables for the given InfrastructureConfig.
+// The result maps each default output key to its effective name, which is the override of the config if
+// one is present. It is an error to override an unknown key, to map two keys to the same name or to use an empty
+// name or one that is not a legal terraform identifier.
+func OutputKeys(config *gcpv1alpha1.InfrastructureConfig) (map[string]string, error) {
+	keys := make(map[string]string, len(TerraformerOutputKeys))
+	for _, key := range TerraformerOutputKeys {
+		keys[key] = key
+	}
+
+	for key, name := range config.OutputKeyOverrides {
+		if _, ok := keys[key]; !ok {
+			return nil, fmt.Errorf("cannot override unknown terraform output key %q", key)
+		}
+		if name == "" {
+			return nil, fmt.Errorf("cannot override terraform output key %q with an empty name", key)
+		}
+		if err := ValidateTerraformIdentifier(name); err != nil {
+			return nil, fmt.Errorf("cannot override terraform output key %q: %v", key, err)
+		}
+		keys[key] = name
+	}
+
+	keysByName := make(map[string]string, len(keys))
+	for _, key := range TerraformerOutputKeys {
+		name := keys[key]
+		if other, ok := keysByName[name]; ok {
+			return nil, fmt.Errorf("terraform output keys %q and %q both map to %q", other, key, name)
+		}
+		keysByName[name] = key
+	}
+
+	return keys, nil
+}
+
+// validateConfig validates the given InfrastructureConfig for rendering the GCP Terraformer chart with the given
+// project ID, region, cluster networks and resource names. All errors are aggregated rather than stopping at the
+// first one.
+func validateConfig(
+	config *gcpv1alpha1.InfrastructureConfig,
+	projectID, region string,
+	networks *gardencorev1alpha1.K8SNetworks,
+	resourceNames map[string]string,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, ValidateFeatureCoherence(config)...)
+	allErrs = append(allErrs, ValidateProjectID(projectID, field.NewPath("credentials", "project_id"))...)
+	if project := serviceAccountProject(config); project != nil {
+		allErrs = append(allErrs, ValidateProjectID(*project, field.NewPath("serviceAccount", "project"))...)
+	}
+	allErrs = append(allErrs, ValidateTimeouts(config.Timeouts, field.NewPath("timeouts"))...)
+	allErrs = append(allErrs, ValidateDiskEncryptionKey(config.DiskEncryptionKey, field.NewPath("diskEncryptionKey"))...)
+	allErrs = append(allErrs, ValidateWorkloadIdentityUsers(config.ServiceAccount, field.NewPath("serviceAccount"))...)
+	allErrs = append(allErrs, ValidateWorkerSubnet(&config.Networks, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateInternalCIDRDisjoint(&config.Networks, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateInternalProxyOnly(&config.Networks, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateInternalAddresses(&config.Networks, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateProxyOnlySubnet(&config.Networks, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidatePrivateServiceConnectSubnet(&config.Networks, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateDisasterRecoverySubnet(&config.Networks, region, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateEgressApplianceSubnet(&config.Networks, field.NewPath("networks"))...)
+	// The chart only renders IPv4 subnets, hence dual-stack is never enabled.
+	allErrs = append(allErrs, ValidateSubnetIPFamilies(&config.Networks, false, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateControlPlanePeering(&config.Networks, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateInternalPurpose(&config.Networks, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateSubnetPurposePrefix(&config.Networks, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateReservedCIDRs(&config.Networks, field.NewPath("networks"))...)
+
+	var subnets []gcpv1alpha1.Subnet
+	for _, purpose := range subnetPurposes(&config.Networks) {
+		subnets = append(subnets, gcpv1alpha1.Subnet{Purpose: purpose, Name: resourceNames[subnetResourceNameKeys[purpose]]})
+	}
+	allErrs = append(allErrs, ValidateSubnetNames(subnets, field.NewPath("subnets"))...)
+	if config.Networks.VPC != nil {
+		allErrs = append(allErrs, ValidateVPCName(config.Networks.VPC.Name, field.NewPath("networks", "vpc", "name"))...)
+	}
+
+	allErrs = append(allErrs, ValidateFlowLogs(config.Networks.FlowLogs, field.NewPath("networks", "flowLogs"))...)
+	allErrs = append(allErrs, ValidateFlowLogsExportFilters(&config.Networks, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateMTU(&config.Networks, DefaultMTURange, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateUnmanagedFirewall(config.Networks.Firewall, field.NewPath("networks", "firewall"))...)
+	allErrs = append(allErrs, ValidateFirewallPriorities(config.Networks.Firewall, field.NewPath("networks", "firewall"))...)
+	allErrs = append(allErrs, ValidateCloudNATPorts(config.Networks.CloudNAT, field.NewPath("networks", "cloudNAT"))...)
+	allErrs = append(allErrs, ValidateCloudNATIPCounts(config.Networks.CloudNAT, field.NewPath("networks", "cloudNAT"))...)
+	allErrs = append(allErrs, ValidateCloudNATNetworkTier(config.Networks.CloudNAT, field.NewPath("networks", "cloudNAT"))...)
+	allErrs = append(allErrs, ValidateCloudRouterBGP(&config.Networks, field.NewPath("networks", "cloudRouterBGP"))...)
+	allErrs = append(allErrs, ValidateCustomRoutes(config.Networks.CustomRoutes, field.NewPath("networks", "customRoutes"))...)
+	allErrs = append(allErrs, ValidateAirGapped(&config.Networks, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateServicePerimeter(&config.Networks, field.NewPath("networks"))...)
+
+	allErrs = append(allErrs, ValidatePodServiceCIDRs(networks.Pods, networks.Services, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateNetworkingMode(config.Networks.NetworkingMode, config.Networks.Worker, networks.Services, field.NewPath("networks"))...)
+	allErrs = append(allErrs, ValidateSecondaryRangeCount(secondaryRanges(config, resourceNames), field.NewPath("secondaryRanges"))...)
+	allErrs = append(allErrs, ValidateSecondaryRangeNames(secondaryRanges(config, resourceNames), field.NewPath("secondaryRanges"))...)
+	allErrs = append(allErrs, ValidateStateRefresh(config.StateRefresh, &config.Networks, field.NewPath("stateRefresh"))...)
+	if aliasIPs := config.Networks.AliasIPs; aliasIPs != nil {
+		allErrs = append(allErrs, ValidateAliasIPRanges(config.Networks.Worker, networks.Pods, networks.Services, field.NewPath("networks"))...)
+		allErrs = append(allErrs, ValidateAliasIPPrefixLengths(aliasIPs, networks.Pods, networks.Services, field.NewPath("networks", "aliasIPs"))...)
+		allErrs = append(allErrs, ValidateNodeCIDRMaskSize(aliasIPs, config.Networks.Worker, networks.Pods, field.NewPath("networks", "aliasIPs"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateInfrastructureConfigComplete validates the given InfrastructureConfig against all naming, CIDR and
+// feature constraints checked when rendering the GCP Terraformer chart for the given cluster and ServiceAccount,
+// e.g. before submitting a shoot. The resources are named after the technical ID of the shoot like they are
+// named after the namespace of the Infrastructure. All errors are aggregated rather than stopping at the first
+// one. The quotas of the project are not checked, see RequiredQuotas.
+func ValidateInfrastructureConfigComplete(
+	config *gcpv1alpha1.InfrastructureConfig,
+	cluster *controller.Cluster,
+	account *internal.ServiceAccount,
+) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	networks, err := getK8SNetworks(cluster)
+	if err != nil {
+		return append(allErrs, field.InternalError(field.NewPath("cluster"), err))
+	}
+	if err := ValidateNetworkingConsistency(config, cluster); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("networks", "networkingMode"), networkingMode(config), err.Error()))
+	}
+
+	region, errs := NormalizeRegion(cluster.Shoot.Spec.Cloud.Region, field.NewPath("region"))
+	allErrs = append(allErrs, errs...)
+	if len(errs) == 0 {
+		allErrs = append(allErrs, ValidateAgainstCloudProfile(region, cluster.CloudProfile, field.NewPath("shoot", "spec", "cloud"))...)
+	}
+
+	if _, err := OutputKeys(config); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("outputKeyOverrides"), config.OutputKeyOverrides, err.Error()))
+	}
+	if email := serviceAccountEmail(config); email != nil {
+		if err := ValidateServiceAccountEmail(*email); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("serviceAccount", "email"), *email, err.Error()))
+		}
+	}
+
+	technicalIDPath := field.NewPath("shoot", "status", "technicalID")
+	technicalID := cluster.Shoot.Status.TechnicalID
+	resourceNames, err := computeResourceNames(technicalID)
+	if err != nil {
+		return append(allErrs, field.Invalid(technicalIDPath, technicalID, err.Error()))
+	}
+	if err := ValidateResourceNameLengths(managedResourceNames(config, resourceNames)); err != nil {
+		allErrs = append(allErrs, field.Invalid(technicalIDPath, technicalID, err.Error()))
+	}
+
+	return append(allErrs, validateConfig(config, account.ProjectID, region, networks, resourceNames)...)
+}
+
+// ComputeTerraformerChartValues computes the values for the GCP Terraformer chart.
+func ComputeTerraformerChartValues(
+	infra *extensionsv1alpha1.Infrastructure,
+	account *internal.ServiceAccount,
+	config *gcpv1alpha1.InfrastructureConfig,
+	cluster *controller.Cluster,
+) (map[string]interface{}, error) {
+	var (
+		vpcName           = DefaultVPCName
+		createVPC         = createVPC(config)
+		createCloudRouter = false
+		cloudRouterName   = ""
+	)
+
+	networks, err := getK8SNetworks(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	region, err := getRegion(infra, cluster)
+	if err != nil {
+		return nil, err
+	}
+	region, errs := NormalizeRegion(region, field.NewPath("region"))
+	if len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+	if errs := ValidateAgainstCloudProfile(region, cluster.CloudProfile, field.NewPath("spec")); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+
+	outputKeys, err := OutputKeys(config)
+	if err != nil {
+		return nil, err
+	}
+
+	resourceNames, err := computeResourceNames(infra.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	resourceNames = managedResourceNames(config, resourceNames)
+	if err := ValidateResourceNameLengths(resourceNames); err != nil {
+		return nil, err
+	}
+
+	if email := serviceAccountEmail(config); email != nil {
+		if err := ValidateServiceAccountEmail(*email); err != nil {
+			return nil, err
+		}
+	}
+	if errs := validateConfig(config, account.ProjectID, region, networks, resourceNames); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+
+	vpcValues := map[string]interface{}{}
+	if createVPC {
+		// The description of a VPC cannot be changed, hence it is only rendered if it is configured.
+		if config.Networks.VPCDescription != nil {
+			vpcValues["description"] = *config.Networks.VPCDescription
+		}
+	} else {
+		vpcName = config.Networks.VPC.Name
+	}
+	vpcValues["name"] = vpcName
+
+	if config.Networks.CloudNAT != nil {
+		if createVPC {
+			createCloudRouter = true
+			cloudRouterName = DefaultCloudRouterName
+		} else if config.Networks.VPC.CloudRouter != nil {
+			cloudRouterName = config.Networks.VPC.CloudRouter.Name
+		}
+	}
+
+	podsRange, servicesRange := networks.Pods, networks.Services
+	if aliasIPs := config.Networks.AliasIPs; aliasIPs != nil {
+		if podsRange, err = carveCIDR(*networks.Pods, aliasIPs.PodsPrefixLength); err != nil {
+			return nil, err
+		}
+		if servicesRange, err = carveCIDR(*networks.Services, aliasIPs.ServicesPrefixLength); err != nil {
+			return nil, err
+		}
+	}
+
+	flowLogsValues := map[string]interface{}{
+		"enabled": config.Networks.FlowLogs != nil,
+	}
+	if flowLogs := config.Networks.FlowLogs; flowLogs != nil && flowLogs.AggregationInterval != nil {
+		flowLogsValues["aggregationInterval"] = *flowLogs.AggregationInterval
+	}
+	if destination := flowLogsExportDestination(config); destination != nil {
+		flowLogsValues["exportDestination"] = *destination
+
+		if filter, ok := config.Networks.FlowLogs.ExportFilters[gcpv1alpha1.PurposeNodes]; ok {
+			flowLogsValues["nodesExportFilter"] = terraformStringEscaper.Replace(filter)
+		}
+		if filter, ok := config.Networks.FlowLogs.ExportFilters[gcpv1alpha1.PurposeInternal]; ok {
+			flowLogsValues["internalExportFilter"] = terraformStringEscaper.Replace(filter)
+		}
+		if config.Networks.FlowLogs.BigQueryPartitionedTables {
+			flowLogsValues["bigQueryPartitionedTables"] = true
+		}
+	}
+
+	cloudNATValues := map[string]interface{}{
+		"enabled":    config.Networks.CloudNAT != nil,
+		"routerName": cloudRouterName,
+	}
+	if cloudNAT := config.Networks.CloudNAT; cloudNAT != nil {
+		if cloudNAT.MinPortsPerVM != nil {
+			cloudNATValues["minPortsPerVM"] = *cloudNAT.MinPortsPerVM
+		}
+		if cloudNAT.MaxPortsPerVM != nil {
+			cloudNATValues["maxPortsPerVM"] = *cloudNAT.MaxPortsPerVM
+		}
+		if cloudNAT.EnableDynamicPortAllocation != nil {
+			cloudNATValues["enableDynamicPortAllocation"] = *cloudNAT.EnableDynamicPortAllocation
+		}
+		cloudNATValues["subnets"] = cloudNATSubnets(&config.Networks)
+		addresses, err := natAddressValues(infra.Namespace, "cloud-nat", natIPCount(cloudNAT.IPCount), natNetworkTier(cloudNAT))
+		if err != nil {
+			return nil, err
+		}
+		cloudNATValues["addresses"] = addresses
+		if len(cloudNAT.Gateways) > 0 {
+			gateways, err := natGatewayValues(infra.Namespace, cloudNAT.Gateways, natNetworkTier(cloudNAT))
+			if err != nil {
+				return nil, err
+			}
+			cloudNATValues["gateways"] = gateways
+		}
+	}
+
+	cloudRouterValues := map[string]interface{}{}
+	if bgp := config.Networks.CloudRouterBGP; bgp != nil {
+		bgpValues := map[string]interface{}{
+			"asn":           bgp.ASN,
+			"advertiseMode": string(cloudRouterAdvertiseMode(bgp)),
+		}
+		if bgp.AdvertiseAllSubnets {
+			bgpValues["advertiseAllSubnets"] = true
+		}
+		if len(bgp.AdvertisedIPRanges) > 0 {
+			bgpValues["advertisedIPRanges"] = bgp.AdvertisedIPRanges
+		}
+		cloudRouterValues["bgp"] = bgpValues
+	}
+
+	managedFirewall, denyAllEgress, allowInternalAccess, allowHealthChecks := true, false, true, true
+	if config.Networks.Firewall != nil {
+		managedFirewall = !config.Networks.Firewall.Unmanaged
+		denyAllEgress = config.Networks.Firewall.DenyAllEgress
+		allowInternalAccess = !config.Networks.Firewall.DisableAllowInternalAccess
+		allowHealthChecks = !config.Networks.Firewall.DisableAllowHealthChecks
+	}
+	allowPriority, denyAllEgressPriority := firewallPriorities(config.Networks.Firewall)
+
+	google := map[string]interface{}{
+		"region":  region,
+		"project": account.ProjectID,
+	}
+	timeouts := map[string]interface{}{}
+	if config.Timeouts != nil {
+		if config.Timeouts.Request != nil {
+			google["requestTimeout"] = config.Timeouts.Request.Duration.String()
+		}
+		for name, timeout := range map[string]*metav1.Duration{
+			"create": config.Timeouts.Create,
+			"update": config.Timeouts.Update,
+			"delete": config.Timeouts.Delete,
+		} {
+			if timeout != nil {
+				timeouts[name] = timeout.Duration.String()
+			}
+		}
+	}
+
+	networkValues := map[string]interface{}{
+		"pods":     podsRange,
+		"services": servicesRange,
+		"worker":   config.Networks.Worker,
+		"internal": config.Networks.Internal,
+	}
+	if config.Networks.InternalProxyOnly {
+		networkValues["internalProxyOnly"] = true
+	}
+	if config.Networks.AirGapped || config.Networks.ServicePerimeter != nil {
+		networkValues["privateGoogleAccess"] = true
+	}
+	if config.Networks.ServicePerimeter != nil {
+		networkValues["restrictedAPIs"] = RestrictedGoogleAPIsRange
+		networkValues["restrictedAPIsAddresses"] = RestrictedGoogleAPIsAddresses
+	}
+	if proxyOnly := config.Networks.ProxyOnly; proxyOnly != nil {
+		networkValues["proxyOnly"] = map[string]interface{}{
+			"cidr": proxyOnly.CIDR,
+			"role": proxyOnlySubnetRole(proxyOnly),
+		}
+	}
+	if config.Networks.PrivateServiceConnect != nil {
+		networkValues["privateServiceConnect"] = *config.Networks.PrivateServiceConnect
+	}
+	if disasterRecovery := config.Networks.DisasterRecovery; disasterRecovery != nil {
+		networkValues["disasterRecovery"] = map[string]interface{}{
+			"region": disasterRecovery.Region,
+			"cidr":   disasterRecovery.CIDR,
+		}
+	}
+	if egressAppliance := config.Networks.EgressAppliance; egressAppliance != nil {
+		networkValues["egressAppliance"] = map[string]interface{}{
+			"cidr": egressAppliance.CIDR,
+		}
+	}
+	if config.Networks.MTU != nil {
+		networkValues["mtu"] = *config.Networks.MTU
+	}
+	if peering := config.Networks.ControlPlanePeering; peering != nil {
+		_, network, err := net.ParseCIDR(string(*peering))
+		if err != nil {
+			return nil, err
+		}
+		prefixLength, _ := network.Mask.Size()
+		peeringValues := map[string]interface{}{
+			"address":      network.IP.String(),
+			"prefixLength": prefixLength,
+		}
+		if routes := config.Networks.ControlPlanePeeringRoutes; routes != nil {
+			peeringValues["routes"] = map[string]interface{}{
+				"importCustomRoutes": routes.ImportCustomRoutes,
+				"exportCustomRoutes": routes.ExportCustomRoutes,
+			}
+		}
+		networkValues["controlPlanePeering"] = peeringValues
+	}
+
+	values := map[string]interface{}{
+		"google": google,
+		"create": map[string]interface{}{
+			"vpc":            createVPC,
+			"subnets":        true,
+			"serviceAccount": createServiceAccount(config),
+			"cloudRouter":    createCloudRouter,
+		},
+		"vpc":           vpcValues,
+		"clusterName":   infra.Namespace,
+		"resourceNames": resourceNames,
+		"networks":      networkValues,
+		"aliasIPs": map[string]interface{}{
+			"enabled": config.Networks.AliasIPs != nil,
+		},
+		"cilium": map[string]interface{}{
+			"enabled": networkingMode(config) == gcpv1alpha1.NetworkingModeCilium,
+		},
+		"flowLogs":    flowLogsValues,
+		"cloudNAT":    cloudNATValues,
+		"cloudRouter": cloudRouterValues,
+		"firewall": map[string]interface{}{
+			"managed":                 managedFirewall,
+			"denyAllEgress":           denyAllEgress,
+			"allowInternalAccess":     allowInternalAccess,
+			"allowExternalAccess":     !config.Networks.AirGapped,
+			"allowHealthChecks":       allowHealthChecks,
+			"healthCheckSourceRanges": HealthCheckSourceRanges,
+			"priorities": map[string]interface{}{
+				"allow":         allowPriority,
+				"denyAllEgress": denyAllEgressPriority,
+			},
+		},
+		"outputKeys": map[string]interface{}{
+			"vpcName":                      outputKeys[TerraformerOutputKeyVPCName],
+			"serviceAccountEmail":          outputKeys[TerraformerOutputKeyServiceAccountEmail],
+			"subnetNodes":                  outputKeys[TerraformerOutputKeySubnetNodes],
+			"subnetInternal":               outputKeys[TerraformerOutputKeySubnetInternal],
+			"subnetProxyOnly":              outputKeys[TerraformerOutputKeySubnetProxyOnly],
+			"subnetProxyOnlyRole":          outputKeys[TerraformerOutputKeySubnetProxyOnlyRole],
+			"subnetPSC":                    outputKeys[TerraformerOutputKeySubnetPSC],
+			"subnetDisasterRecovery":       outputKeys[TerraformerOutputKeySubnetDisasterRecovery],
+			"subnetDisasterRecoveryRegion": outputKeys[TerraformerOutputKeySubnetDisasterRecoveryRegion],
+			"subnetEgressAppliance":        outputKeys[TerraformerOutputKeySubnetEgressAppliance],
+			"subnetNodesSelfLink":          outputKeys[TerraformerOutputKeySubnetNodesSelfLink],
+			"subnetInternalSelfLink":       outputKeys[TerraformerOutputKeySubnetInternalSelfLink],
+			"natIPs":                       outputKeys[TerraformerOutputKeyNATIPs],
+			"natGateways":                  outputKeys[TerraformerOutputKeyNATGateways],
+			"routes":                       outputKeys[TerraformerOutputKeyRoutes],
+			"flowLogsSink":                 outputKeys[TerraformerOutputKeyFlowLogsSink],
+			"controlPlanePeeringRange":     outputKeys[TerraformerOutputKeyControlPlanePeeringRange],
+			"internalAddresses":            outputKeys[TerraformerOutputKeyInternalAddresses],
+		},
+	}
+	if len(timeouts) > 0 {
+		values["timeouts"] = timeouts
+	}
+	if config.DiskEncryptionKey != "" {
+		values["diskEncryptionKey"] = config.DiskEncryptionKey
+	}
+	if project := serviceAccountProject(config); project != nil {
+		values["serviceAccount"] = map[string]interface{}{
+			"project": *project,
+		}
+	}
+	if users := workloadIdentityUsers(config); len(users) > 0 {
+		members := make([]string, 0, len(users))
+		for _, user := range users {
+			members = append(members, WorkloadIdentityMember(account.ProjectID, user))
+		}
+		values["workloadIdentity"] = map[string]interface{}{
+			"members": members,
+		}
+	}
+	if len(configuredRoutes(&config.Networks)) > 0 {
+		routes, err := customRouteValues(infra.Namespace, config.Networks.CustomRoutes)
+		if err != nil {
+			return nil, err
+		}
+		if egressAppliance := config.Networks.EgressAppliance; egressAppliance != nil {
+			steeringRoutes, err := egressApplianceRouteValues(infra.Namespace, egressAppliance)
+			if err != nil {
+				return nil, err
+			}
+			routes = append(routes, steeringRoutes...)
+		}
+		values["customRoutes"] = routes
+	}
+	if count := internalAddressCount(config); count > 0 {
+		addresses, err := internalAddressValues(infra.Namespace, count)
+		if err != nil {
+			return nil, err
+		}
+		values["internalAddresses"] = addresses
+	}
+	return values, nil
+}
+
+// internalAddressValues computes the chart values of the given number of internal addresses. The resource name of
+// each address is suffixed with its index.
+func internalAddressValues(namespace string, count int32) ([]interface{}, error) {
+	values := make([]interface{}, 0, count)
+	for i := int32(0); i < count; i++ {
+		resourceName, err := ResourceName(namespace, fmt.Sprintf("internal-address-%d", i))
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, map[string]interface{}{
+			"resourceName": resourceName,
+		})
+	}
+	return values, nil
+}
+
+// customRouteValues computes the chart values of the given custom routes. The resource name of each route is
+// suffixed with its name.
+func customRouteValues(namespace string, routes []gcpv1alpha1.Route) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(routes))
+	for _, route := range routes {
+		resourceName, err := ResourceName(namespace, "route-"+route.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		routeValues := map[string]interface{}{
+			"name":         route.Name,
+			"resourceName": resourceName,
+			"destination":  string(route.Destination),
+		}
+		if route.NextHopIP != nil {
+			routeValues["nextHopIP"] = *route.NextHopIP
+		}
+		if route.NextHopVPNTunnel != nil {
+			routeValues["nextHopVPNTunnel"] = *route.NextHopVPNTunnel
+		}
+		if route.NextHopILB != nil {
+			routeValues["nextHopILB"] = *route.NextHopILB
+		}
+		values = append(values, routeValues)
+	}
+	return values, nil
+}
+
+// natGatewayValues computes the chart values of the given additional NAT gateways of a Cloud NAT, whose external
+// addresses are reserved in the given network tier. The resource name of each gateway is suffixed by its name.
+func natGatewayValues(namespace string, gateways []gcpv1alpha1.CloudNATGateway, networkTier gcpv1alpha1.NetworkTier) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(gateways))
+	for _, gateway := range gateways {
+		resourceName, err := ResourceName(namespace, "cloud-nat-"+gateway.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		addresses, err := natAddressValues(namespace, "cloud-nat-"+gateway.Name, natIPCount(gateway.IPCount), networkTier)
+		if err != nil {
+			return nil, err
+		}
+
+		purposes := append([]gcpv1alpha1.SubnetPurpose{}, gateway.Subnets...)
+		sortSubnetPurposes(purposes)
+
+		values = append(values, map[string]interface{}{
+			"name":         gateway.Name,
+			"resourceName": resourceName,
+			"subnets":      subnetPurposeStrings(purposes),
+			"addresses":    addresses,
+		})
+	}
+	return values, nil
+}
+
+// natAddressValues computes the chart values of the given number of external addresses reserved in the given
+// network tier for the NAT gateway whose resource name has the given suffix.
+func natAddressValues(namespace, gatewaySuffix string, count int32, networkTier gcpv1alpha1.NetworkTier) ([]interface{}, error) {
+	values := make([]interface{}, 0, count)
+	for i := int32(0); i < count; i++ {
+		resourceName, err := ResourceName(namespace, fmt.Sprintf("%s-address-%d", gatewaySuffix, i))
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, map[string]interface{}{
+			"resourceName": resourceName,
+			"networkTier":  string(networkTier),
+		})
+	}
+	return values, nil
+}
+
+// natNetworkTier returns the network tier of the external addresses of the given CloudNAT, defaulting to
+// NetworkTierPremium.
+func natNetworkTier(cloudNAT *gcpv1alpha1.CloudNAT) gcpv1alpha1.NetworkTier {
+	if cloudNAT.NetworkTier == nil {
+		return gcpv1alpha1.NetworkTierPremium
+	}
+	return *cloudNAT.NetworkTier
+}
+
+// natIPCount returns the given number of external IPs reserved for a NAT gateway, defaulting to 1.
+func natIPCount(ipCount *int32) int32 {
+	if ipCount == nil {
+		return 1
+	}
+	return *ipCount
+}
+
+// cloudRouterAdvertiseMode returns the advertise mode of the given CloudRouterBGP, defaulting to
+// BGPAdvertiseModeDefault.
+func cloudRouterAdvertiseMode(bgp *gcpv1alpha1.CloudRouterBGP) gcpv1alpha1.BGPAdvertiseMode {
+	if bgp.AdvertiseMode == nil {
+		return gcpv1alpha1.BGPAdvertiseModeDefault
+	}
+	return *bgp.AdvertiseMode
+}
+
+// egressApplianceRouteValues computes the chart values of the routes steering the egress traffic through the
+// appliances of the given EgressApplianceSubnet. They are rendered like custom routes, but depend on the subnet
+// of the appliances. They only apply to the nodes, which carry the name of the cluster as network tag, as the
+// egress traffic of the appliances themselves would otherwise be steered back to them.
+func egressApplianceRouteValues(namespace string, egressAppliance *gcpv1alpha1.EgressApplianceSubnet) ([]interface{}, error) {
+	values, err := customRouteValues(namespace, egressApplianceRoutes(egressAppliance))
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		routeValues := value.(map[string]interface{})
+		routeValues["egressAppliance"] = true
+		routeValues["tags"] = []interface{}{namespace}
+		if egressAppliance.Priority != nil {
+			routeValues["priority"] = *egressAppliance.Priority
+		}
+	}
+	return values, nil
+}
+
+// egressApplianceRoutes returns the routes steering the traffic to the destinations of the given
+// EgressApplianceSubnet through its next hop. The routes are named by the index of their destination.
+func egressApplianceRoutes(egressAppliance *gcpv1alpha1.EgressApplianceSubnet) []gcpv1alpha1.Route {
+	destinations := egressAppliance.Destinations
+	if len(destinations) == 0 {
+		destinations = []gardencorev1alpha1.CIDR{DefaultEgressApplianceDestination}
+	}
+
+	routes := make([]gcpv1alpha1.Route, 0, len(destinations))
+	for i, destination := range destinations {
+		nextHopIP := egressAppliance.NextHopIP
+		routes = append(routes, gcpv1alpha1.Route{
+			Name:        egressApplianceRouteName(i),
+			Destination: destination,
+			NextHopIP:   &nextHopIP,
+		})
+	}
+	return routes
+}
+
+// egressApplianceRouteName returns the name of the route steering the traffic to the destination with the given
+// index through the egress appliances.
+func egressApplianceRouteName(index int) string {
+	return fmt.Sprintf("egress-appliance-%d", index)
+}
+
+// routeCount returns the number of routes created for the given InfrastructureConfig, i.e. the configured routes
+// and the route to the restricted VIP of a service perimeter. The private DNS zone resolving the Google APIs to the
+// restricted VIP is not counted as it is no route.
+func routeCount(config *gcpv1alpha1.InfrastructureConfig) int {
+	n := len(configuredRoutes(&config.Networks))
+	if config.Networks.ServicePerimeter != nil {
+		n++
+	}
+	return n
+}
+
+// configuredRoutes returns all routes created for the given NetworkConfig, i.e. the custom routes followed by the
+// routes steering the traffic through the egress appliances.
+func configuredRoutes(networks *gcpv1alpha1.NetworkConfig) []gcpv1alpha1.Route {
+	if networks.EgressAppliance == nil {
+		return networks.CustomRoutes
+	}
+	return append(append([]gcpv1alpha1.Route{}, networks.CustomRoutes...), egressApplianceRoutes(networks.EgressAppliance)...)
+}
+
+// FirewallApplyTargets returns the terraform apply arguments that restrict an apply of the GCP Terraformer chart
+// rendered for the given InfrastructureConfig to its firewall rules, e.g. for applying firewall changes without
+// touching the networking. It is only a helper for operators running terraform against the state of the
+// infrastructure manually; the actuator always applies the whole chart. Terraform still applies pending changes of
+// the resources the rules depend on, i.e. of a created VPC.
+func FirewallApplyTargets(config *gcpv1alpha1.InfrastructureConfig) ([]string, error) {
+	resources := firewallRuleResources(config)
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("no firewall rules are managed for the infrastructure")
+	}
+
+	targets := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		targets = append(targets, "-target=google_compute_firewall."+resource)
+	}
+	return targets, nil
+}
+
+// firewallRuleResources returns the names of the firewall rule resources the GCP Terraformer chart renders for the
+// given InfrastructureConfig.
+func firewallRuleResources(config *gcpv1alpha1.InfrastructureConfig) []string {
+	firewall := config.Networks.Firewall
+	if firewall != nil && firewall.Unmanaged {
+		return nil
 	}
-)
 
-// getK8SNetworks gets the K8SNetworks from the given controller.Cluster.
-func getK8SNetworks(cluster *controller.Cluster) *gardencorev1alpha1.K8SNetworks {
-	return &cluster.Shoot.Spec.Cloud.GCP.Networks.K8SNetworks
+	var resources []string
+	if firewall == nil || !firewall.DisableAllowInternalAccess {
+		resources = append(resources, "rule-allow-internal-access")
+	}
+	if !config.Networks.AirGapped {
+		resources = append(resources, "rule-allow-external-access")
+	}
+	if firewall == nil || !firewall.DisableAllowHealthChecks {
+		resources = append(resources, "rule-allow-health-checks")
+	}
+	if firewall != nil && firewall.DenyAllEgress {
+		resources = append(resources, "rule-deny-all-egress", "rule-allow-cluster-egress", "rule-allow-metadata-egress", "rule-allow-control-plane-egress")
+	}
+	return resources
 }
 
-// ComputeTerraformerChartValues computes the values for the GCP Terraformer chart.
-func ComputeTerraformerChartValues(
+// ComputeTerraformerChartValuesYAML computes the values for the GCP Terraformer chart like
+// ComputeTerraformerChartValues and marshals them to YAML, e.g. for reviewing them. The keys of all maps are
+// sorted, hence the YAML is deterministic. The values never contain credentials as these are passed to terraform
+// via the TF_VAR_SERVICEACCOUNT environment variable only.
+func ComputeTerraformerChartValuesYAML(
 	infra *extensionsv1alpha1.Infrastructure,
 	account *internal.ServiceAccount,
 	config *gcpv1alpha1.InfrastructureConfig,
 	cluster *controller.Cluster,
-) map[string]interface{} {
-	var (
-		vpcName   = DefaultVPCName
-		createVPC = true
-	)
-
-	networks := getK8SNetworks(cluster)
-
-	if config.Networks.VPC != nil {
-		createVPC = false
-		vpcName = config.Networks.VPC.Name
+) ([]byte, error) {
+	values, err := ComputeTerraformerChartValues(infra, account, config, cluster)
+	if err != nil {
+		return nil, err
 	}
 
-	return map[string]interface{}{
-		"google": map[string]interface{}{
-			"region":  infra.Spec.Region,
-			"project": account.ProjectID,
-		},
-		"create": map[string]interface{}{
-			"vpc": createVPC,
-		},
-		"vpc": map[string]interface{}{
-			"name": vpcName,
-		},
-		"clusterName": infra.Namespace,
-		"networks": map[string]interface{}{
-			"pods":     networks.Pods,
-			"services": networks.Services,
-			"worker":   config.Networks.Worker,
-			"internal": config.Networks.Internal,
-		},
-		"outputKeys": map[string]interface{}{
-			"vpcName":             TerraformerOutputKeyVPCName,
-			"serviceAccountEmail": TerraformerOutputKeyServiceAccountEmail,
-			"subnetNodes":         TerraformerOutputKeySubnetNodes,
-			"subnetInternal":      TerraformerOutputKeySubnetInternal,
-		},
-	}
+	return yaml.Marshal(values)
 }
 
 // RenderTerraformerChart renders the gcp-infra chart with the given values.
@@ -118,7 +1097,10 @@ func RenderTerraformerChart(
 	config *gcpv1alpha1.InfrastructureConfig,
 	cluster *controller.Cluster,
 ) (*TerraformFiles, error) {
-	values := ComputeTerraformerChartValues(infra, account, config, cluster)
+	values, err := ComputeTerraformerChartValues(infra, account, config, cluster)
+	if err != nil {
+		return nil, err
+	}
 
 	release, err := renderer.Render(filepath.Join(InternalChartsPath, "gcp-infra"), "gcp-infra", infra.Namespace, values)
 	if err != nil {
@@ -128,10 +1110,32 @@ func RenderTerraformerChart(
 	return &TerraformFiles{
 		Main:      release.FileContent("main.tf"),
 		Variables: release.FileContent("variables.tf"),
-		TFVars:    []byte(release.FileContent("terraform.tfvars")),
+		TFVars:    []byte(tfVarsFileContent(release)),
 	}, nil
 }
 
+// tfVarsFileContent returns the content of the terraform variables file of the given rendered chart. It is
+// TFVarsFileName if the chart renders it, otherwise the first rendered file with the extension of terraform
+// variables files, so that charts can name the file differently.
+func tfVarsFileContent(release *chartrenderer.RenderedChart) string {
+	if content := release.FileContent(TFVarsFileName); content != "" {
+		return content
+	}
+
+	files := release.Files()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if strings.HasSuffix(name, tfVarsFileExtension) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return files[names[0]]
+}
+
 // TerraformFiles are the files that have been rendered from the infrastructure chart.
 type TerraformFiles struct {
 	Main      string
@@ -139,6 +1143,41 @@ type TerraformFiles struct {
 	TFVars    []byte
 }
 
+// LoadTerraformFiles loads the TerraformFiles stored in the given directory, e.g. for inspecting or re-applying
+// previously rendered files. The directory has to contain main.tf, variables.tf and TFVarsFileName.
+func LoadTerraformFiles(dir string) (*TerraformFiles, error) {
+	contents := make(map[string][]byte, 3)
+	for _, name := range []string{"main.tf", "variables.tf", TFVarsFileName} {
+		content, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("could not read terraform file %q: %v", name, err)
+		}
+		contents[name] = content
+	}
+
+	return &TerraformFiles{
+		Main:      string(contents["main.tf"]),
+		Variables: string(contents["variables.tf"]),
+		TFVars:    contents[TFVarsFileName],
+	}, nil
+}
+
+// String returns the labeled contents of all files, e.g. for logging them when debugging a chart render.
+func (f *TerraformFiles) String() string {
+	var b strings.Builder
+	for _, file := range []struct {
+		name    string
+		content string
+	}{
+		{"main.tf", f.Main},
+		{"variables.tf", f.Variables},
+		{TFVarsFileName, string(f.TFVars)},
+	} {
+		fmt.Fprintf(&b, "--- %s ---\n%s\n", file.name, file.content)
+	}
+	return b.String()
+}
+
 // TerraformState is the Terraform state for an infrastructure.
 type TerraformState struct {
 	// VPCName is the name of the VPC created for an infrastructure.
@@ -149,36 +1188,495 @@ type TerraformState struct {
 	SubnetNodes string
 	// SubnetInternal is the CIDR of the internal subnet of an infrastructure.
 	SubnetInternal *string
+	// SubnetProxyOnly is the name of the proxy-only subnet of an infrastructure.
+	SubnetProxyOnly *string
+	// SubnetProxyOnlyRole is the role of the proxy-only subnet of an infrastructure.
+	SubnetProxyOnlyRole *string
+	// SubnetPSC is the name of the Private Service Connect subnet of an infrastructure.
+	SubnetPSC *string
+	// SubnetDisasterRecovery is the name of the disaster recovery subnet of an infrastructure.
+	SubnetDisasterRecovery *string
+	// SubnetDisasterRecoveryRegion is the region of the disaster recovery subnet of an infrastructure.
+	SubnetDisasterRecoveryRegion *string
+	// SubnetEgressAppliance is the name of the egress appliance subnet of an infrastructure.
+	SubnetEgressAppliance *string
+	// ControlPlanePeeringRange is the range reserved for the control plane peering of an infrastructure. It is
+	// empty if no range is reserved.
+	ControlPlanePeeringRange string
+	// SubnetNodesSelfLink is the self-link of the nodes subnet of an infrastructure. It is nil for states
+	// written before the output was introduced.
+	SubnetNodesSelfLink *string
+	// SubnetInternalSelfLink is the self-link of the internal subnet of an infrastructure. It is nil for states
+	// written before the output was introduced.
+	SubnetInternalSelfLink *string
+	// NatIPs are the external IPs reserved for all NAT gateways of an infrastructure. They are empty as long as
+	// no IPs have been reserved.
+	NatIPs []string
+	// NATGateways are the NAT gateways of the Cloud NAT of an infrastructure. They are nil for states written
+	// before the output was introduced.
+	NATGateways []NATGatewayState
+	// Routes are the names of the custom routes of an infrastructure.
+	Routes []string
+	// FlowLogsSink is the name of the logging sink the flow logs of an infrastructure are exported to. It is
+	// empty if the flow logs are not exported.
+	FlowLogsSink string
+	// SubnetInternalRole is the proxy-only role of the internal subnet of an infrastructure. It is nil if the
+	// internal subnet is not proxy-only.
+	SubnetInternalRole *string
+	// SubnetInternalPurpose is the purpose label of the internal subnet in the status. PurposeInternal is used
+	// if it is nil.
+	SubnetInternalPurpose *string
+	// SubnetPurposePrefix is prepended to the purposes of all subnets in the status. No prefix is used if it is
+	// nil.
+	SubnetPurposePrefix *string
+	// InternalAddresses are the internal IP addresses reserved in the internal subnet of an infrastructure.
+	InternalAddresses []string
+}
+
+// NATGatewayState is the Terraform state of a NAT gateway of an infrastructure.
+type NATGatewayState struct {
+	// Name is the name of the NAT gateway.
+	Name string `json:"name"`
+	// IPs are the external IPs reserved for the NAT gateway. They are empty as long as no IPs have been
+	// reserved.
+	IPs []string `json:"ips,omitempty"`
+}
+
+// TerraformStateSnapshotVersion is the version of the JSON representation of a TerraformState.
+// Fields added to the representation have to be optional so that older snapshots can still be read.
+const TerraformStateSnapshotVersion = "v1"
+
+// terraformStateSnapshot is the versioned JSON representation of a TerraformState.
+type terraformStateSnapshot struct {
+	Version                string            `json:"version"`
+	VPCName                string            `json:"vpcName"`
+	ServiceAccountEmail    string            `json:"serviceAccountEmail"`
+	SubnetNodes            string            `json:"subnetNodes"`
+	SubnetInternal         *string           `json:"subnetInternal,omitempty"`
+	SubnetProxyOnly        *string           `json:"subnetProxyOnly,omitempty"`
+	SubnetProxyOnlyRole    *string           `json:"subnetProxyOnlyRole,omitempty"`
+	SubnetPSC              *string           `json:"subnetPSC,omitempty"`
+	SubnetNodesSelfLink    *string           `json:"subnetNodesSelfLink,omitempty"`
+	SubnetInternalSelfLink *string           `json:"subnetInternalSelfLink,omitempty"`
+	SubnetInternalPurpose  *string           `json:"subnetInternalPurpose,omitempty"`
+	SubnetPurposePrefix    *string           `json:"subnetPurposePrefix,omitempty"`
+	SubnetInternalRole     *string           `json:"subnetInternalRole,omitempty"`
+	NatIPs                 []string          `json:"natIPs,omitempty"`
+	NATGateways            []NATGatewayState `json:"natGateways,omitempty"`
+	Routes                 []string          `json:"routes,omitempty"`
+	InternalAddresses      []string          `json:"internalAddresses,omitempty"`
+
+	FlowLogsSink string `json:"flowLogsSink,omitempty"`
+
+	SubnetDisasterRecovery       *string `json:"subnetDisasterRecovery,omitempty"`
+	SubnetDisasterRecoveryRegion *string `json:"subnetDisasterRecoveryRegion,omitempty"`
+	SubnetEgressAppliance        *string `json:"subnetEgressAppliance,omitempty"`
+	ControlPlanePeeringRange     string  `json:"controlPlanePeeringRange,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (t TerraformState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&terraformStateSnapshot{
+		Version:                TerraformStateSnapshotVersion,
+		VPCName:                t.VPCName,
+		ServiceAccountEmail:    t.ServiceAccountEmail,
+		SubnetNodes:            t.SubnetNodes,
+		SubnetInternal:         t.SubnetInternal,
+		SubnetProxyOnly:        t.SubnetProxyOnly,
+		SubnetProxyOnlyRole:    t.SubnetProxyOnlyRole,
+		SubnetPSC:              t.SubnetPSC,
+		SubnetNodesSelfLink:    t.SubnetNodesSelfLink,
+		SubnetInternalSelfLink: t.SubnetInternalSelfLink,
+		SubnetInternalPurpose:  t.SubnetInternalPurpose,
+		SubnetPurposePrefix:    t.SubnetPurposePrefix,
+		SubnetInternalRole:     t.SubnetInternalRole,
+		NatIPs:                 t.NatIPs,
+		NATGateways:            t.NATGateways,
+		Routes:                 t.Routes,
+		InternalAddresses:      t.InternalAddresses,
+
+		FlowLogsSink: t.FlowLogsSink,
+
+		SubnetDisasterRecovery:       t.SubnetDisasterRecovery,
+		SubnetDisasterRecoveryRegion: t.SubnetDisasterRecoveryRegion,
+		SubnetEgressAppliance:        t.SubnetEgressAppliance,
+		ControlPlanePeeringRange:     t.ControlPlanePeeringRange,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *TerraformState) UnmarshalJSON(data []byte) error {
+	snapshot := &terraformStateSnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return err
+	}
+	if snapshot.Version != TerraformStateSnapshotVersion {
+		return fmt.Errorf("unsupported terraform state snapshot version %q", snapshot.Version)
+	}
+
+	*t = TerraformState{
+		VPCName:                snapshot.VPCName,
+		ServiceAccountEmail:    snapshot.ServiceAccountEmail,
+		SubnetNodes:            snapshot.SubnetNodes,
+		SubnetInternal:         snapshot.SubnetInternal,
+		SubnetProxyOnly:        snapshot.SubnetProxyOnly,
+		SubnetProxyOnlyRole:    snapshot.SubnetProxyOnlyRole,
+		SubnetPSC:              snapshot.SubnetPSC,
+		SubnetNodesSelfLink:    snapshot.SubnetNodesSelfLink,
+		SubnetInternalSelfLink: snapshot.SubnetInternalSelfLink,
+		SubnetInternalPurpose:  snapshot.SubnetInternalPurpose,
+		SubnetPurposePrefix:    snapshot.SubnetPurposePrefix,
+		SubnetInternalRole:     snapshot.SubnetInternalRole,
+		NatIPs:                 snapshot.NatIPs,
+		NATGateways:            snapshot.NATGateways,
+		Routes:                 snapshot.Routes,
+		InternalAddresses:      snapshot.InternalAddresses,
+
+		FlowLogsSink: snapshot.FlowLogsSink,
+
+		SubnetDisasterRecovery:       snapshot.SubnetDisasterRecovery,
+		SubnetDisasterRecoveryRegion: snapshot.SubnetDisasterRecoveryRegion,
+		SubnetEgressAppliance:        snapshot.SubnetEgressAppliance,
+		ControlPlanePeeringRange:     snapshot.ControlPlanePeeringRange,
+	}
+	return nil
+}
+
+// TerraformStateReader reads the state of a Terraformer. It is implemented by *terraformer.Terraformer.
+type TerraformStateReader interface {
+	// GetStateOutputVariables returns the given output variables of the terraform state.
+	GetStateOutputVariables(variables ...string) (map[string]string, error)
+}
+
+var _ TerraformStateReader = &terraformer.Terraformer{}
+
+// ExtractTerraformState extracts the TerraformState from the given TerraformStateReader. It only reads
+// the terraform output variables of the state and does not depend on the chart being rendered.
+func ExtractTerraformState(tf TerraformStateReader, config *gcpv1alpha1.InfrastructureConfig) (*TerraformState, error) {
+	keys, err := OutputKeys(config)
+	if err != nil {
+		return nil, err
+	}
+
+	vars, err := tf.GetStateOutputVariables(stateOutputKeys(config, keys)...)
+	if err != nil {
+		return nil, err
+	}
+
+	state := terraformStateFromOutputs(config, keys, vars)
+	if errs := readOptionalStateOutputVariables(tf, config, keys, state); len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+	return state, nil
+}
+
+// readOptionalStateOutputVariables reads the terraform output variables of the given InfrastructureConfig that
+// may be missing in the state into the given TerraformState. It returns the errors of all variables that could
+// not be read.
+func readOptionalStateOutputVariables(tf TerraformStateReader, config *gcpv1alpha1.InfrastructureConfig, keys map[string]string, state *TerraformState) []error {
+	var (
+		errs []error
+		err  error
+	)
+
+	if state.SubnetNodesSelfLink, err = optionalStateOutputVariable(tf, keys[TerraformerOutputKeySubnetNodesSelfLink]); err != nil {
+		errs = append(errs, err)
+	}
+	if config.Networks.Internal != nil {
+		if state.SubnetInternalSelfLink, err = optionalStateOutputVariable(tf, keys[TerraformerOutputKeySubnetInternalSelfLink]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if config.Networks.CloudNAT != nil {
+		natIPs, err := optionalStateOutputVariable(tf, keys[TerraformerOutputKeyNATIPs])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		// The IPs of the Cloud NAT are only reserved during apply, hence the list is empty until they are.
+		state.NatIPs = splitOutputList(stringValue(natIPs))
+
+		natGateways, err := optionalStateOutputVariable(tf, keys[TerraformerOutputKeyNATGateways])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if natGateways != nil {
+			state.NATGateways = splitNATGateways(*natGateways)
+		}
+		// The nat_ips output only covers the primary NAT gateway, hence the IPs of all gateways are collected from
+		// the nat_gateways output if it exists.
+		if len(state.NATGateways) > 0 {
+			state.NatIPs = nil
+			for _, gateway := range state.NATGateways {
+				state.NatIPs = append(state.NatIPs, gateway.IPs...)
+			}
+		}
+	}
+	if len(configuredRoutes(&config.Networks)) > 0 {
+		routes, err := optionalStateOutputVariable(tf, keys[TerraformerOutputKeyRoutes])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		state.Routes = splitOutputList(stringValue(routes))
+	}
+	if internalAddressCount(config) > 0 {
+		addresses, err := optionalStateOutputVariable(tf, keys[TerraformerOutputKeyInternalAddresses])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		state.InternalAddresses = splitOutputList(stringValue(addresses))
+	}
+	if flowLogsExportDestination(config) != nil {
+		sink, err := optionalStateOutputVariable(tf, keys[TerraformerOutputKeyFlowLogsSink])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		state.FlowLogsSink = stringValue(sink)
+	}
+	if config.Networks.ControlPlanePeering != nil {
+		peeringRange, err := optionalStateOutputVariable(tf, keys[TerraformerOutputKeyControlPlanePeeringRange])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		state.ControlPlanePeeringRange = stringValue(peeringRange)
+	}
+	return errs
+}
+
+// splitOutputList splits the given comma-separated list of a terraform output variable, omitting empty items.
+func splitOutputList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
-// ExtractTerraformState extracts the TerraformState from the given Terraformer.
-func ExtractTerraformState(tf *terraformer.Terraformer, config *gcpv1alpha1.InfrastructureConfig) (*TerraformState, error) {
+// splitNATGateways splits the given value of the nat_gateways output variable, a comma-separated list of
+// "<name>=<ips>" items whose IPs are separated by semicolons, into the states of the NAT gateways.
+func splitNATGateways(list string) []NATGatewayState {
+	var gateways []NATGatewayState
+	for _, item := range splitOutputList(list) {
+		parts := strings.SplitN(item, "=", 2)
+		gateway := NATGatewayState{Name: parts[0]}
+		if len(parts) == 2 {
+			for _, ip := range strings.Split(parts[1], ";") {
+				if ip = strings.TrimSpace(ip); len(ip) > 0 {
+					gateway.IPs = append(gateway.IPs, ip)
+				}
+			}
+		}
+		gateways = append(gateways, gateway)
+	}
+	return gateways
+}
+
+// stateOutputKeys returns the names of the terraform output variables that the state of the given
+// InfrastructureConfig has to contain, given the effective output keys computed by OutputKeys.
+func stateOutputKeys(config *gcpv1alpha1.InfrastructureConfig, keys map[string]string) []string {
 	outputKeys := []string{
-		TerraformerOutputKeyVPCName,
-		TerraformerOutputKeySubnetNodes,
-		TerraformerOutputKeyServiceAccountEmail,
+		keys[TerraformerOutputKeyVPCName],
+		keys[TerraformerOutputKeySubnetNodes],
+	}
+	if createServiceAccount(config) {
+		outputKeys = append(outputKeys, keys[TerraformerOutputKeyServiceAccountEmail])
 	}
+	if config.Networks.Internal != nil {
+		outputKeys = append(outputKeys, keys[TerraformerOutputKeySubnetInternal])
+	}
+	if config.Networks.ProxyOnly != nil {
+		outputKeys = append(outputKeys, keys[TerraformerOutputKeySubnetProxyOnly], keys[TerraformerOutputKeySubnetProxyOnlyRole])
+	}
+	if config.Networks.PrivateServiceConnect != nil {
+		outputKeys = append(outputKeys, keys[TerraformerOutputKeySubnetPSC])
+	}
+	if config.Networks.DisasterRecovery != nil {
+		outputKeys = append(outputKeys, keys[TerraformerOutputKeySubnetDisasterRecovery], keys[TerraformerOutputKeySubnetDisasterRecoveryRegion])
+	}
+	if config.Networks.EgressAppliance != nil {
+		outputKeys = append(outputKeys, keys[TerraformerOutputKeySubnetEgressAppliance])
+	}
+	return outputKeys
+}
 
-	hasInternal := config.Networks.Internal != nil
-	if hasInternal {
-		outputKeys = append(outputKeys, TerraformerOutputKeySubnetInternal)
+// optionalStateOutputKeys returns the names of the terraform output variables that the state of the given
+// InfrastructureConfig may contain in addition to the stateOutputKeys, given the effective output keys computed
+// by OutputKeys. They are the ones read by readOptionalStateOutputVariables.
+func optionalStateOutputKeys(config *gcpv1alpha1.InfrastructureConfig, keys map[string]string) []string {
+	outputKeys := []string{keys[TerraformerOutputKeySubnetNodesSelfLink]}
+	if config.Networks.Internal != nil {
+		outputKeys = append(outputKeys, keys[TerraformerOutputKeySubnetInternalSelfLink])
+	}
+	if config.Networks.CloudNAT != nil {
+		outputKeys = append(outputKeys, keys[TerraformerOutputKeyNATIPs], keys[TerraformerOutputKeyNATGateways])
+	}
+	if len(configuredRoutes(&config.Networks)) > 0 {
+		outputKeys = append(outputKeys, keys[TerraformerOutputKeyRoutes])
 	}
+	if internalAddressCount(config) > 0 {
+		outputKeys = append(outputKeys, keys[TerraformerOutputKeyInternalAddresses])
+	}
+	if flowLogsExportDestination(config) != nil {
+		outputKeys = append(outputKeys, keys[TerraformerOutputKeyFlowLogsSink])
+	}
+	if config.Networks.ControlPlanePeering != nil {
+		outputKeys = append(outputKeys, keys[TerraformerOutputKeyControlPlanePeeringRange])
+	}
+	return outputKeys
+}
 
-	vars, err := tf.GetStateOutputVariables(outputKeys...)
+// ExpectedOutputs returns the terraform output variables the state of the given InfrastructureConfig is expected
+// to contain, e.g. for driving extraction tests or for checking the outputs of the chart. The result maps the
+// effective name of each output variable to the value of its default output key in the given sample values, or
+// to the default output key itself if there is none.
+func ExpectedOutputs(config *gcpv1alpha1.InfrastructureConfig, sampleValues map[string]string) (map[string]string, error) {
+	keys, err := OutputKeys(config)
 	if err != nil {
 		return nil, err
 	}
 
+	defaultKeys := make(map[string]string, len(TerraformerOutputKeys))
+	for _, key := range TerraformerOutputKeys {
+		defaultKeys[key] = key
+	}
+
+	outputs := make(map[string]string)
+	for _, key := range append(stateOutputKeys(config, defaultKeys), optionalStateOutputKeys(config, defaultKeys)...) {
+		value, ok := sampleValues[key]
+		if !ok {
+			value = key
+		}
+		outputs[keys[key]] = value
+	}
+	return outputs, nil
+}
+
+// terraformStateFromOutputs computes the TerraformState of the given InfrastructureConfig from the given terraform
+// output variables. Optional parts of the state are only set if their output variables are present.
+func terraformStateFromOutputs(config *gcpv1alpha1.InfrastructureConfig, keys, vars map[string]string) *TerraformState {
+	output := func(key string) *string {
+		if value, ok := vars[keys[key]]; ok {
+			return &value
+		}
+		return nil
+	}
+
 	state := &TerraformState{
-		VPCName:             vars[TerraformerOutputKeyVPCName],
-		SubnetNodes:         vars[TerraformerOutputKeySubnetNodes],
-		ServiceAccountEmail: vars[TerraformerOutputKeyServiceAccountEmail],
+		VPCName:     vars[keys[TerraformerOutputKeyVPCName]],
+		SubnetNodes: vars[keys[TerraformerOutputKeySubnetNodes]],
 	}
-	if hasInternal {
-		subnetInternal := vars[TerraformerOutputKeySubnetInternal]
-		state.SubnetInternal = &subnetInternal
+	if createServiceAccount(config) {
+		state.ServiceAccountEmail = vars[keys[TerraformerOutputKeyServiceAccountEmail]]
+	} else if email := serviceAccountEmail(config); email != nil {
+		state.ServiceAccountEmail = *email
 	}
-	return state, nil
+	state.SubnetPurposePrefix = config.Networks.SubnetPurposePrefix
+	if config.Networks.Internal != nil {
+		state.SubnetInternal = output(TerraformerOutputKeySubnetInternal)
+		if purpose := config.Networks.InternalPurpose; purpose != nil {
+			label := string(*purpose)
+			state.SubnetInternalPurpose = &label
+		}
+		if config.Networks.InternalProxyOnly {
+			role := string(gcpv1alpha1.ProxyOnlySubnetRoleActive)
+			state.SubnetInternalRole = &role
+		}
+	}
+	if config.Networks.ProxyOnly != nil {
+		state.SubnetProxyOnly = output(TerraformerOutputKeySubnetProxyOnly)
+		state.SubnetProxyOnlyRole = output(TerraformerOutputKeySubnetProxyOnlyRole)
+	}
+	if config.Networks.PrivateServiceConnect != nil {
+		state.SubnetPSC = output(TerraformerOutputKeySubnetPSC)
+	}
+	if config.Networks.DisasterRecovery != nil {
+		state.SubnetDisasterRecovery = output(TerraformerOutputKeySubnetDisasterRecovery)
+		state.SubnetDisasterRecoveryRegion = output(TerraformerOutputKeySubnetDisasterRecoveryRegion)
+	}
+	if config.Networks.EgressAppliance != nil {
+		state.SubnetEgressAppliance = output(TerraformerOutputKeySubnetEgressAppliance)
+	}
+	return state
+}
+
+// optionalStateOutputVariable reads the given output variable from the given TerraformStateReader. It returns nil
+// if the state does not contain the variable, e.g. because the state was written before the output was introduced.
+func optionalStateOutputVariable(tf TerraformStateReader, key string) (*string, error) {
+	vars, err := tf.GetStateOutputVariables(key)
+	if err != nil {
+		if terraformer.IsVariablesNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	value := vars[key]
+	return &value, nil
+}
+
+// OutputsDiff compares the given old and new terraform output variables and returns the old and the new value of
+// each variable that changed. Variables that are only present in one of them are compared with the empty string.
+func OutputsDiff(old, new map[string]string) map[string][2]string {
+	diff := make(map[string][2]string)
+	for key, oldValue := range old {
+		if newValue := new[key]; newValue != oldValue {
+			diff[key] = [2]string{oldValue, newValue}
+		}
+	}
+	for key, newValue := range new {
+		if _, ok := old[key]; !ok && newValue != "" {
+			diff[key] = [2]string{"", newValue}
+		}
+	}
+	return diff
+}
+
+// AllSubnetPurposes returns all purposes a subnet of an InfrastructureStatus can have, in the order in which
+// the subnets appear in the status.
+func AllSubnetPurposes() []gcpv1alpha1.SubnetPurpose {
+	return []gcpv1alpha1.SubnetPurpose{
+		gcpv1alpha1.PurposeNodes,
+		gcpv1alpha1.PurposeInternal,
+		gcpv1alpha1.PurposeProxyOnly,
+		gcpv1alpha1.PurposePrivateServiceConnect,
+		gcpv1alpha1.PurposeDisasterRecovery,
+		gcpv1alpha1.PurposeEgressAppliance,
+	}
+}
+
+// sortSubnets sorts the given subnets by the order of their purposes in AllSubnetPurposes.
+func sortSubnets(subnets []gcpv1alpha1.Subnet) {
+	ranks := subnetPurposeRanks()
+	sort.SliceStable(subnets, func(i, j int) bool {
+		return ranks[subnets[i].Purpose] < ranks[subnets[j].Purpose]
+	})
+}
+
+// sortSubnetPurposes sorts the given subnet purposes by their order in AllSubnetPurposes, so that values derived
+// from them do not depend on the order they are configured in.
+func sortSubnetPurposes(purposes []gcpv1alpha1.SubnetPurpose) {
+	ranks := subnetPurposeRanks()
+	sort.SliceStable(purposes, func(i, j int) bool {
+		return ranks[purposes[i]] < ranks[purposes[j]]
+	})
+}
+
+// subnetPurposeRanks maps the subnet purposes to their index in AllSubnetPurposes.
+func subnetPurposeRanks() map[gcpv1alpha1.SubnetPurpose]int {
+	ranks := make(map[gcpv1alpha1.SubnetPurpose]int)
+	for rank, purpose := range AllSubnetPurposes() {
+		ranks[purpose] = rank
+	}
+	return ranks
+}
+
+// stringValue returns the value of the given string pointer or the empty string if it is nil.
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
 
 // StatusFromTerraformState computes an InfrastructureStatus from the given
@@ -186,33 +1684,116 @@ func ExtractTerraformState(tf *terraformer.Terraformer, config *gcpv1alpha1.Infr
 func StatusFromTerraformState(state *TerraformState) *gcpv1alpha1.InfrastructureStatus {
 	var (
 		status = &gcpv1alpha1.InfrastructureStatus{
-			TypeMeta: StatusTypeMeta,
+			TypeMeta: StatusTypeMetaForVersion(StatusGroupVersion),
 			Networks: gcpv1alpha1.NetworkStatus{
 				VPC: gcpv1alpha1.VPC{
 					Name: state.VPCName,
 				},
 				Subnets: []gcpv1alpha1.Subnet{
 					{
-						Purpose: gcpv1alpha1.PurposeNodes,
-						Name:    state.SubnetNodes,
+						Purpose:  gcpv1alpha1.PurposeNodes,
+						Name:     state.SubnetNodes,
+						SelfLink: stringValue(state.SubnetNodesSelfLink),
 					},
 				},
 			},
 			ServiceAccountEmail: state.ServiceAccountEmail,
 		}
 	)
+	status.Networks.Routes = state.Routes
+	status.Networks.InternalAddresses = state.InternalAddresses
+	for _, gateway := range state.NATGateways {
+		status.Networks.NATGateways = append(status.Networks.NATGateways, gcpv1alpha1.NATGateway{Name: gateway.Name, IPs: gateway.IPs})
+	}
+	status.Networks.FlowLogsSink = state.FlowLogsSink
+	status.Networks.ControlPlanePeeringRange = state.ControlPlanePeeringRange
 
 	if state.SubnetInternal != nil {
 		status.Networks.Subnets = append(status.Networks.Subnets, gcpv1alpha1.Subnet{
-			Purpose: gcpv1alpha1.PurposeInternal,
-			Name:    *state.SubnetInternal,
+			Purpose:  gcpv1alpha1.PurposeInternal,
+			Name:     *state.SubnetInternal,
+			Role:     gcpv1alpha1.ProxyOnlySubnetRole(stringValue(state.SubnetInternalRole)),
+			SelfLink: stringValue(state.SubnetInternalSelfLink),
+		})
+	}
+	if state.SubnetProxyOnly != nil {
+		subnet := gcpv1alpha1.Subnet{
+			Purpose: gcpv1alpha1.PurposeProxyOnly,
+			Name:    *state.SubnetProxyOnly,
+		}
+		if state.SubnetProxyOnlyRole != nil {
+			subnet.Role = gcpv1alpha1.ProxyOnlySubnetRole(*state.SubnetProxyOnlyRole)
+		}
+		status.Networks.Subnets = append(status.Networks.Subnets, subnet)
+	}
+	if state.SubnetPSC != nil {
+		status.Networks.Subnets = append(status.Networks.Subnets, gcpv1alpha1.Subnet{
+			Purpose: gcpv1alpha1.PurposePrivateServiceConnect,
+			Name:    *state.SubnetPSC,
+		})
+	}
+	if state.SubnetDisasterRecovery != nil {
+		status.Networks.Subnets = append(status.Networks.Subnets, gcpv1alpha1.Subnet{
+			Purpose: gcpv1alpha1.PurposeDisasterRecovery,
+			Name:    *state.SubnetDisasterRecovery,
+			Region:  stringValue(state.SubnetDisasterRecoveryRegion),
+		})
+	}
+	if state.SubnetEgressAppliance != nil {
+		status.Networks.Subnets = append(status.Networks.Subnets, gcpv1alpha1.Subnet{
+			Purpose: gcpv1alpha1.PurposeEgressAppliance,
+			Name:    *state.SubnetEgressAppliance,
 		})
 	}
+	sortSubnets(status.Networks.Subnets)
+
+	// The subnets are sorted by their default purposes, hence the internal subnet is only relabeled afterwards.
+	if state.SubnetInternalPurpose != nil {
+		for i := range status.Networks.Subnets {
+			if status.Networks.Subnets[i].Purpose == gcpv1alpha1.PurposeInternal {
+				status.Networks.Subnets[i].Purpose = gcpv1alpha1.SubnetPurpose(*state.SubnetInternalPurpose)
+			}
+		}
+	}
+	if state.SubnetPurposePrefix != nil {
+		for i := range status.Networks.Subnets {
+			status.Networks.Subnets[i].Purpose = gcpv1alpha1.SubnetPurpose(*state.SubnetPurposePrefix) + status.Networks.Subnets[i].Purpose
+		}
+	}
 	return status
 }
 
-// ComputeStatus computes the status based on the Terraformer and the given InfrastructureConfig.
-func ComputeStatus(tf *terraformer.Terraformer, config *gcpv1alpha1.InfrastructureConfig) (*gcpv1alpha1.InfrastructureStatus, error) {
+// ResourcePurposeMap maps the names of the subnets of the given TerraformState to their purposes, as reported in
+// the status computed by StatusFromTerraformState.
+func ResourcePurposeMap(state *TerraformState) map[string]gcpv1alpha1.SubnetPurpose {
+	subnets := StatusFromTerraformState(state).Networks.Subnets
+
+	purposes := make(map[string]gcpv1alpha1.SubnetPurpose, len(subnets))
+	for _, subnet := range subnets {
+		purposes[subnet.Name] = subnet.Purpose
+	}
+	return purposes
+}
+
+// SubnetsByRegion groups the subnets of the given InfrastructureStatus by their regions, keeping their order.
+// Only subnets in a secondary region carry their region, hence the subnets without one are grouped under the
+// given region of the infrastructure.
+func SubnetsByRegion(status *gcpv1alpha1.InfrastructureStatus, region string) map[string][]gcpv1alpha1.Subnet {
+	subnets := make(map[string][]gcpv1alpha1.Subnet)
+	for _, subnet := range status.Networks.Subnets {
+		subnetRegion := subnet.Region
+		if len(subnetRegion) == 0 {
+			subnetRegion = region
+		}
+		subnets[subnetRegion] = append(subnets[subnetRegion], subnet)
+	}
+	return subnets
+}
+
+// ComputeStatus computes the minimal status based on the given TerraformStateReader and InfrastructureConfig, i.e.
+// only the parts reflected in the terraform output variables. Like ExtractTerraformState, it only reads the
+// terraform output variables and can be used without rendering the chart.
+func ComputeStatus(tf TerraformStateReader, config *gcpv1alpha1.InfrastructureConfig) (*gcpv1alpha1.InfrastructureStatus, error) {
 	state, err := ExtractTerraformState(tf, config)
 	if err != nil {
 		return nil, err
@@ -220,3 +1801,187 @@ func ComputeStatus(tf *terraformer.Terraformer, config *gcpv1alpha1.Infrastructu
 
 	return StatusFromTerraformState(state), nil
 }
+
+// ComputeFullStatus computes the status like ComputeStatus, but additionally includes the parts of the given
+// InfrastructureConfig that are not reflected in the terraform outputs, see StatusFromTerraformStateWithConfig.
+func ComputeFullStatus(tf TerraformStateReader, config *gcpv1alpha1.InfrastructureConfig) (*gcpv1alpha1.InfrastructureStatus, error) {
+	state, err := ExtractTerraformState(tf, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return StatusFromTerraformStateWithConfig(state, config), nil
+}
+
+// ComputeStatusPartial computes the status like ComputeFullStatus, but reads each terraform output variable
+// separately. Output variables that cannot be read are left empty in the returned best-effort status, and
+// their errors are returned as an aggregate along with it. Callers can decide whether to treat such a status
+// as degraded.
+func ComputeStatusPartial(tf TerraformStateReader, config *gcpv1alpha1.InfrastructureConfig) (*gcpv1alpha1.InfrastructureStatus, error) {
+	keys, err := OutputKeys(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		errs []error
+		vars = make(map[string]string)
+	)
+	for _, key := range stateOutputKeys(config, keys) {
+		values, err := tf.GetStateOutputVariables(key)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not read terraform output variable %q: %v", key, err))
+			continue
+		}
+		vars[key] = values[key]
+	}
+
+	state := terraformStateFromOutputs(config, keys, vars)
+	errs = append(errs, readOptionalStateOutputVariables(tf, config, keys, state)...)
+
+	return StatusFromTerraformStateWithConfig(state, config), utilerrors.NewAggregate(errs)
+}
+
+// ComputeStatusAfterPartialApply computes the status like ComputeFullStatus, but tolerates terraform output
+// variables missing in the state, e.g. after an apply that failed after creating only some of the resources. The
+// status only reflects the resources whose outputs are present: Subnets without name are omitted, the flow logs
+// export destination is only stated if the sink exists, and the missing output variables are listed in the
+// MissingOutputs of the degraded status. Errors other than missing variables are returned.
+func ComputeStatusAfterPartialApply(tf TerraformStateReader, config *gcpv1alpha1.InfrastructureConfig) (*gcpv1alpha1.InfrastructureStatus, error) {
+	keys, err := OutputKeys(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		missing []string
+		vars    = make(map[string]string)
+	)
+	for _, key := range stateOutputKeys(config, keys) {
+		value, err := optionalStateOutputVariable(tf, key)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			missing = append(missing, key)
+			continue
+		}
+		vars[key] = *value
+	}
+
+	state := terraformStateFromOutputs(config, keys, vars)
+	if errs := readOptionalStateOutputVariables(tf, config, keys, state); len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+
+	status := StatusFromTerraformStateWithConfig(state, config)
+	subnets := status.Networks.Subnets[:0]
+	for _, subnet := range status.Networks.Subnets {
+		if subnet.Name != "" {
+			subnets = append(subnets, subnet)
+		}
+	}
+	status.Networks.Subnets = subnets
+	if state.FlowLogsSink == "" {
+		status.Networks.FlowLogsExportDestination = ""
+	}
+	status.MissingOutputs = missing
+	return status, nil
+}
+
+// StatusFromTerraformStateWithConfig computes the status from the given TerraformState and the parts of the given
+// InfrastructureConfig that are not reflected in the terraform outputs, i.e. whether the VPC is managed, the
+// networking mode, the flow logs export destination, the disk encryption key, the workload identity users and the
+// service perimeter.
+func StatusFromTerraformStateWithConfig(state *TerraformState, config *gcpv1alpha1.InfrastructureConfig) *gcpv1alpha1.InfrastructureStatus {
+	status := StatusFromTerraformState(state)
+	vpcManaged := createVPC(config)
+	status.Networks.VPCManaged = &vpcManaged
+	status.Networks.NetworkingMode = networkingMode(config)
+	status.Networks.FlowLogsExportDestination = stringValue(flowLogsExportDestination(config))
+	status.Networks.ServicePerimeter = stringValue(config.Networks.ServicePerimeter)
+	status.Networks.Worker = config.Networks.Worker
+	status.Networks.Internal = config.Networks.Internal
+	status.Networks.InternalProxyOnly = config.Networks.InternalProxyOnly
+	status.DiskEncryptionKey = config.DiskEncryptionKey
+	status.WorkloadIdentityUsers = workloadIdentityUsers(config)
+	return status
+}
+
+// PreviousNetworkConfig returns the ranges of the networks and whether the internal subnet is proxy-only as
+// recorded in the given previous InfrastructureStatus as NetworkConfig, e.g. for validating an update with
+// ValidateNetworkConfigUpdate. It returns nil if the status does not record the applied ranges.
+func PreviousNetworkConfig(status *gcpv1alpha1.InfrastructureStatus) *gcpv1alpha1.NetworkConfig {
+	if status == nil || status.Networks.Worker == "" {
+		return nil
+	}
+	return &gcpv1alpha1.NetworkConfig{
+		Worker:            status.Networks.Worker,
+		Internal:          status.Networks.Internal,
+		InternalProxyOnly: status.Networks.InternalProxyOnly,
+	}
+}
+
+// IsVPCModeTransition checks whether reconciling the given InfrastructureConfig switches the VPC mode compared to
+// the given previous InfrastructureStatus, i.e. whether a previously created VPC is now referenced or vice versa.
+// Such a transition potentially destroys the previous VPC. If the previous status does not state whether the VPC
+// was managed, no transition is detected.
+func IsVPCModeTransition(config *gcpv1alpha1.InfrastructureConfig, status *gcpv1alpha1.InfrastructureStatus) bool {
+	if status == nil || status.Networks.VPCManaged == nil {
+		return false
+	}
+	return createVPC(config) != *status.Networks.VPCManaged
+}
+
+// TerraformStateGetter reads the raw terraform state of a Terraformer. It is implemented by *terraformer.Terraformer.
+type TerraformStateGetter interface {
+	// GetState returns the terraform state as byte slice.
+	GetState() ([]byte, error)
+}
+
+var _ TerraformStateGetter = &terraformer.Terraformer{}
+
+// ReadStateSerial reads the serial number of the terraform state of the given TerraformStateGetter.
+func ReadStateSerial(tf TerraformStateGetter) (int64, error) {
+	data, err := tf.GetState()
+	if err != nil {
+		return 0, err
+	}
+
+	var state struct {
+		Serial *int64 `json:"serial"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return 0, fmt.Errorf("could not decode the terraform state: %v", err)
+	}
+	if state.Serial == nil {
+		return 0, fmt.Errorf("the terraform state has no serial")
+	}
+	return *state.Serial, nil
+}
+
+// RecordApplyMetrics stamps the duration and the finishing time of the last successful terraform apply
+// into the given InfrastructureStatus.
+func RecordApplyMetrics(status *gcpv1alpha1.InfrastructureStatus, duration time.Duration, applyTime time.Time) {
+	status.LastApplyDuration = &metav1.Duration{Duration: duration}
+	status.LastApplyTime = &metav1.Time{Time: applyTime}
+}
+
+// statusFingerprintLength is the number of hex digits of a status fingerprint.
+const statusFingerprintLength = 16
+
+// StatusFingerprint returns a compact fingerprint of the given InfrastructureStatus that only changes if the
+// infrastructure it describes changes, e.g. for emitting events on actual changes only. The fields describing the
+// last apply rather than the infrastructure, i.e. its duration, time and state serial, are not part of it.
+func StatusFingerprint(status *gcpv1alpha1.InfrastructureStatus) string {
+	meaningful := status.DeepCopy()
+	meaningful.TypeMeta = metav1.TypeMeta{}
+	meaningful.LastApplyDuration = nil
+	meaningful.LastApplyTime = nil
+	meaningful.StateSerial = nil
+
+	// Marshalling the plain status struct cannot fail.
+	data, _ := json.Marshal(meaningful)
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])[:statusFingerprintLength]
+}
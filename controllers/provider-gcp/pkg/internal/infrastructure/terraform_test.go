@@ -15,21 +15,102 @@
 package infrastructure
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
 	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal"
+	mockinfrastructure "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/mock/infrastructure"
 	"github.com/gardener/gardener-extensions/pkg/controller"
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
 	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	"github.com/gardener/gardener/pkg/chartrenderer"
+	"github.com/gardener/gardener/pkg/logger"
+	"github.com/gardener/gardener/pkg/operation/terraformer"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/engine"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+func proxyOnlySubnetRolePtr(role gcpv1alpha1.ProxyOnlySubnetRole) *gcpv1alpha1.ProxyOnlySubnetRole {
+	return &role
+}
+
+// copyChart copies the files of the chart in the given source directory to the given destination directory,
+// renaming them according to the given renames.
+func copyChart(src, dst string, renames map[string]string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if newName, ok := renames[rel]; ok {
+			rel = newName
+		}
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), 0755)
+		}
+
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(filepath.Join(dst, rel), data, 0644)
+	})
+}
+
+// newTerraformerWithOutputs creates a Terraformer whose state contains the given output variables.
+func newTerraformerWithOutputs(ctrl *gomock.Controller, namespace, name string, outputs map[string]string) *terraformer.Terraformer {
+	stateOutputs := make(map[string]map[string]interface{}, len(outputs))
+	for key, value := range outputs {
+		stateOutputs[key] = map[string]interface{}{"value": value}
+	}
+
+	return newTerraformerWithState(ctrl, namespace, name, map[string]interface{}{
+		"modules": []interface{}{
+			map[string]interface{}{"outputs": stateOutputs},
+		},
+	})
+}
+
+func newTerraformerWithState(ctrl *gomock.Controller, namespace, name string, content map[string]interface{}) *terraformer.Terraformer {
+	state, err := json.Marshal(content)
+	Expect(err).NotTo(HaveOccurred())
+
+	c := mockclient.NewMockClient(ctrl)
+	c.EXPECT().Get(gomock.Any(), kutil.Key(namespace, fmt.Sprintf("%s.%s.tf-state", name, TerraformerPurpose)), gomock.AssignableToTypeOf(&corev1.ConfigMap{})).
+		DoAndReturn(func(_ context.Context, _ client.ObjectKey, configMap *corev1.ConfigMap) error {
+			configMap.Data = map[string]string{terraformer.StateKey: string(state)}
+			return nil
+		}).
+		AnyTimes()
+
+	return terraformer.New(logger.NewLogger("info"), c, nil, TerraformerPurpose, namespace, name, "")
+}
+
 var _ = Describe("Terraform", func() {
 	var (
 		infra              *extensionsv1alpha1.Infrastructure
@@ -38,8 +119,23 @@ var _ = Describe("Terraform", func() {
 		projectID          string
 		serviceAccountData []byte
 		serviceAccount     *internal.ServiceAccount
+
+		ctrl               *gomock.Controller
+		renderer           chartrenderer.Interface
+		internalChartsPath string
 	)
 
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		renderer = chartrenderer.New(engine.New(), &chartutil.Capabilities{KubeVersion: &version.Info{}})
+		internalChartsPath = InternalChartsPath
+		InternalChartsPath = filepath.Join("..", "..", "..", "charts", "internal")
+	})
+	AfterEach(func() {
+		ctrl.Finish()
+		InternalChartsPath = internalChartsPath
+	})
+
 	BeforeEach(func() {
 		internalCIDR := gardencorev1alpha1.CIDR("192.168.0.0/16")
 
@@ -97,134 +193,3046 @@ var _ = Describe("Terraform", func() {
 
 	Describe("#ComputeTerraformerChartValues", func() {
 		It("should correctly compute the terraformer chart values", func() {
-			values := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
 
+			Expect(err).NotTo(HaveOccurred())
 			Expect(values).To(Equal(map[string]interface{}{
 				"google": map[string]interface{}{
 					"region":  infra.Spec.Region,
 					"project": projectID,
 				},
 				"create": map[string]interface{}{
-					"vpc": false,
+					"vpc":            false,
+					"subnets":        true,
+					"serviceAccount": true,
+					"cloudRouter":    false,
 				},
 				"vpc": map[string]interface{}{
 					"name": config.Networks.VPC.Name,
 				},
 				"clusterName": infra.Namespace,
+				"resourceNames": map[string]string{
+					"network":                 "foo",
+					"serviceAccount":          "foo",
+					"subnetNodes":             "foo-nodes",
+					"subnetInternal":          "foo-internal",
+					"podsRange":               "foo-pods",
+					"servicesRange":           "foo-services",
+					"subnetProxyOnly":         "foo-proxy-only",
+					"subnetPSC":               "foo-psc",
+					"subnetDisasterRecovery":  "foo-disaster-recovery",
+					"subnetEgressAppliance":   "foo-egress-appliance",
+					"cloudRouter":             "foo-cloud-router",
+					"cloudNAT":                "foo-cloud-nat",
+					"controlPlanePeering":     "foo-control-plane-peering",
+					"flowLogsSink":            "foo-flow-logs-sink",
+					"flowLogsSinkInternal":    "foo-flow-logs-sink-internal",
+					"restrictedAPIsRoute":     "foo-restricted-apis",
+					"restrictedAPIsZone":      "foo-restricted-apis",
+					"allowInternalAccess":     "foo-allow-internal-access",
+					"allowExternalAccess":     "foo-allow-external-access",
+					"allowHealthChecks":       "foo-allow-health-checks",
+					"denyAllEgress":           "foo-deny-all-egress",
+					"allowClusterEgress":      "foo-allow-cluster-egress",
+					"allowMetadataEgress":     "foo-allow-metadata-egress",
+					"allowControlPlaneEgress": "foo-allow-control-plane-egress",
+				},
 				"networks": map[string]interface{}{
 					"pods":     cluster.Shoot.Spec.Cloud.GCP.Networks.Pods,
 					"services": cluster.Shoot.Spec.Cloud.GCP.Networks.Services,
 					"worker":   config.Networks.Worker,
 					"internal": config.Networks.Internal,
 				},
+				"aliasIPs": map[string]interface{}{
+					"enabled": false,
+				},
+				"cilium": map[string]interface{}{
+					"enabled": false,
+				},
+				"flowLogs": map[string]interface{}{
+					"enabled": false,
+				},
+				"cloudNAT": map[string]interface{}{
+					"enabled":    false,
+					"routerName": "",
+				},
+				"cloudRouter": map[string]interface{}{},
+				"firewall": map[string]interface{}{
+					"managed":                 true,
+					"denyAllEgress":           false,
+					"allowInternalAccess":     true,
+					"allowExternalAccess":     true,
+					"allowHealthChecks":       true,
+					"healthCheckSourceRanges": HealthCheckSourceRanges,
+					"priorities": map[string]interface{}{
+						"allow":         DefaultFirewallPriority,
+						"denyAllEgress": DenyAllEgressFirewallPriority,
+					},
+				},
 				"outputKeys": map[string]interface{}{
-					"vpcName":             TerraformerOutputKeyVPCName,
-					"serviceAccountEmail": TerraformerOutputKeyServiceAccountEmail,
-					"subnetNodes":         TerraformerOutputKeySubnetNodes,
-					"subnetInternal":      TerraformerOutputKeySubnetInternal,
+					"vpcName":                      TerraformerOutputKeyVPCName,
+					"serviceAccountEmail":          TerraformerOutputKeyServiceAccountEmail,
+					"subnetNodes":                  TerraformerOutputKeySubnetNodes,
+					"subnetInternal":               TerraformerOutputKeySubnetInternal,
+					"subnetProxyOnly":              TerraformerOutputKeySubnetProxyOnly,
+					"subnetProxyOnlyRole":          TerraformerOutputKeySubnetProxyOnlyRole,
+					"subnetPSC":                    TerraformerOutputKeySubnetPSC,
+					"subnetDisasterRecovery":       TerraformerOutputKeySubnetDisasterRecovery,
+					"subnetDisasterRecoveryRegion": TerraformerOutputKeySubnetDisasterRecoveryRegion,
+					"subnetEgressAppliance":        TerraformerOutputKeySubnetEgressAppliance,
+					"subnetNodesSelfLink":          TerraformerOutputKeySubnetNodesSelfLink,
+					"subnetInternalSelfLink":       TerraformerOutputKeySubnetInternalSelfLink,
+					"natIPs":                       TerraformerOutputKeyNATIPs,
+					"natGateways":                  TerraformerOutputKeyNATGateways,
+					"routes":                       TerraformerOutputKeyRoutes,
+					"flowLogsSink":                 TerraformerOutputKeyFlowLogsSink,
+					"controlPlanePeeringRange":     TerraformerOutputKeyControlPlanePeeringRange,
+					"internalAddresses":            TerraformerOutputKeyInternalAddresses,
 				},
 			}))
 		})
 
 		It("should correctly compute the terraformer chart values with vpc creation", func() {
 			config.Networks.VPC = nil
-			values := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
 
+			Expect(err).NotTo(HaveOccurred())
 			Expect(values).To(Equal(map[string]interface{}{
 				"google": map[string]interface{}{
 					"region":  infra.Spec.Region,
 					"project": projectID,
 				},
 				"create": map[string]interface{}{
-					"vpc": true,
+					"vpc":            true,
+					"subnets":        true,
+					"serviceAccount": true,
+					"cloudRouter":    false,
 				},
 				"vpc": map[string]interface{}{
 					"name": DefaultVPCName,
 				},
 				"clusterName": infra.Namespace,
+				"resourceNames": map[string]string{
+					"network":                 "foo",
+					"serviceAccount":          "foo",
+					"subnetNodes":             "foo-nodes",
+					"subnetInternal":          "foo-internal",
+					"podsRange":               "foo-pods",
+					"servicesRange":           "foo-services",
+					"subnetProxyOnly":         "foo-proxy-only",
+					"subnetPSC":               "foo-psc",
+					"subnetDisasterRecovery":  "foo-disaster-recovery",
+					"subnetEgressAppliance":   "foo-egress-appliance",
+					"cloudRouter":             "foo-cloud-router",
+					"cloudNAT":                "foo-cloud-nat",
+					"controlPlanePeering":     "foo-control-plane-peering",
+					"flowLogsSink":            "foo-flow-logs-sink",
+					"flowLogsSinkInternal":    "foo-flow-logs-sink-internal",
+					"restrictedAPIsRoute":     "foo-restricted-apis",
+					"restrictedAPIsZone":      "foo-restricted-apis",
+					"allowInternalAccess":     "foo-allow-internal-access",
+					"allowExternalAccess":     "foo-allow-external-access",
+					"allowHealthChecks":       "foo-allow-health-checks",
+					"denyAllEgress":           "foo-deny-all-egress",
+					"allowClusterEgress":      "foo-allow-cluster-egress",
+					"allowMetadataEgress":     "foo-allow-metadata-egress",
+					"allowControlPlaneEgress": "foo-allow-control-plane-egress",
+				},
 				"networks": map[string]interface{}{
 					"pods":     cluster.Shoot.Spec.Cloud.GCP.Networks.Pods,
 					"services": cluster.Shoot.Spec.Cloud.GCP.Networks.Services,
 					"worker":   config.Networks.Worker,
 					"internal": config.Networks.Internal,
 				},
+				"aliasIPs": map[string]interface{}{
+					"enabled": false,
+				},
+				"cilium": map[string]interface{}{
+					"enabled": false,
+				},
+				"flowLogs": map[string]interface{}{
+					"enabled": false,
+				},
+				"cloudNAT": map[string]interface{}{
+					"enabled":    false,
+					"routerName": "",
+				},
+				"cloudRouter": map[string]interface{}{},
+				"firewall": map[string]interface{}{
+					"managed":                 true,
+					"denyAllEgress":           false,
+					"allowInternalAccess":     true,
+					"allowExternalAccess":     true,
+					"allowHealthChecks":       true,
+					"healthCheckSourceRanges": HealthCheckSourceRanges,
+					"priorities": map[string]interface{}{
+						"allow":         DefaultFirewallPriority,
+						"denyAllEgress": DenyAllEgressFirewallPriority,
+					},
+				},
 				"outputKeys": map[string]interface{}{
-					"vpcName":             TerraformerOutputKeyVPCName,
-					"serviceAccountEmail": TerraformerOutputKeyServiceAccountEmail,
-					"subnetNodes":         TerraformerOutputKeySubnetNodes,
-					"subnetInternal":      TerraformerOutputKeySubnetInternal,
+					"vpcName":                      TerraformerOutputKeyVPCName,
+					"serviceAccountEmail":          TerraformerOutputKeyServiceAccountEmail,
+					"subnetNodes":                  TerraformerOutputKeySubnetNodes,
+					"subnetInternal":               TerraformerOutputKeySubnetInternal,
+					"subnetProxyOnly":              TerraformerOutputKeySubnetProxyOnly,
+					"subnetProxyOnlyRole":          TerraformerOutputKeySubnetProxyOnlyRole,
+					"subnetPSC":                    TerraformerOutputKeySubnetPSC,
+					"subnetDisasterRecovery":       TerraformerOutputKeySubnetDisasterRecovery,
+					"subnetDisasterRecoveryRegion": TerraformerOutputKeySubnetDisasterRecoveryRegion,
+					"subnetEgressAppliance":        TerraformerOutputKeySubnetEgressAppliance,
+					"subnetNodesSelfLink":          TerraformerOutputKeySubnetNodesSelfLink,
+					"subnetInternalSelfLink":       TerraformerOutputKeySubnetInternalSelfLink,
+					"natIPs":                       TerraformerOutputKeyNATIPs,
+					"natGateways":                  TerraformerOutputKeyNATGateways,
+					"routes":                       TerraformerOutputKeyRoutes,
+					"flowLogsSink":                 TerraformerOutputKeyFlowLogsSink,
+					"controlPlanePeeringRange":     TerraformerOutputKeyControlPlanePeeringRange,
+					"internalAddresses":            TerraformerOutputKeyInternalAddresses,
 				},
 			}))
 		})
+
+		It("should normalize the casing of the region", func() {
+			infra.Spec.Region = "EU-West-1"
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["google"]).To(HaveKeyWithValue("region", "eu-west-1"))
+		})
+
+		It("should fail for an invalid region", func() {
+			infra.Spec.Region = "eu west 1"
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("region")))
+		})
+
+		It("should accept an existing internal subnet smaller than the minimum of created ones", func() {
+			internal := gardencorev1alpha1.CIDR("192.168.0.0/29")
+			config.Networks.Internal = &internal
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should fail if the service account ID derived from a long namespace overflows", func() {
+			infra.Namespace = "shoot--" + strings.Repeat("a", 30)
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("resource serviceAccount")))
+		})
+
+		It("should fail if the pod and service CIDRs of the cluster overlap", func() {
+			services := gardencorev1alpha1.CIDR("11.0.128.0/17")
+			cluster.Shoot.Spec.Cloud.GCP.Networks.Services = &services
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring(`networks.services: Invalid value: "11.0.128.0/17": must not overlap with the pods CIDR 11.0.0.0/16`)))
+		})
 	})
 
-	Describe("#StatusFromTerraformState", func() {
-		var (
-			serviceAccountEmail string
-			vpcName             string
-			subnetNodes         string
-			subnetInternal      string
+	Context("with timeouts", func() {
+		It("should add the set timeouts to the values", func() {
+			config.Timeouts = &gcpv1alpha1.Timeouts{
+				Request: &metav1.Duration{Duration: 30 * time.Second},
+				Create:  &metav1.Duration{Duration: 10 * time.Minute},
+			}
 
-			state *TerraformState
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("google", map[string]interface{}{
+				"region":         infra.Spec.Region,
+				"project":        projectID,
+				"requestTimeout": "30s",
+			}))
+			Expect(values).To(HaveKeyWithValue("timeouts", map[string]interface{}{
+				"create": "10m0s",
+			}))
+		})
+
+		It("should omit unset timeouts from the values", func() {
+			config.Timeouts = &gcpv1alpha1.Timeouts{}
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["google"]).NotTo(HaveKey("requestTimeout"))
+			Expect(values).NotTo(HaveKey("timeouts"))
+		})
+
+		It("should fail for a non-positive timeout", func() {
+			config.Timeouts = &gcpv1alpha1.Timeouts{Delete: &metav1.Duration{}}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("with disk encryption key", func() {
+		const diskEncryptionKey = "projects/project/locations/europe-west1/keyRings/ring/cryptoKeys/key"
+
+		BeforeEach(func() {
+			config.DiskEncryptionKey = diskEncryptionKey
+		})
+
+		It("should add the disk encryption key to the values", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("diskEncryptionKey", diskEncryptionKey))
+		})
+
+		It("should add the disk encryption key to the status", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+			})
+
+			status, err := ComputeFullStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.DiskEncryptionKey).To(Equal(diskEncryptionKey))
+		})
+
+		It("should fail for a malformed disk encryption key", func() {
+			config.DiskEncryptionKey = "projects/project/keyRings/ring/cryptoKeys/key"
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("with proxy-only subnet", func() {
+		BeforeEach(func() {
+			config.Networks.ProxyOnly = &gcpv1alpha1.ProxyOnlySubnet{CIDR: gardencorev1alpha1.CIDR("10.2.0.0/23")}
+		})
+
+		DescribeTable("should pass the role through the values and the status",
+			func(role *gcpv1alpha1.ProxyOnlySubnetRole, expected gcpv1alpha1.ProxyOnlySubnetRole) {
+				config.Networks.ProxyOnly.Role = role
+
+				values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(values["networks"]).To(HaveKeyWithValue("proxyOnly", map[string]interface{}{
+					"cidr": config.Networks.ProxyOnly.CIDR,
+					"role": expected,
+				}))
+
+				tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+					TerraformerOutputKeyVPCName:             "vpc",
+					TerraformerOutputKeyServiceAccountEmail: "email",
+					TerraformerOutputKeySubnetNodes:         "nodes",
+					TerraformerOutputKeySubnetInternal:      "internal",
+					TerraformerOutputKeySubnetProxyOnly:     "proxy-only",
+					TerraformerOutputKeySubnetProxyOnlyRole: string(expected),
+				})
+
+				status, err := ComputeStatus(tf, config)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(status.Networks.Subnets).To(ContainElement(gcpv1alpha1.Subnet{
+					Purpose: gcpv1alpha1.PurposeProxyOnly,
+					Name:    "proxy-only",
+					Role:    expected,
+				}))
+			},
+			Entry("default", nil, gcpv1alpha1.ProxyOnlySubnetRoleActive),
+			Entry("ACTIVE", proxyOnlySubnetRolePtr(gcpv1alpha1.ProxyOnlySubnetRoleActive), gcpv1alpha1.ProxyOnlySubnetRoleActive),
+			Entry("BACKUP", proxyOnlySubnetRolePtr(gcpv1alpha1.ProxyOnlySubnetRoleBackup), gcpv1alpha1.ProxyOnlySubnetRoleBackup),
 		)
 
+		It("should fail for an unknown role", func() {
+			config.Networks.ProxyOnly.Role = proxyOnlySubnetRolePtr("PASSIVE")
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("with proxy-only internal subnet", func() {
 		BeforeEach(func() {
-			serviceAccountEmail = "gardener@cloud"
-			vpcName = "vpc-name"
-			subnetNodes = "nodes-subnet"
-			subnetInternal = "internal"
+			config.Networks.InternalProxyOnly = true
+		})
 
-			state = &TerraformState{
-				VPCName:             vpcName,
-				ServiceAccountEmail: serviceAccountEmail,
-				SubnetNodes:         subnetNodes,
-				SubnetInternal:      &subnetInternal,
+		It("should render the internal subnet as active proxy-only subnet and report its role", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["networks"]).To(HaveKeyWithValue("internalProxyOnly", true))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`region        = "eu-west-1"
+  purpose       = "INTERNAL_HTTPS_LOAD_BALANCER"
+  role          = "ACTIVE"`))
+
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+			})
+
+			status, err := ComputeStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.Subnets).To(ContainElement(gcpv1alpha1.Subnet{
+				Purpose: gcpv1alpha1.PurposeInternal,
+				Name:    "internal",
+				Role:    gcpv1alpha1.ProxyOnlySubnetRoleActive,
+			}))
+		})
+
+		It("should not translate the internal subnet via Cloud NAT by default", func() {
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["cloudNAT"]).To(HaveKeyWithValue("subnets", []string{string(gcpv1alpha1.PurposeNodes)}))
+		})
+
+		It("should fail if the internal subnet is translated via Cloud NAT", func() {
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{
+				Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeNodes, gcpv1alpha1.PurposeInternal},
 			}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.cloudNAT.subnets[1]")))
 		})
 
-		It("should correctly compute the status", func() {
-			status := StatusFromTerraformState(state)
+		It("should fail if the state refresh is disabled for a Cloud NAT", func() {
+			stateRefresh := false
+			config.StateRefresh = &stateRefresh
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
 
-			Expect(status).To(Equal(&gcpv1alpha1.InfrastructureStatus{
-				TypeMeta: StatusTypeMeta,
-				Networks: gcpv1alpha1.NetworkStatus{
-					VPC: gcpv1alpha1.VPC{
-						Name: vpcName,
-					},
-					Subnets: []gcpv1alpha1.Subnet{
-						{
-							Purpose: gcpv1alpha1.PurposeNodes,
-							Name:    subnetNodes,
-						},
-						{
-							Purpose: gcpv1alpha1.PurposeInternal,
-							Name:    subnetInternal,
-						},
-					},
-				},
-				ServiceAccountEmail: serviceAccountEmail,
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("stateRefresh")))
+		})
+
+		It("should fail if the VPC is named like the default network", func() {
+			config.Networks.VPC = &gcpv1alpha1.VPC{Name: "default"}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.vpc.name")))
+		})
+
+		It("should fail if there is another active proxy-only subnet", func() {
+			config.Networks.ProxyOnly = &gcpv1alpha1.ProxyOnlySubnet{CIDR: gardencorev1alpha1.CIDR("10.2.0.0/23")}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.proxyOnly.role")))
+		})
+
+		It("should fail without internal subnet", func() {
+			config.Networks.Internal = nil
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.internalProxyOnly")))
+		})
+	})
+
+	Context("with air-gapped network", func() {
+		BeforeEach(func() {
+			config.Networks.AirGapped = true
+		})
+
+		It("should enable Private Google Access and not allow external access", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["networks"]).To(HaveKeyWithValue("privateGoogleAccess", true))
+			Expect(values["firewall"]).To(HaveKeyWithValue("allowExternalAccess", false))
+			Expect(values["cloudNAT"]).To(HaveKeyWithValue("enabled", false))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring("private_ip_google_access = true"))
+			Expect(files.Main).NotTo(ContainSubstring(`"rule-allow-external-access"`))
+			Expect(files.Main).NotTo(ContainSubstring(`source_ranges = ["0.0.0.0/0"]`))
+		})
+
+		It("should not enable Private Google Access for a proxy-only internal subnet", func() {
+			config.Networks.InternalProxyOnly = true
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.Count(files.Main, "private_ip_google_access = true")).To(Equal(1))
+		})
+
+		It("should fail with Cloud NAT", func() {
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.cloudNAT")))
+		})
+
+		It("should fail with a custom default route", func() {
+			nextHopIP := "10.250.0.10"
+			config.Networks.CustomRoutes = []gcpv1alpha1.Route{
+				{Name: "on-prem", Destination: gardencorev1alpha1.CIDR("192.168.0.0/16"), NextHopIP: &nextHopIP},
+				{Name: "default", Destination: gardencorev1alpha1.CIDR("0.0.0.0/0"), NextHopIP: &nextHopIP},
+			}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.customRoutes[1].destination")))
+		})
+	})
+
+	Context("with internal addresses", func() {
+		BeforeEach(func() {
+			var count int32 = 2
+			config.Networks.InternalAddresses = &count
+		})
+
+		It("should reserve the internal addresses and surface them in the status", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("internalAddresses", []interface{}{
+				map[string]interface{}{"resourceName": "foo-internal-address-0"},
+				map[string]interface{}{"resourceName": "foo-internal-address-1"},
 			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_address" "internal-address-1" {
+  name         = "foo-internal-address-1"
+  region       = "eu-west-1"
+  subnetwork   = "${google_compute_subnetwork.subnetwork-internal.self_link}"
+  address_type = "INTERNAL"
+}`))
+			Expect(files.Main).To(ContainSubstring(`output "internal_addresses"`))
+
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+				TerraformerOutputKeyInternalAddresses:   "192.168.0.2,192.168.0.3",
+			})
+
+			status, err := ComputeStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.InternalAddresses).To(Equal([]string{"192.168.0.2", "192.168.0.3"}))
 		})
 
-		It("should correctly compute the status without internal subnet", func() {
-			state.SubnetInternal = nil
-			status := StatusFromTerraformState(state)
+		It("should fail if the internal subnet has no room for the addresses", func() {
+			internal := gardencorev1alpha1.CIDR("192.168.0.0/28")
+			config.Networks.Internal = &internal
+			var count int32 = 13
+			config.Networks.InternalAddresses = &count
 
-			Expect(status).To(Equal(&gcpv1alpha1.InfrastructureStatus{
-				TypeMeta: StatusTypeMeta,
-				Networks: gcpv1alpha1.NetworkStatus{
-					VPC: gcpv1alpha1.VPC{
-						Name: vpcName,
-					},
-					Subnets: []gcpv1alpha1.Subnet{
-						{
-							Purpose: gcpv1alpha1.PurposeNodes,
-							Name:    subnetNodes,
-						},
-					},
-				},
-				ServiceAccountEmail: serviceAccountEmail,
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.internalAddresses")))
+		})
+
+		It("should fail without internal subnet", func() {
+			config.Networks.Internal = nil
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.internalAddresses")))
+		})
+	})
+
+	Context("with Private Service Connect subnet", func() {
+		BeforeEach(func() {
+			pscCIDR := gardencorev1alpha1.CIDR("10.3.0.0/24")
+			config.Networks.PrivateServiceConnect = &pscCIDR
+		})
+
+		It("should pass the subnet through the values and the status", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["networks"]).To(HaveKeyWithValue("privateServiceConnect", gardencorev1alpha1.CIDR("10.3.0.0/24")))
+
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+				TerraformerOutputKeySubnetPSC:           "psc",
+			})
+
+			status, err := ComputeStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.Subnets).To(Equal([]gcpv1alpha1.Subnet{
+				{Purpose: gcpv1alpha1.PurposeNodes, Name: "nodes"},
+				{Purpose: gcpv1alpha1.PurposeInternal, Name: "internal"},
+				{Purpose: gcpv1alpha1.PurposePrivateServiceConnect, Name: "psc"},
+			}))
+		})
+
+		It("should fail for a subnet overlapping the worker range", func() {
+			pscCIDR := config.Networks.Worker
+			config.Networks.PrivateServiceConnect = &pscCIDR
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("with disaster recovery subnet", func() {
+		BeforeEach(func() {
+			config.Networks.DisasterRecovery = &gcpv1alpha1.DisasterRecoverySubnet{
+				Region: "eu-west-2",
+				CIDR:   gardencorev1alpha1.CIDR("10.4.0.0/16"),
+			}
+		})
+
+		It("should render the subnet in the secondary region and round-trip it through the status", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["networks"]).To(HaveKeyWithValue("disasterRecovery", map[string]interface{}{
+				"region": "eu-west-2",
+				"cidr":   gardencorev1alpha1.CIDR("10.4.0.0/16"),
 			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_subnetwork" "subnetwork-disaster-recovery"`))
+			Expect(files.Main).To(ContainSubstring(`region        = "eu-west-2"`))
+
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:                      "vpc",
+				TerraformerOutputKeyServiceAccountEmail:          "email",
+				TerraformerOutputKeySubnetNodes:                  "nodes",
+				TerraformerOutputKeySubnetInternal:               "internal",
+				TerraformerOutputKeySubnetDisasterRecovery:       "foo-disaster-recovery",
+				TerraformerOutputKeySubnetDisasterRecoveryRegion: "eu-west-2",
+			})
+
+			status, err := ComputeStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.Subnets).To(Equal([]gcpv1alpha1.Subnet{
+				{Purpose: gcpv1alpha1.PurposeNodes, Name: "nodes"},
+				{Purpose: gcpv1alpha1.PurposeInternal, Name: "internal"},
+				{Purpose: gcpv1alpha1.PurposeDisasterRecovery, Name: "foo-disaster-recovery", Region: "eu-west-2"},
+			}))
+		})
+
+		It("should fail if the secondary region equals the primary one", func() {
+			config.Networks.DisasterRecovery.Region = infra.Spec.Region
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.disasterRecovery.region")))
+		})
+	})
+
+	Context("with egress appliance subnet", func() {
+		BeforeEach(func() {
+			priority := int32(900)
+			config.Networks.EgressAppliance = &gcpv1alpha1.EgressApplianceSubnet{
+				CIDR:         gardencorev1alpha1.CIDR("10.5.0.0/24"),
+				NextHopIP:    "10.5.0.10",
+				Destinations: []gardencorev1alpha1.CIDR{"0.0.0.0/1", "128.0.0.0/1"},
+				Priority:     &priority,
+			}
+		})
+
+		It("should render the subnet and the routes steering the egress traffic through it", func() {
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_subnetwork" "subnetwork-egress-appliance" {
+  name          = "foo-egress-appliance"
+  ip_cidr_range = "10.5.0.0/24"`))
+			for i, destination := range []string{"0.0.0.0/1", "128.0.0.0/1"} {
+				Expect(files.Main).To(ContainSubstring(fmt.Sprintf(`resource "google_compute_route" "route-egress-appliance-%d" {
+  name                = "foo-route-egress-appliance-%d"
+  network             = "vpc"
+  dest_range          = "%s"
+  next_hop_ip         = "10.5.0.10"
+  priority            = 900
+  tags                = ["foo"]
+
+  depends_on = ["google_compute_subnetwork.subnetwork-egress-appliance"]
+}`, i, i, destination)))
+			}
+			Expect(files.Main).To(ContainSubstring(`value = "${google_compute_subnetwork.subnetwork-egress-appliance.name}"`))
+			Expect(files.Main).To(ContainSubstring(`value = "${google_compute_route.route-egress-appliance-0.name},${google_compute_route.route-egress-appliance-1.name}"`))
+		})
+
+		It("should steer the default destination through the appliances if no destinations are configured", func() {
+			config.Networks.EgressAppliance.Destinations = nil
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`dest_range          = "0.0.0.0/0"`))
+			Expect(files.Main).NotTo(ContainSubstring("route-egress-appliance-1"))
+		})
+
+		It("should surface the subnet and the routes in the status", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:               "vpc",
+				TerraformerOutputKeyServiceAccountEmail:   "email",
+				TerraformerOutputKeySubnetNodes:           "nodes",
+				TerraformerOutputKeySubnetInternal:        "internal",
+				TerraformerOutputKeySubnetEgressAppliance: "foo-egress-appliance",
+				TerraformerOutputKeyRoutes:                "foo-route-egress-appliance-0,foo-route-egress-appliance-1",
+			})
+
+			status, err := ComputeStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.Subnets).To(Equal([]gcpv1alpha1.Subnet{
+				{Purpose: gcpv1alpha1.PurposeNodes, Name: "nodes"},
+				{Purpose: gcpv1alpha1.PurposeInternal, Name: "internal"},
+				{Purpose: gcpv1alpha1.PurposeEgressAppliance, Name: "foo-egress-appliance"},
+			}))
+			Expect(status.Networks.Routes).To(Equal([]string{"foo-route-egress-appliance-0", "foo-route-egress-appliance-1"}))
+		})
+
+		It("should fail if the next hop is not within the subnet", func() {
+			config.Networks.EgressAppliance.NextHopIP = "10.6.0.10"
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.egressAppliance.nextHopIP")))
+		})
+	})
+
+	Context("with service perimeter", func() {
+		var servicePerimeter string
+
+		BeforeEach(func() {
+			servicePerimeter = "accessPolicies/123456/servicePerimeters/shoots"
+			config.Networks.ServicePerimeter = &servicePerimeter
+		})
+
+		It("should enable Private Google Access and route and resolve the restricted Google APIs", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["networks"]).To(HaveKeyWithValue("privateGoogleAccess", true))
+			Expect(values["networks"]).To(HaveKeyWithValue("restrictedAPIs", RestrictedGoogleAPIsRange))
+			Expect(values["networks"]).To(HaveKeyWithValue("restrictedAPIsAddresses", RestrictedGoogleAPIsAddresses))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring("private_ip_google_access = true"))
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_route" "restricted-apis" {
+  name             = "foo-restricted-apis"
+  network          = "vpc"
+  dest_range       = "199.36.153.4/30"
+  next_hop_gateway = "default-internet-gateway"
+}`))
+			Expect(files.Main).To(ContainSubstring(`resource "google_dns_managed_zone" "restricted-apis" {
+  name       = "foo-restricted-apis"
+  dns_name   = "googleapis.com."
+  visibility = "private"
+
+  private_visibility_config {
+    networks {
+      network_url = "https://www.googleapis.com/compute/v1/projects/project/global/networks/vpc"
+    }
+  }
+}`))
+			Expect(files.Main).To(ContainSubstring(`resource "google_dns_record_set" "restricted-apis" {
+  managed_zone = "${google_dns_managed_zone.restricted-apis.name}"
+  name         = "restricted.googleapis.com."
+  type         = "A"
+  ttl          = 300
+  rrdatas      = ["199.36.153.4", "199.36.153.5", "199.36.153.6", "199.36.153.7"]
+}`))
+			Expect(files.Main).To(ContainSubstring(`resource "google_dns_record_set" "restricted-apis-wildcard" {
+  managed_zone = "${google_dns_managed_zone.restricted-apis.name}"
+  name         = "*.googleapis.com."
+  type         = "CNAME"
+  ttl          = 300
+  rrdatas      = ["restricted.googleapis.com."]
+}`))
+		})
+
+		It("should bind the private DNS zone to a created VPC", func() {
+			config.Networks.VPC = nil
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`network_url = "${google_compute_network.network.self_link}"`))
+		})
+
+		It("should not route the restricted Google APIs without a service perimeter", func() {
+			config.Networks.ServicePerimeter = nil
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).NotTo(ContainSubstring("restricted-apis"))
+		})
+
+		It("should surface the service perimeter in the status", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+			})
+
+			status, err := ComputeFullStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.ServicePerimeter).To(Equal(servicePerimeter))
+		})
+
+		It("should fail for a malformed service perimeter", func() {
+			servicePerimeter = "servicePerimeters/shoots"
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.servicePerimeter")))
+		})
+	})
+
+	Context("with Cloud Router BGP", func() {
+		BeforeEach(func() {
+			custom := gcpv1alpha1.BGPAdvertiseModeCustom
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+			config.Networks.CloudRouterBGP = &gcpv1alpha1.CloudRouterBGP{
+				ASN:                 64514,
+				AdvertiseMode:       &custom,
+				AdvertiseAllSubnets: true,
+				AdvertisedIPRanges:  []gardencorev1alpha1.CIDR{"10.252.0.0/20", "192.168.0.0/16"},
+			}
+		})
+
+		It("should render the advertise mode and the advertised ranges", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["cloudRouter"]).To(Equal(map[string]interface{}{
+				"bgp": map[string]interface{}{
+					"asn":                 int64(64514),
+					"advertiseMode":       "CUSTOM",
+					"advertiseAllSubnets": true,
+					"advertisedIPRanges":  []gardencorev1alpha1.CIDR{"10.252.0.0/20", "192.168.0.0/16"},
+				},
+			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`  bgp {
+    asn               = 64514
+    advertise_mode    = "CUSTOM"
+    advertised_groups = ["ALL_SUBNETS"]
+
+    advertised_ip_ranges {
+      range = "10.252.0.0/20"
+    }
+
+    advertised_ip_ranges {
+      range = "192.168.0.0/16"
+    }
+  }`))
+		})
+
+		It("should default the advertise mode", func() {
+			config.Networks.CloudRouterBGP = &gcpv1alpha1.CloudRouterBGP{ASN: 64514}
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`advertise_mode    = "DEFAULT"`))
+			Expect(files.Main).NotTo(ContainSubstring("advertised_groups"))
+			Expect(files.Main).NotTo(ContainSubstring("advertised_ip_ranges"))
+		})
+
+		It("should not render BGP if it is not configured", func() {
+			config.Networks.CloudRouterBGP = nil
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_router" "router"`))
+			Expect(files.Main).NotTo(ContainSubstring("bgp {"))
+		})
+
+		It("should fail if the Cloud Router is not created", func() {
+			config.Networks.VPC = &gcpv1alpha1.VPC{Name: "vpc", CloudRouter: &gcpv1alpha1.CloudRouter{Name: "router"}}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.cloudRouterBGP")))
+		})
+	})
+
+	Context("with control plane peering", func() {
+		BeforeEach(func() {
+			peering := gardencorev1alpha1.CIDR("10.5.0.0/20")
+			config.Networks.VPC = nil
+			config.Networks.ControlPlanePeering = &peering
+		})
+
+		It("should compute the peering range values and surface the range in the status", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["networks"]).To(HaveKeyWithValue("controlPlanePeering", map[string]interface{}{
+				"address":      "10.5.0.0",
+				"prefixLength": 20,
+			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_global_address" "control-plane-peering"`))
+			Expect(files.Main).To(ContainSubstring(`prefix_length = 20`))
+			Expect(files.Main).To(ContainSubstring(`resource "google_service_networking_connection" "control-plane-peering"`))
+
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:                  "vpc",
+				TerraformerOutputKeyServiceAccountEmail:      "email",
+				TerraformerOutputKeySubnetNodes:              "nodes",
+				TerraformerOutputKeySubnetInternal:           "internal",
+				TerraformerOutputKeyControlPlanePeeringRange: "10.5.0.0/20",
+			})
+
+			status, err := ComputeStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.ControlPlanePeeringRange).To(Equal("10.5.0.0/20"))
+		})
+
+		It("should render the route import and export of the peering", func() {
+			config.Networks.ControlPlanePeeringRoutes = &gcpv1alpha1.PeeringRoutes{ImportCustomRoutes: true}
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["networks"]).To(HaveKeyWithValue("controlPlanePeering", map[string]interface{}{
+				"address":      "10.5.0.0",
+				"prefixLength": 20,
+				"routes": map[string]interface{}{
+					"importCustomRoutes": true,
+					"exportCustomRoutes": false,
+				},
+			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_network_peering_routes_config" "control-plane-peering"`))
+			Expect(files.Main).To(ContainSubstring(`import_custom_routes = true`))
+			Expect(files.Main).To(ContainSubstring(`export_custom_routes = false`))
+		})
+
+		It("should fail for peering routes without a peering range", func() {
+			config.Networks.ControlPlanePeering = nil
+			config.Networks.ControlPlanePeeringRoutes = &gcpv1alpha1.PeeringRoutes{ExportCustomRoutes: true}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.controlPlanePeeringRoutes")))
+		})
+
+		It("should fail for a range overlapping the worker subnet", func() {
+			peering := config.Networks.Worker
+			config.Networks.ControlPlanePeering = &peering
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.controlPlanePeering")))
+		})
+	})
+
+	Context("with VPC description", func() {
+		BeforeEach(func() {
+			config.Networks.VPC = nil
+		})
+
+		It("should not render a description unless it is configured", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["vpc"]).NotTo(HaveKey("description"))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).NotTo(ContainSubstring("description             ="))
+		})
+
+		It("should use the configured description and render it", func() {
+			description := `VPC of "foo"`
+			config.Networks.VPCDescription = &description
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["vpc"]).To(HaveKeyWithValue("description", description))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`description             = "VPC of \"foo\""`))
+		})
+
+		It("should fail for a description of an existing VPC", func() {
+			description := "foo"
+			config.Networks.VPC = &gcpv1alpha1.VPC{Name: "vpc"}
+			config.Networks.VPCDescription = &description
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.vpcDescription")))
+		})
+	})
+
+	Context("with subnet self-links", func() {
+		var outputs map[string]string
+
+		BeforeEach(func() {
+			outputs = map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+			}
+		})
+
+		It("should surface the self-links in the status", func() {
+			outputs[TerraformerOutputKeySubnetNodesSelfLink] = "https://www.googleapis.com/compute/v1/projects/foo/regions/bar/subnetworks/nodes"
+			outputs[TerraformerOutputKeySubnetInternalSelfLink] = "https://www.googleapis.com/compute/v1/projects/foo/regions/bar/subnetworks/internal"
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, outputs)
+
+			status, err := ComputeStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.Subnets).To(Equal([]gcpv1alpha1.Subnet{
+				{Purpose: gcpv1alpha1.PurposeNodes, Name: "nodes", SelfLink: outputs[TerraformerOutputKeySubnetNodesSelfLink]},
+				{Purpose: gcpv1alpha1.PurposeInternal, Name: "internal", SelfLink: outputs[TerraformerOutputKeySubnetInternalSelfLink]},
+			}))
+		})
+
+		It("should tolerate states without self-links", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, outputs)
+
+			state, err := ExtractTerraformState(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.SubnetNodesSelfLink).To(BeNil())
+			Expect(state.SubnetInternalSelfLink).To(BeNil())
+		})
+	})
+
+	Context("with auto-allocated NAT IPs", func() {
+		var outputs map[string]string
+
+		BeforeEach(func() {
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+			outputs = map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+			}
+		})
+
+		It("should extract the allocated NAT IPs", func() {
+			outputs[TerraformerOutputKeyNATIPs] = "35.1.2.3,35.4.5.6"
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, outputs)
+
+			state, err := ExtractTerraformState(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.NatIPs).To(Equal([]string{"35.1.2.3", "35.4.5.6"}))
+		})
+
+		It("should tolerate NAT IPs that are not allocated yet", func() {
+			outputs[TerraformerOutputKeyNATIPs] = ""
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, outputs)
+
+			state, err := ExtractTerraformState(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.NatIPs).To(BeEmpty())
+		})
+
+		It("should tolerate states without NAT IPs", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, outputs)
+
+			state, err := ExtractTerraformState(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.NatIPs).To(BeEmpty())
+		})
+	})
+
+	Context("with additional NAT gateways", func() {
+		BeforeEach(func() {
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{
+				Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeNodes},
+				Gateways: []gcpv1alpha1.CloudNATGateway{
+					{Name: "internal", Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeInternal}},
+				},
+			}
+		})
+
+		It("should render a NAT gateway with a reserved address per configuration and output their IPs", func() {
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_address" "nat-address-0" {
+  name         = "foo-cloud-nat-address-0"
+  region       = "eu-west-1"
+  network_tier = "PREMIUM"
+}
+
+resource "google_compute_router_nat" "nat" {
+  name                               = "foo-cloud-nat"
+  router                             = "${google_compute_router.router.name}"
+  region                             = "eu-west-1"
+  nat_ip_allocate_option             = "MANUAL_ONLY"
+  nat_ips                            = ["${google_compute_address.nat-address-0.self_link}"]
+  source_subnetwork_ip_ranges_to_nat = "LIST_OF_SUBNETWORKS"
+
+  subnetwork {
+    name                    = "${google_compute_subnetwork.subnetwork-nodes.self_link}"
+    source_ip_ranges_to_nat = ["ALL_IP_RANGES"]
+  }
+}`))
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_address" "nat-internal-address-0" {
+  name         = "foo-cloud-nat-internal-address-0"
+  region       = "eu-west-1"
+  network_tier = "PREMIUM"
+}
+
+resource "google_compute_router_nat" "nat-internal" {
+  name                               = "foo-cloud-nat-internal"
+  router                             = "${google_compute_router.router.name}"
+  region                             = "eu-west-1"
+  nat_ip_allocate_option             = "MANUAL_ONLY"
+  nat_ips                            = ["${google_compute_address.nat-internal-address-0.self_link}"]
+  source_subnetwork_ip_ranges_to_nat = "LIST_OF_SUBNETWORKS"
+
+  subnetwork {
+    name                    = "${google_compute_subnetwork.subnetwork-internal.self_link}"
+    source_ip_ranges_to_nat = ["ALL_IP_RANGES"]
+  }
+}`))
+			Expect(files.Main).To(ContainSubstring(`value = "${google_compute_address.nat-address-0.address}"`))
+			Expect(files.Main).To(ContainSubstring(`value = "${google_compute_router_nat.nat.name}=${google_compute_address.nat-address-0.address},${google_compute_router_nat.nat-internal.name}=${google_compute_address.nat-internal-address-0.address}"`))
+		})
+
+		It("should surface the IPs of each NAT gateway in the status", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+				TerraformerOutputKeyNATIPs:              "35.1.2.3,35.4.5.6",
+				TerraformerOutputKeyNATGateways:         "foo-cloud-nat=35.1.2.3;35.4.5.6,foo-cloud-nat-internal=35.7.8.9",
+			})
+
+			status, err := ComputeStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.NATGateways).To(Equal([]gcpv1alpha1.NATGateway{
+				{Name: "foo-cloud-nat", IPs: []string{"35.1.2.3", "35.4.5.6"}},
+				{Name: "foo-cloud-nat-internal", IPs: []string{"35.7.8.9"}},
+			}))
+		})
+
+		It("should extract the NAT IPs of all NAT gateways", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+				TerraformerOutputKeyNATIPs:              "35.1.2.3,35.4.5.6",
+				TerraformerOutputKeyNATGateways:         "foo-cloud-nat=35.1.2.3;35.4.5.6,foo-cloud-nat-internal=35.7.8.9",
+			})
+
+			state, err := ExtractTerraformState(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.NatIPs).To(Equal([]string{"35.1.2.3", "35.4.5.6", "35.7.8.9"}))
+		})
+
+		It("should reserve the configured number of addresses per NAT gateway", func() {
+			var ipCount, gatewayIPCount int32 = 2, 3
+			config.Networks.CloudNAT.IPCount = &ipCount
+			config.Networks.CloudNAT.Gateways[0].IPCount = &gatewayIPCount
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`nat_ips                            = ["${google_compute_address.nat-address-0.self_link}", "${google_compute_address.nat-address-1.self_link}"]`))
+			Expect(files.Main).To(ContainSubstring(`nat_ips                            = ["${google_compute_address.nat-internal-address-0.self_link}", "${google_compute_address.nat-internal-address-1.self_link}", "${google_compute_address.nat-internal-address-2.self_link}"]`))
+			Expect(files.Main).To(ContainSubstring(`name         = "foo-cloud-nat-internal-address-2"`))
+		})
+
+		It("should fail for a number of NAT IPs out of range", func() {
+			var gatewayIPCount int32
+			config.Networks.CloudNAT.Gateways[0].IPCount = &gatewayIPCount
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.cloudNAT.gateways[0].ipCount")))
+		})
+
+		It("should tolerate NAT gateways that did not allocate IPs yet", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+				TerraformerOutputKeyNATGateways:         "foo-cloud-nat=,foo-cloud-nat-internal=",
+			})
+
+			status, err := ComputeStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.NATGateways).To(Equal([]gcpv1alpha1.NATGateway{
+				{Name: "foo-cloud-nat"},
+				{Name: "foo-cloud-nat-internal"},
+			}))
+		})
+
+		It("should fail if the subnets of the Cloud NAT are not selected explicitly", func() {
+			config.Networks.CloudNAT.Subnets = nil
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.cloudNAT.subnets")))
+		})
+	})
+
+	Context("with alias IP prefix lengths", func() {
+		BeforeEach(func() {
+			var (
+				podsPrefixLength     int32 = 18
+				servicesPrefixLength int32 = 20
+			)
+			config.Networks.AliasIPs = &gcpv1alpha1.AliasIPs{
+				PodsPrefixLength:     &podsPrefixLength,
+				ServicesPrefixLength: &servicesPrefixLength,
+			}
+		})
+
+		It("should carve the secondary ranges with the requested sizes", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			pods, services := gardencorev1alpha1.CIDR("11.0.0.0/18"), gardencorev1alpha1.CIDR("12.0.0.0/20")
+			Expect(values).To(HaveKeyWithValue("networks", And(
+				HaveKeyWithValue("pods", &pods),
+				HaveKeyWithValue("services", &services),
+			)))
+		})
+
+		It("should fail for a prefix length not fitting within the network", func() {
+			var podsPrefixLength int32 = 8
+			config.Networks.AliasIPs.PodsPrefixLength = &podsPrefixLength
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.aliasIPs.podsPrefixLength")))
+		})
+	})
+
+	Context("with the Cilium layout", func() {
+		BeforeEach(func() {
+			mode := gcpv1alpha1.NetworkingModeCilium
+			config.Networks.NetworkingMode = &mode
+		})
+
+		It("should only create the services secondary range", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("cilium", map[string]interface{}{"enabled": true}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`range_name    = "foo-services"`))
+			Expect(files.Main).NotTo(ContainSubstring(`range_name    = "foo-pods"`))
+		})
+
+		It("should reject the Cilium layout along with alias IPs", func() {
+			config.Networks.AliasIPs = &gcpv1alpha1.AliasIPs{}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.networkingMode")))
+		})
+
+		It("should surface the networking mode in the status", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+			})
+
+			status, err := ComputeFullStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.NetworkingMode).To(Equal(gcpv1alpha1.NetworkingModeCilium))
+		})
+	})
+
+	Context("with custom routes", func() {
+		var (
+			nextHopVPNTunnel string
+			nextHopIP        string
+		)
+
+		BeforeEach(func() {
+			nextHopVPNTunnel = "projects/foo/regions/bar/vpnTunnels/on-prem"
+			nextHopIP = "10.250.0.5"
+			config.Networks.CustomRoutes = []gcpv1alpha1.Route{
+				{Name: "on-prem", Destination: "192.168.0.0/16", NextHopVPNTunnel: &nextHopVPNTunnel},
+				{Name: "appliance", Destination: "172.16.0.0/12", NextHopIP: &nextHopIP},
+			}
+		})
+
+		It("should compute the route values", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("customRoutes", []interface{}{
+				map[string]interface{}{
+					"name":             "on-prem",
+					"resourceName":     "foo-route-on-prem",
+					"destination":      "192.168.0.0/16",
+					"nextHopVPNTunnel": nextHopVPNTunnel,
+				},
+				map[string]interface{}{
+					"name":         "appliance",
+					"resourceName": "foo-route-appliance",
+					"destination":  "172.16.0.0/12",
+					"nextHopIP":    nextHopIP,
+				},
+			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_route" "route-on-prem"`))
+			Expect(files.Main).To(ContainSubstring(`next_hop_ip         = "10.250.0.5"`))
+			Expect(files.Main).To(ContainSubstring(`value = "${google_compute_route.route-on-prem.name},${google_compute_route.route-appliance.name}"`))
+		})
+
+		It("should render a route with an internal load balancer as next hop", func() {
+			nextHopILB := "projects/foo/regions/bar/forwardingRules/appliance"
+			config.Networks.CustomRoutes[1].NextHopIP = nil
+			config.Networks.CustomRoutes[1].NextHopILB = &nextHopILB
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_route" "route-appliance" {
+  name                = "foo-route-appliance"
+  network             = "vpc"
+  dest_range          = "172.16.0.0/12"
+  next_hop_ilb        = "projects/foo/regions/bar/forwardingRules/appliance"
+}`))
+		})
+
+		It("should fail for a malformed internal load balancer next hop", func() {
+			nextHopILB := "appliance"
+			config.Networks.CustomRoutes[1].NextHopIP = nil
+			config.Networks.CustomRoutes[1].NextHopILB = &nextHopILB
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.customRoutes[1].nextHopILB")))
+		})
+
+		It("should fail for a route without next hop", func() {
+			config.Networks.CustomRoutes[1].NextHopIP = nil
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.customRoutes[1]")))
+		})
+
+		It("should surface the route names in the status", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+				TerraformerOutputKeyRoutes:              "foo-route-on-prem,foo-route-appliance",
+			})
+
+			status, err := ComputeStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.Routes).To(Equal([]string{"foo-route-on-prem", "foo-route-appliance"}))
+		})
+	})
+
+	Context("with flow logs", func() {
+		It("should render the aggregation interval", func() {
+			aggregationInterval := "INTERVAL_30_SEC"
+			config.Networks.FlowLogs = &gcpv1alpha1.FlowLogs{AggregationInterval: &aggregationInterval}
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("flowLogs", map[string]interface{}{
+				"enabled":             true,
+				"aggregationInterval": aggregationInterval,
+			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`aggregation_interval = "INTERVAL_30_SEC"`))
+		})
+
+		It("should apply the default aggregation interval of the provider if none is specified", func() {
+			config.Networks.FlowLogs = &gcpv1alpha1.FlowLogs{}
+
+			ApplyDefaultFlowLogsAggregationInterval(config, "INTERVAL_15_MIN")
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("flowLogs", map[string]interface{}{
+				"enabled":             true,
+				"aggregationInterval": "INTERVAL_15_MIN",
+			}))
+		})
+
+		It("should keep a specified aggregation interval", func() {
+			aggregationInterval := "INTERVAL_30_SEC"
+			config.Networks.FlowLogs = &gcpv1alpha1.FlowLogs{AggregationInterval: &aggregationInterval}
+
+			ApplyDefaultFlowLogsAggregationInterval(config, "INTERVAL_15_MIN")
+
+			Expect(*config.Networks.FlowLogs.AggregationInterval).To(Equal(aggregationInterval))
+		})
+
+		It("should not enable flow logs when applying the default aggregation interval", func() {
+			ApplyDefaultFlowLogsAggregationInterval(config, "INTERVAL_15_MIN")
+
+			Expect(config.Networks.FlowLogs).To(BeNil())
+		})
+
+		It("should render a logging sink for the export destination and surface its name in the status", func() {
+			destination := "storage.googleapis.com/flow-logs"
+			config.Networks.FlowLogs = &gcpv1alpha1.FlowLogs{ExportDestination: &destination}
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("flowLogs", map[string]interface{}{
+				"enabled":           true,
+				"exportDestination": destination,
+			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_logging_project_sink" "flow-logs"`))
+			Expect(files.Main).To(ContainSubstring(`name                   = "foo-flow-logs-sink"`))
+			Expect(files.Main).To(ContainSubstring(`destination            = "storage.googleapis.com/flow-logs"`))
+			Expect(files.Main).To(ContainSubstring(`log_id(\"compute.googleapis.com/vpc_flows\")`))
+			Expect(files.Main).To(ContainSubstring(`output "flow_logs_sink"`))
+
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+				TerraformerOutputKeyFlowLogsSink:        "foo-flow-logs-sink",
+			})
+
+			status, err := ComputeStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.FlowLogsSink).To(Equal("foo-flow-logs-sink"))
+
+			status, err = ComputeFullStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.FlowLogsExportDestination).To(Equal(destination))
+		})
+
+		It("should render a logging sink for a BigQuery dataset with partitioned tables", func() {
+			destination := "bigquery.googleapis.com/projects/my-project/datasets/flow_logs"
+			config.Networks.FlowLogs = &gcpv1alpha1.FlowLogs{
+				ExportDestination:         &destination,
+				BigQueryPartitionedTables: true,
+			}
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("flowLogs", map[string]interface{}{
+				"enabled":                   true,
+				"exportDestination":         destination,
+				"bigQueryPartitionedTables": true,
+			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_logging_project_sink" "flow-logs" {
+  name                   = "foo-flow-logs-sink"
+  destination            = "bigquery.googleapis.com/projects/my-project/datasets/flow_logs"`))
+			Expect(files.Main).To(ContainSubstring(`  unique_writer_identity = true
+
+  bigquery_options {
+    use_partitioned_tables = true
+  }
+}`))
+		})
+
+		It("should fail for partitioned tables without a BigQuery dataset", func() {
+			destination := "storage.googleapis.com/flow-logs"
+			config.Networks.FlowLogs = &gcpv1alpha1.FlowLogs{
+				ExportDestination:         &destination,
+				BigQueryPartitionedTables: true,
+			}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.flowLogs.bigQueryPartitionedTables")))
+		})
+
+		It("should not render a logging sink without an export destination", func() {
+			config.Networks.FlowLogs = &gcpv1alpha1.FlowLogs{}
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).NotTo(ContainSubstring("google_logging_project_sink"))
+		})
+
+		It("should fail for a malformed export destination", func() {
+			destination := "gs://flow-logs"
+			config.Networks.FlowLogs = &gcpv1alpha1.FlowLogs{ExportDestination: &destination}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.flowLogs.exportDestination")))
+		})
+
+		It("should render distinct logging sinks for the export filters of the nodes and the internal subnet", func() {
+			destination := "storage.googleapis.com/flow-logs"
+			config.Networks.FlowLogs = &gcpv1alpha1.FlowLogs{
+				ExportDestination: &destination,
+				ExportFilters: map[gcpv1alpha1.SubnetPurpose]string{
+					gcpv1alpha1.PurposeNodes:    `jsonPayload.reporter="SRC"`,
+					gcpv1alpha1.PurposeInternal: "jsonPayload.connection.dest_port=443",
+				},
+			}
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("flowLogs", map[string]interface{}{
+				"enabled":              true,
+				"exportDestination":    destination,
+				"nodesExportFilter":    `jsonPayload.reporter=\"SRC\"`,
+				"internalExportFilter": "jsonPayload.connection.dest_port=443",
+			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_logging_project_sink" "flow-logs" {
+  name                   = "foo-flow-logs-sink"
+  destination            = "storage.googleapis.com/flow-logs"
+  filter                 = "resource.type=\"gce_subnetwork\" AND log_id(\"compute.googleapis.com/vpc_flows\") AND resource.labels.subnetwork_name=\"${google_compute_subnetwork.subnetwork-nodes.name}\" AND (jsonPayload.reporter=\"SRC\")"`))
+			Expect(files.Main).To(ContainSubstring(`resource "google_logging_project_sink" "flow-logs-internal" {
+  name                   = "foo-flow-logs-sink-internal"
+  destination            = "storage.googleapis.com/flow-logs"
+  filter                 = "resource.type=\"gce_subnetwork\" AND log_id(\"compute.googleapis.com/vpc_flows\") AND resource.labels.subnetwork_name=\"${google_compute_subnetwork.subnetwork-internal.name}\" AND (jsonPayload.connection.dest_port=443)"`))
+		})
+
+		It("should fail for an export filter of an unsupported subnet", func() {
+			destination := "storage.googleapis.com/flow-logs"
+			config.Networks.FlowLogs = &gcpv1alpha1.FlowLogs{
+				ExportDestination: &destination,
+				ExportFilters:     map[gcpv1alpha1.SubnetPurpose]string{gcpv1alpha1.PurposeProxyOnly: "severity>=INFO"},
+			}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.flowLogs.exportFilters[proxy-only]")))
+		})
+
+		It("should fail for an unsupported aggregation interval", func() {
+			aggregationInterval := "INTERVAL_1_SEC"
+			config.Networks.FlowLogs = &gcpv1alpha1.FlowLogs{AggregationInterval: &aggregationInterval}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("INTERVAL_1_SEC")))
+		})
+	})
+
+	Context("with a service account in another project", func() {
+		BeforeEach(func() {
+			project := "service-accounts"
+			config.ServiceAccount = &gcpv1alpha1.ServiceAccountConfig{Project: &project}
+		})
+
+		It("should create the service account in its own project", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("google", HaveKeyWithValue("project", projectID)))
+			Expect(values).To(HaveKeyWithValue("serviceAccount", map[string]interface{}{"project": "service-accounts"}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`project      = "service-accounts"`))
+		})
+
+		It("should fail for an invalid project ID", func() {
+			project := "Service_Accounts"
+			config.ServiceAccount.Project = &project
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("serviceAccount.project")))
+		})
+	})
+
+	Context("with workload identity users", func() {
+		BeforeEach(func() {
+			config.ServiceAccount = &gcpv1alpha1.ServiceAccountConfig{
+				WorkloadIdentityUsers: []string{"kube-system/foo", "garden/bar"},
+			}
+		})
+
+		It("should bind the users to the service account", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("workloadIdentity", map[string]interface{}{
+				"members": []string{
+					fmt.Sprintf("serviceAccount:%s.svc.id.goog[kube-system/foo]", projectID),
+					fmt.Sprintf("serviceAccount:%s.svc.id.goog[garden/bar]", projectID),
+				},
+			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(fmt.Sprintf(`resource "google_service_account_iam_member" "workload-identity-user-1" {
+  service_account_id = "${google_service_account.serviceaccount.name}"
+  role               = "roles/iam.workloadIdentityUser"
+  member             = "serviceAccount:%s.svc.id.goog[garden/bar]"
+
+  depends_on = ["google_service_account.serviceaccount"]
+}`, projectID)))
+		})
+
+		It("should surface the users in the status", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+			})
+
+			status, err := ComputeFullStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.WorkloadIdentityUsers).To(Equal([]string{"kube-system/foo", "garden/bar"}))
+		})
+
+		It("should fail for an invalid user reference", func() {
+			config.ServiceAccount.WorkloadIdentityUsers = []string{"foo"}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("without shoot", func() {
+		It("should fail if the cluster is nil", func() {
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, nil)
+
+			Expect(err).To(MatchError("cluster is nil"))
+		})
+
+		It("should fail if the cluster has no shoot", func() {
+			cluster.Shoot = nil
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError("cluster has no shoot"))
+		})
+
+		It("should fail if the shoot has no GCP cloud configuration", func() {
+			cluster.Shoot.Spec.Cloud.GCP = nil
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("has no GCP cloud configuration")))
+		})
+
+		It("should fail if the region is not permitted by the cloud profile", func() {
+			cluster.CloudProfile = &gardenv1beta1.CloudProfile{
+				Spec: gardenv1beta1.CloudProfileSpec{
+					GCP: &gardenv1beta1.GCPProfile{
+						Constraints: gardenv1beta1.GCPConstraints{
+							Zones: []gardenv1beta1.Zone{
+								{Region: "europe-west1", Names: []string{"europe-west1-b"}},
+							},
+						},
+					},
+				},
+			}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("spec.region")))
+		})
+	})
+
+	Context("without region in the infrastructure", func() {
+		BeforeEach(func() {
+			infra.Spec.Region = ""
+		})
+
+		It("should fall back to the region of the cloud profile", func() {
+			cluster.CloudProfile = &gardenv1beta1.CloudProfile{
+				Spec: gardenv1beta1.CloudProfileSpec{
+					GCP: &gardenv1beta1.GCPProfile{
+						Constraints: gardenv1beta1.GCPConstraints{
+							Zones: []gardenv1beta1.Zone{
+								{Region: "europe-west1", Names: []string{"europe-west1-b", "europe-west1-c"}},
+							},
+						},
+					},
+				},
+			}
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("google", map[string]interface{}{
+				"region":  "europe-west1",
+				"project": projectID,
+			}))
+		})
+
+		It("should fail if the cloud profile does not provide a region either", func() {
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#FirewallApplyTargets", func() {
+		It("should only target the rendered firewall rules", func() {
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+
+			targets, err := FirewallApplyTargets(config)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(targets).To(Equal([]string{
+				"-target=google_compute_firewall.rule-allow-internal-access",
+				"-target=google_compute_firewall.rule-allow-external-access",
+				"-target=google_compute_firewall.rule-allow-health-checks",
+			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			for _, target := range targets {
+				resource := strings.Split(strings.TrimPrefix(target, "-target="), ".")
+				Expect(files.Main).To(ContainSubstring(fmt.Sprintf(`resource "%s" "%s" {`, resource[0], resource[1])))
+			}
+		})
+
+		It("should target the egress rules if all egress traffic is denied", func() {
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{DenyAllEgress: true, DisableAllowHealthChecks: true}
+
+			targets, err := FirewallApplyTargets(config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(targets).To(ConsistOf(
+				"-target=google_compute_firewall.rule-allow-internal-access",
+				"-target=google_compute_firewall.rule-allow-external-access",
+				"-target=google_compute_firewall.rule-deny-all-egress",
+				"-target=google_compute_firewall.rule-allow-cluster-egress",
+				"-target=google_compute_firewall.rule-allow-metadata-egress",
+				"-target=google_compute_firewall.rule-allow-control-plane-egress",
+			))
+		})
+
+		It("should fail if the firewall is unmanaged", func() {
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{Unmanaged: true}
+
+			_, err := FirewallApplyTargets(config)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#RenderTerraformerChart", func() {
+		It("should render the service account but no deny-all egress firewall rule by default", func() {
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_service_account" "serviceaccount"`))
+			Expect(files.Main).NotTo(ContainSubstring(`"rule-deny-all-egress"`))
+		})
+
+		It("should render the sanitized cluster name as ID of the service account", func() {
+			infra.Namespace = "Shoot--Foo.Bar"
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`  account_id   = "shoot--foo-bar"
+  display_name = "shoot--foo-bar"`))
+		})
+
+		It("should capture the terraform variables of a chart naming them differently", func() {
+			expected, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expected.TFVars).NotTo(BeEmpty())
+
+			dir, err := ioutil.TempDir("", "gcp-infra")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+			Expect(copyChart(filepath.Join(InternalChartsPath, "gcp-infra"), filepath.Join(dir, "gcp-infra"), map[string]string{
+				filepath.Join("templates", TFVarsFileName): filepath.Join("templates", "gcp.tfvars"),
+			})).To(Succeed())
+			InternalChartsPath = dir
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.TFVars).To(Equal(expected.TFVars))
+		})
+
+		It("should render the subnets and firewall rules depending on the created network", func() {
+			config.Networks.VPC = nil
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.Count(files.Main, `depends_on = ["google_compute_network.network"]`)).To(Equal(5))
+		})
+
+		It("should destroy the firewall rules, routes and Cloud Router before the created network", func() {
+			nextHopIP := "10.1.0.5"
+			config.Networks.VPC = nil
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{DenyAllEgress: true}
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+			config.Networks.CustomRoutes = []gcpv1alpha1.Route{{Name: "appliance", Destination: "172.16.0.0/12", NextHopIP: &nextHopIP}}
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			resources := 0
+			for _, resource := range strings.Split(files.Main, "\nresource ")[1:] {
+				if strings.HasPrefix(resource, `"google_compute_firewall"`) ||
+					strings.HasPrefix(resource, `"google_compute_router" `) ||
+					strings.HasPrefix(resource, `"google_compute_route" `) {
+					block := resource[:strings.Index(resource, "\n}")]
+					Expect(block).To(ContainSubstring(`depends_on = ["google_compute_network.network"]`), resource)
+					resources++
+				}
+			}
+			Expect(resources).To(Equal(9))
+		})
+
+		It("should not render dependencies on the network if it is not created", func() {
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).NotTo(ContainSubstring("depends_on"))
+		})
+
+		It("should render a Cloud Router and Cloud NAT if the VPC is created", func() {
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_router" "router"`))
+			Expect(files.Main).To(ContainSubstring(`router                             = "${google_compute_router.router.name}"`))
+		})
+
+		It("should render only Cloud NAT on the existing Cloud Router of an existing VPC", func() {
+			config.Networks.VPC.CloudRouter = &gcpv1alpha1.CloudRouter{Name: "router"}
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).NotTo(ContainSubstring(`resource "google_compute_router" "router"`))
+			Expect(files.Main).To(ContainSubstring(`router                             = "router"`))
+		})
+
+		It("should render the port allocation of Cloud NAT", func() {
+			var (
+				minPortsPerVM               int32 = 64
+				maxPortsPerVM               int32 = 1024
+				enableDynamicPortAllocation       = true
+			)
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{
+				MinPortsPerVM:               &minPortsPerVM,
+				MaxPortsPerVM:               &maxPortsPerVM,
+				EnableDynamicPortAllocation: &enableDynamicPortAllocation,
+			}
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("cloudNAT", map[string]interface{}{
+				"enabled":                     true,
+				"routerName":                  DefaultCloudRouterName,
+				"minPortsPerVM":               minPortsPerVM,
+				"maxPortsPerVM":               maxPortsPerVM,
+				"enableDynamicPortAllocation": enableDynamicPortAllocation,
+				"subnets":                     []string{"nodes", "internal"},
+				"addresses": []interface{}{
+					map[string]interface{}{"resourceName": "foo-cloud-nat-address-0", "networkTier": "PREMIUM"},
+				},
+			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`min_ports_per_vm                   = 64`))
+			Expect(files.Main).To(ContainSubstring(`max_ports_per_vm                   = 1024`))
+			Expect(files.Main).To(ContainSubstring(`enable_dynamic_port_allocation     = true`))
+		})
+
+		It("should reserve the Cloud NAT addresses in the configured network tier", func() {
+			networkTier := gcpv1alpha1.NetworkTierStandard
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{
+				NetworkTier: &networkTier,
+				Subnets:     []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeNodes},
+				Gateways:    []gcpv1alpha1.CloudNATGateway{{Name: "internal", Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeInternal}}},
+			}
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			cloudNAT := values["cloudNAT"].(map[string]interface{})
+			Expect(cloudNAT["addresses"]).To(Equal([]interface{}{
+				map[string]interface{}{"resourceName": "foo-cloud-nat-address-0", "networkTier": "STANDARD"},
+			}))
+			Expect(cloudNAT["gateways"].([]interface{})[0]).To(HaveKeyWithValue("addresses", []interface{}{
+				map[string]interface{}{"resourceName": "foo-cloud-nat-internal-address-0", "networkTier": "STANDARD"},
+			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(strings.Count(files.Main, `network_tier = "STANDARD"`)).To(Equal(2))
+		})
+
+		It("should fail for an unsupported network tier of Cloud NAT", func() {
+			networkTier := gcpv1alpha1.NetworkTier("BASIC")
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{NetworkTier: &networkTier}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.cloudNAT.networkTier")))
+		})
+
+		It("should apply Cloud NAT to all subnets by default", func() {
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`name                    = "${google_compute_subnetwork.subnetwork-nodes.self_link}"`))
+			Expect(files.Main).To(ContainSubstring(`name                    = "${google_compute_subnetwork.subnetwork-internal.self_link}"`))
+		})
+
+		It("should apply Cloud NAT only to the selected subnets", func() {
+			config.Networks.VPC = nil
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{
+				Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeInternal},
+			}
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).NotTo(ContainSubstring(`name                    = "${google_compute_subnetwork.subnetwork-nodes.self_link}"`))
+			Expect(files.Main).To(ContainSubstring(`name                    = "${google_compute_subnetwork.subnetwork-internal.self_link}"`))
+		})
+
+		It("should render the subnets in the order of their purposes regardless of the configured order", func() {
+			pscCIDR := gardencorev1alpha1.CIDR("10.3.0.0/24")
+			config.Networks.VPC = nil
+			config.Networks.ProxyOnly = &gcpv1alpha1.ProxyOnlySubnet{CIDR: gardencorev1alpha1.CIDR("10.2.0.0/23")}
+			config.Networks.PrivateServiceConnect = &pscCIDR
+			config.Networks.DisasterRecovery = &gcpv1alpha1.DisasterRecoverySubnet{Region: "eu-west-2", CIDR: gardencorev1alpha1.CIDR("10.4.0.0/16")}
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{
+				Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeInternal, gcpv1alpha1.PurposeNodes},
+			}
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["cloudNAT"]).To(HaveKeyWithValue("subnets", []string{"nodes", "internal"}))
+			Expect(config.Networks.CloudNAT.Subnets).To(Equal([]gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeInternal, gcpv1alpha1.PurposeNodes}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			offset := 0
+			for _, subnet := range []string{"nodes", "internal", "proxy-only", "psc", "disaster-recovery"} {
+				index := strings.Index(files.Main[offset:], fmt.Sprintf(`resource "google_compute_subnetwork" "subnetwork-%s"`, subnet))
+				Expect(index).To(BeNumerically(">=", 0), subnet)
+				offset += index
+			}
+
+			config.Networks.CloudNAT.Subnets = []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeNodes, gcpv1alpha1.PurposeInternal}
+			reordered, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(reordered.Main).To(Equal(files.Main))
+		})
+
+		It("should render a deny-all egress firewall rule with the required allow rules if enabled", func() {
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{DenyAllEgress: true}
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_firewall" "rule-deny-all-egress"`))
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_firewall" "rule-allow-cluster-egress"`))
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_firewall" "rule-allow-control-plane-egress"`))
+			Expect(files.Main).To(ContainSubstring(`"12.0.0.0/16",`))
+		})
+
+		It("should omit the allow-internal-access firewall rule if disabled", func() {
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{DisableAllowInternalAccess: true}
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).NotTo(ContainSubstring(`resource "google_compute_firewall" "rule-allow-internal-access"`))
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_firewall" "rule-allow-external-access"`))
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_firewall" "rule-allow-health-checks"`))
+		})
+
+		It("should render the allow-health-checks firewall rule by default", func() {
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_firewall" "rule-allow-health-checks"`))
+			for _, sourceRange := range HealthCheckSourceRanges {
+				Expect(files.Main).To(ContainSubstring(`"` + sourceRange + `",`))
+			}
+		})
+
+		It("should omit the allow-health-checks firewall rule if disabled", func() {
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{DisableAllowHealthChecks: true}
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).NotTo(ContainSubstring(`resource "google_compute_firewall" "rule-allow-health-checks"`))
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_firewall" "rule-allow-internal-access"`))
+		})
+
+		It("should offset the firewall rule priorities by the priority base", func() {
+			var priorityBase int32 = -100
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{DenyAllEgress: true, PriorityBase: &priorityBase}
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("firewall", map[string]interface{}{
+				"managed":                 true,
+				"denyAllEgress":           true,
+				"allowInternalAccess":     true,
+				"allowExternalAccess":     true,
+				"allowHealthChecks":       true,
+				"healthCheckSourceRanges": HealthCheckSourceRanges,
+				"priorities": map[string]interface{}{
+					"allow":         int32(900),
+					"denyAllEgress": int32(65434),
+				},
+			}))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`priority      = 900`))
+			Expect(files.Main).To(ContainSubstring(`priority           = 65434`))
+		})
+
+		It("should render the networking but no firewall rules if the firewall is unmanaged", func() {
+			config.Networks.VPC = nil
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{Unmanaged: true}
+
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["create"]).To(HaveKeyWithValue("vpc", true))
+			Expect(values["firewall"]).To(HaveKeyWithValue("managed", false))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_network" "network"`))
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_subnetwork" "subnetwork-nodes"`))
+			Expect(files.Main).NotTo(ContainSubstring(`resource "google_compute_firewall"`))
+		})
+
+		It("should fail if the firewall is unmanaged but its rules are configured", func() {
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{Unmanaged: true, DenyAllEgress: true}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("networks.firewall.denyAllEgress")))
+		})
+
+		It("should fail if the offset firewall rule priorities are out of range", func() {
+			var priorityBase int32 = 2
+			config.Networks.Firewall = &gcpv1alpha1.FirewallConfig{DenyAllEgress: true, PriorityBase: &priorityBase}
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("without service account", func() {
+		BeforeEach(func() {
+			createServiceAccount := false
+			config.ServiceAccount = &gcpv1alpha1.ServiceAccountConfig{Create: &createServiceAccount}
+		})
+
+		It("should not render the service account", func() {
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).NotTo(ContainSubstring(`resource "google_service_account" "serviceaccount"`))
+			Expect(files.Main).NotTo(ContainSubstring(TerraformerOutputKeyServiceAccountEmail))
+		})
+
+		It("should not extract the service account email", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:        "vpc",
+				TerraformerOutputKeySubnetNodes:    "nodes",
+				TerraformerOutputKeySubnetInternal: "internal",
+			})
+
+			state, err := ExtractTerraformState(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.ServiceAccountEmail).To(BeEmpty())
+		})
+
+		It("should report the email of a pre-provisioned service account", func() {
+			email := "gardener@my-project.iam.gserviceaccount.com"
+			config.ServiceAccount.Email = &email
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:        "vpc",
+				TerraformerOutputKeySubnetNodes:    "nodes",
+				TerraformerOutputKeySubnetInternal: "internal",
+			})
+
+			status, err := ComputeStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.ServiceAccountEmail).To(Equal(email))
+		})
+
+		It("should reject a malformed email of a pre-provisioned service account", func() {
+			email := "gardener@my-project"
+			config.ServiceAccount.Email = &email
+
+			_, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).To(MatchError(ContainSubstring("iam.gserviceaccount.com")))
+		})
+	})
+
+	Describe("TerraformFiles", func() {
+		Describe("#String", func() {
+			It("should contain all files with their headers", func() {
+				files := &TerraformFiles{
+					Main:      "resource \"foo\" \"bar\" {}",
+					Variables: "variable \"baz\" {}",
+					TFVars:    []byte("baz = \"qux\""),
+				}
+
+				Expect(files.String()).To(Equal(`--- main.tf ---
+resource "foo" "bar" {}
+--- variables.tf ---
+variable "baz" {}
+--- terraform.tfvars ---
+baz = "qux"
+`))
+			})
+		})
+	})
+
+	Describe("#LoadTerraformFiles", func() {
+		It("should load the files stored in the directory", func() {
+			files, err := LoadTerraformFiles(filepath.Join("testdata", "terraform-files"))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`resource "google_compute_network" "network"`))
+			Expect(files.Variables).To(ContainSubstring(`variable "SERVICEACCOUNT"`))
+			Expect(string(files.TFVars)).To(ContainSubstring(`SERVICEACCOUNT = "<redacted>"`))
+		})
+
+		It("should fail if a file is missing", func() {
+			_, err := LoadTerraformFiles(filepath.Join("testdata", "missing"))
+
+			Expect(err).To(MatchError(ContainSubstring("main.tf")))
+		})
+	})
+
+	Describe("#ComputeTerraformerChartValuesYAML", func() {
+		It("should compute deterministic YAML", func() {
+			nextHopIP := "10.250.0.10"
+			config.Networks.CustomRoutes = []gcpv1alpha1.Route{
+				{Name: "on-prem", Destination: "172.16.0.0/12", NextHopIP: &nextHopIP},
+			}
+
+			first, err := ComputeTerraformerChartValuesYAML(infra, serviceAccount, config, cluster)
+			Expect(err).NotTo(HaveOccurred())
+
+			for i := 0; i < 10; i++ {
+				Expect(ComputeTerraformerChartValuesYAML(infra, serviceAccount, config, cluster)).To(Equal(first))
+			}
+			Expect(string(first)).To(ContainSubstring("clusterName: foo\n"))
+			Expect(string(first)).NotTo(ContainSubstring(string(serviceAccountData)))
+		})
+	})
+
+	Describe("#OutputKeys", func() {
+		It("should default to the terraformer output key constants", func() {
+			keys, err := OutputKeys(config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(Equal(map[string]string{
+				TerraformerOutputKeyVPCName:                      TerraformerOutputKeyVPCName,
+				TerraformerOutputKeyServiceAccountEmail:          TerraformerOutputKeyServiceAccountEmail,
+				TerraformerOutputKeySubnetNodes:                  TerraformerOutputKeySubnetNodes,
+				TerraformerOutputKeySubnetInternal:               TerraformerOutputKeySubnetInternal,
+				TerraformerOutputKeySubnetProxyOnly:              TerraformerOutputKeySubnetProxyOnly,
+				TerraformerOutputKeySubnetProxyOnlyRole:          TerraformerOutputKeySubnetProxyOnlyRole,
+				TerraformerOutputKeySubnetPSC:                    TerraformerOutputKeySubnetPSC,
+				TerraformerOutputKeySubnetDisasterRecovery:       TerraformerOutputKeySubnetDisasterRecovery,
+				TerraformerOutputKeySubnetDisasterRecoveryRegion: TerraformerOutputKeySubnetDisasterRecoveryRegion,
+				TerraformerOutputKeySubnetEgressAppliance:        TerraformerOutputKeySubnetEgressAppliance,
+				TerraformerOutputKeySubnetNodesSelfLink:          TerraformerOutputKeySubnetNodesSelfLink,
+				TerraformerOutputKeySubnetInternalSelfLink:       TerraformerOutputKeySubnetInternalSelfLink,
+				TerraformerOutputKeyNATIPs:                       TerraformerOutputKeyNATIPs,
+				TerraformerOutputKeyNATGateways:                  TerraformerOutputKeyNATGateways,
+				TerraformerOutputKeyRoutes:                       TerraformerOutputKeyRoutes,
+				TerraformerOutputKeyFlowLogsSink:                 TerraformerOutputKeyFlowLogsSink,
+				TerraformerOutputKeyControlPlanePeeringRange:     TerraformerOutputKeyControlPlanePeeringRange,
+				TerraformerOutputKeyInternalAddresses:            TerraformerOutputKeyInternalAddresses,
+			}))
+		})
+
+		It("should fail if two output keys map to the same name", func() {
+			config.OutputKeyOverrides = map[string]string{TerraformerOutputKeyVPCName: TerraformerOutputKeySubnetNodes}
+
+			_, err := OutputKeys(config)
+
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail if two overrides map to the same name", func() {
+			config.OutputKeyOverrides = map[string]string{
+				TerraformerOutputKeyVPCName:     "network",
+				TerraformerOutputKeySubnetNodes: "network",
+			}
+
+			_, err := OutputKeys(config)
+
+			Expect(err).To(MatchError(ContainSubstring(`both map to "network"`)))
+		})
+
+		It("should fail if an override is empty", func() {
+			config.OutputKeyOverrides = map[string]string{TerraformerOutputKeyVPCName: ""}
+
+			_, err := OutputKeys(config)
+
+			Expect(err).To(MatchError(ContainSubstring("with an empty name")))
+		})
+
+		It("should accept an override that is a legal terraform identifier", func() {
+			config.OutputKeyOverrides = map[string]string{TerraformerOutputKeyVPCName: "_network-name2"}
+
+			keys, err := OutputKeys(config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(keys).To(HaveKeyWithValue(TerraformerOutputKeyVPCName, "_network-name2"))
+		})
+
+		It("should fail if an override is not a legal terraform identifier", func() {
+			config.OutputKeyOverrides = map[string]string{TerraformerOutputKeyVPCName: "1network"}
+
+			_, err := OutputKeys(config)
+
+			Expect(err).To(MatchError(ContainSubstring(`"1network" is not a legal terraform identifier`)))
+		})
+
+		It("should fail if an unknown output key is overridden", func() {
+			config.OutputKeyOverrides = map[string]string{"foo": "bar"}
+
+			_, err := OutputKeys(config)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("with output key overrides", func() {
+		BeforeEach(func() {
+			config.OutputKeyOverrides = map[string]string{
+				TerraformerOutputKeyVPCName:        "network_name",
+				TerraformerOutputKeySubnetInternal: "subnetwork_internal",
+			}
+		})
+
+		It("should use the overridden output keys when computing the chart values", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("outputKeys", map[string]interface{}{
+				"vpcName":                      "network_name",
+				"serviceAccountEmail":          TerraformerOutputKeyServiceAccountEmail,
+				"subnetNodes":                  TerraformerOutputKeySubnetNodes,
+				"subnetInternal":               "subnetwork_internal",
+				"subnetProxyOnly":              TerraformerOutputKeySubnetProxyOnly,
+				"subnetProxyOnlyRole":          TerraformerOutputKeySubnetProxyOnlyRole,
+				"subnetPSC":                    TerraformerOutputKeySubnetPSC,
+				"subnetDisasterRecovery":       TerraformerOutputKeySubnetDisasterRecovery,
+				"subnetDisasterRecoveryRegion": TerraformerOutputKeySubnetDisasterRecoveryRegion,
+				"subnetEgressAppliance":        TerraformerOutputKeySubnetEgressAppliance,
+				"subnetNodesSelfLink":          TerraformerOutputKeySubnetNodesSelfLink,
+				"subnetInternalSelfLink":       TerraformerOutputKeySubnetInternalSelfLink,
+				"natIPs":                       TerraformerOutputKeyNATIPs,
+				"natGateways":                  TerraformerOutputKeyNATGateways,
+				"routes":                       TerraformerOutputKeyRoutes,
+				"flowLogsSink":                 TerraformerOutputKeyFlowLogsSink,
+				"controlPlanePeeringRange":     TerraformerOutputKeyControlPlanePeeringRange,
+				"internalAddresses":            TerraformerOutputKeyInternalAddresses,
+			}))
+		})
+
+		It("should use the overridden output keys when extracting the terraform state", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				"network_name":                          "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "gardener@cloud",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				"subnetwork_internal":                   "internal",
+			})
+
+			state, err := ExtractTerraformState(tf, config)
+
+			subnetInternal := "internal"
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state).To(Equal(&TerraformState{
+				VPCName:             "vpc",
+				ServiceAccountEmail: "gardener@cloud",
+				SubnetNodes:         "nodes",
+				SubnetInternal:      &subnetInternal,
+			}))
+		})
+	})
+
+	Describe("TerraformState JSON", func() {
+		It("should round-trip a fully populated state", func() {
+			subnetInternal := "internal"
+			state := &TerraformState{
+				VPCName:             "vpc",
+				ServiceAccountEmail: "gardener@cloud",
+				SubnetNodes:         "nodes",
+				SubnetInternal:      &subnetInternal,
+			}
+
+			data, err := json.Marshal(state)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(ContainSubstring(`"version":"%s"`, TerraformStateSnapshotVersion))
+
+			actual := &TerraformState{}
+			Expect(json.Unmarshal(data, actual)).To(Succeed())
+			Expect(actual).To(Equal(state))
+		})
+
+		It("should fail to read a snapshot of an unknown version", func() {
+			Expect(json.Unmarshal([]byte(`{"version":"v0"}`), &TerraformState{})).NotTo(Succeed())
+		})
+	})
+
+	Describe("#StatusFromTerraformState", func() {
+		var (
+			serviceAccountEmail string
+			vpcName             string
+			subnetNodes         string
+			subnetInternal      string
+
+			state *TerraformState
+		)
+
+		BeforeEach(func() {
+			serviceAccountEmail = "gardener@cloud"
+			vpcName = "vpc-name"
+			subnetNodes = "nodes-subnet"
+			subnetInternal = "internal"
+
+			state = &TerraformState{
+				VPCName:             vpcName,
+				ServiceAccountEmail: serviceAccountEmail,
+				SubnetNodes:         subnetNodes,
+				SubnetInternal:      &subnetInternal,
+			}
+		})
+
+		It("should correctly compute the status", func() {
+			status := StatusFromTerraformState(state)
+
+			Expect(status).To(Equal(&gcpv1alpha1.InfrastructureStatus{
+				TypeMeta: StatusTypeMeta,
+				Networks: gcpv1alpha1.NetworkStatus{
+					VPC: gcpv1alpha1.VPC{
+						Name: vpcName,
+					},
+					Subnets: []gcpv1alpha1.Subnet{
+						{
+							Purpose: gcpv1alpha1.PurposeNodes,
+							Name:    subnetNodes,
+						},
+						{
+							Purpose: gcpv1alpha1.PurposeInternal,
+							Name:    subnetInternal,
+						},
+					},
+				},
+				ServiceAccountEmail: serviceAccountEmail,
+			}))
+		})
+
+		It("should correctly compute the status without internal subnet", func() {
+			state.SubnetInternal = nil
+			status := StatusFromTerraformState(state)
+
+			Expect(status).To(Equal(&gcpv1alpha1.InfrastructureStatus{
+				TypeMeta: StatusTypeMeta,
+				Networks: gcpv1alpha1.NetworkStatus{
+					VPC: gcpv1alpha1.VPC{
+						Name: vpcName,
+					},
+					Subnets: []gcpv1alpha1.Subnet{
+						{
+							Purpose: gcpv1alpha1.PurposeNodes,
+							Name:    subnetNodes,
+						},
+					},
+				},
+				ServiceAccountEmail: serviceAccountEmail,
+			}))
+		})
+
+		It("should use the configured status version", func() {
+			defer func(gv schema.GroupVersion) { StatusGroupVersion = gv }(StatusGroupVersion)
+			StatusGroupVersion = schema.GroupVersion{Group: gcpv1alpha1.SchemeGroupVersion.Group, Version: "v1beta1"}
+
+			status := StatusFromTerraformState(state)
+
+			Expect(status.TypeMeta).To(Equal(metav1.TypeMeta{
+				APIVersion: gcpv1alpha1.SchemeGroupVersion.Group + "/v1beta1",
+				Kind:       "InfrastructureStatus",
+			}))
+		})
+
+		It("should use the custom purpose label of the internal subnet", func() {
+			subnetInternalPurpose := "ilb"
+			state.SubnetInternalPurpose = &subnetInternalPurpose
+			status := StatusFromTerraformState(state)
+
+			Expect(status.Networks.Subnets).To(Equal([]gcpv1alpha1.Subnet{
+				{
+					Purpose: gcpv1alpha1.PurposeNodes,
+					Name:    subnetNodes,
+				},
+				{
+					Purpose: gcpv1alpha1.SubnetPurpose("ilb"),
+					Name:    subnetInternal,
+				},
+			}))
+		})
+
+		It("should prefix the purposes of all subnets", func() {
+			subnetInternalPurpose, subnetPurposePrefix := "ilb", "gardener-"
+			state.SubnetInternalPurpose = &subnetInternalPurpose
+			state.SubnetPurposePrefix = &subnetPurposePrefix
+			status := StatusFromTerraformState(state)
+
+			Expect(status.Networks.Subnets).To(Equal([]gcpv1alpha1.Subnet{
+				{
+					Purpose: gcpv1alpha1.SubnetPurpose("gardener-nodes"),
+					Name:    subnetNodes,
+				},
+				{
+					Purpose: gcpv1alpha1.SubnetPurpose("gardener-ilb"),
+					Name:    subnetInternal,
+				},
+			}))
+		})
+	})
+
+	Describe("#ResourcePurposeMap", func() {
+		var state *TerraformState
+
+		BeforeEach(func() {
+			subnetInternal := "internal-subnet"
+			state = &TerraformState{
+				VPCName:        "vpc",
+				SubnetNodes:    "nodes-subnet",
+				SubnetInternal: &subnetInternal,
+			}
+		})
+
+		It("should map the nodes and internal subnets to their purposes", func() {
+			Expect(ResourcePurposeMap(state)).To(Equal(map[string]gcpv1alpha1.SubnetPurpose{
+				"nodes-subnet":    gcpv1alpha1.PurposeNodes,
+				"internal-subnet": gcpv1alpha1.PurposeInternal,
+			}))
+		})
+
+		It("should use the custom purpose of the internal subnet", func() {
+			purpose := "ilb"
+			state.SubnetInternalPurpose = &purpose
+
+			Expect(ResourcePurposeMap(state)).To(HaveKeyWithValue("internal-subnet", gcpv1alpha1.SubnetPurpose("ilb")))
+		})
+	})
+
+	Describe("#SubnetsByRegion", func() {
+		It("should group the subnets by their regions", func() {
+			status := &gcpv1alpha1.InfrastructureStatus{
+				Networks: gcpv1alpha1.NetworkStatus{
+					Subnets: []gcpv1alpha1.Subnet{
+						{Purpose: gcpv1alpha1.PurposeNodes, Name: "nodes"},
+						{Purpose: gcpv1alpha1.PurposeInternal, Name: "internal"},
+						{Purpose: gcpv1alpha1.PurposeDisasterRecovery, Name: "disaster-recovery", Region: "europe-west4"},
+					},
+				},
+			}
+
+			Expect(SubnetsByRegion(status, "europe-west1")).To(Equal(map[string][]gcpv1alpha1.Subnet{
+				"europe-west1": {
+					{Purpose: gcpv1alpha1.PurposeNodes, Name: "nodes"},
+					{Purpose: gcpv1alpha1.PurposeInternal, Name: "internal"},
+				},
+				"europe-west4": {
+					{Purpose: gcpv1alpha1.PurposeDisasterRecovery, Name: "disaster-recovery", Region: "europe-west4"},
+				},
+			}))
+		})
+
+		It("should return an empty map for a status without subnets", func() {
+			Expect(SubnetsByRegion(&gcpv1alpha1.InfrastructureStatus{}, "europe-west1")).To(BeEmpty())
+		})
+	})
+
+	Describe("#ComputeStatus", func() {
+		It("should compute the status only from the terraform outputs", func() {
+			InternalChartsPath = "does-not-exist"
+			tf := mockinfrastructure.NewMockTerraformStateReader(ctrl)
+			tf.EXPECT().GetStateOutputVariables(
+				TerraformerOutputKeyVPCName,
+				TerraformerOutputKeySubnetNodes,
+				TerraformerOutputKeyServiceAccountEmail,
+				TerraformerOutputKeySubnetInternal,
+			).Return(map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetInternal:      "internal",
+			}, nil)
+			tf.EXPECT().GetStateOutputVariables(TerraformerOutputKeySubnetNodesSelfLink).
+				Return(map[string]string{TerraformerOutputKeySubnetNodesSelfLink: "nodes-self-link"}, nil)
+			tf.EXPECT().GetStateOutputVariables(TerraformerOutputKeySubnetInternalSelfLink).
+				Return(map[string]string{TerraformerOutputKeySubnetInternalSelfLink: "internal-self-link"}, nil)
+
+			status, err := ComputeStatus(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(&gcpv1alpha1.InfrastructureStatus{
+				TypeMeta: StatusTypeMeta,
+				Networks: gcpv1alpha1.NetworkStatus{
+					VPC: gcpv1alpha1.VPC{Name: "vpc"},
+					Subnets: []gcpv1alpha1.Subnet{
+						{Purpose: gcpv1alpha1.PurposeNodes, Name: "nodes", SelfLink: "nodes-self-link"},
+						{Purpose: gcpv1alpha1.PurposeInternal, Name: "internal", SelfLink: "internal-self-link"},
+					},
+				},
+				ServiceAccountEmail: "email",
+			}))
+		})
+	})
+
+	Describe("#ComputeFullStatus", func() {
+		var tf TerraformStateReader
+
+		BeforeEach(func() {
+			tf = newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+			})
+		})
+
+		It("should include the config metadata in addition to the minimal status", func() {
+			minimal, err := ComputeStatus(tf, config)
+			Expect(err).NotTo(HaveOccurred())
+
+			status, err := ComputeFullStatus(tf, config)
+
+			vpcManaged := false
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.VPCManaged).To(Equal(&vpcManaged))
+			Expect(status.Networks.NetworkingMode).To(Equal(gcpv1alpha1.NetworkingModeDefault))
+			Expect(status.Networks.Subnets).To(Equal(minimal.Networks.Subnets))
+			Expect(minimal.Networks.VPCManaged).To(BeNil())
+		})
+
+		It("should flag a created VPC as managed", func() {
+			config.Networks.VPC = nil
+
+			status, err := ComputeFullStatus(tf, config)
+
+			vpcManaged := true
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.VPCManaged).To(Equal(&vpcManaged))
+		})
+	})
+
+	Describe("#ComputeStatusPartial", func() {
+		It("should return the best-effort status and the errors of the failed outputs", func() {
+			tf := mockinfrastructure.NewMockTerraformStateReader(ctrl)
+			tf.EXPECT().GetStateOutputVariables(TerraformerOutputKeyVPCName).
+				Return(map[string]string{TerraformerOutputKeyVPCName: "vpc"}, nil)
+			tf.EXPECT().GetStateOutputVariables(TerraformerOutputKeySubnetNodes).
+				Return(map[string]string{TerraformerOutputKeySubnetNodes: "nodes"}, nil)
+			tf.EXPECT().GetStateOutputVariables(TerraformerOutputKeyServiceAccountEmail).
+				Return(map[string]string{TerraformerOutputKeyServiceAccountEmail: "email"}, nil)
+			tf.EXPECT().GetStateOutputVariables(TerraformerOutputKeySubnetInternal).
+				Return(nil, fmt.Errorf("error"))
+			tf.EXPECT().GetStateOutputVariables(TerraformerOutputKeySubnetNodesSelfLink).
+				Return(map[string]string{TerraformerOutputKeySubnetNodesSelfLink: "nodes-self-link"}, nil)
+			tf.EXPECT().GetStateOutputVariables(TerraformerOutputKeySubnetInternalSelfLink).
+				Return(map[string]string{TerraformerOutputKeySubnetInternalSelfLink: "internal-self-link"}, nil)
+
+			status, err := ComputeStatusPartial(tf, config)
+
+			Expect(err).To(MatchError(ContainSubstring(TerraformerOutputKeySubnetInternal)))
+			Expect(status.Networks.VPC.Name).To(Equal("vpc"))
+			Expect(status.ServiceAccountEmail).To(Equal("email"))
+			Expect(status.Networks.Subnets).To(Equal([]gcpv1alpha1.Subnet{
+				{Purpose: gcpv1alpha1.PurposeNodes, Name: "nodes", SelfLink: "nodes-self-link"},
+			}))
+		})
+
+		It("should return the complete status without error if all outputs can be read", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+			})
+
+			expected, err := ComputeFullStatus(tf, config)
+			Expect(err).NotTo(HaveOccurred())
+
+			status, err := ComputeStatusPartial(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(expected))
+		})
+	})
+
+	Describe("#ComputeStatusAfterPartialApply", func() {
+		It("should only reflect the resources whose outputs exist and list the missing ones", func() {
+			destination := "storage.googleapis.com/bucket"
+			config.Networks.FlowLogs = &gcpv1alpha1.FlowLogs{ExportDestination: &destination}
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:        "vpc",
+				TerraformerOutputKeySubnetInternal: "internal",
+			})
+
+			status, err := ComputeStatusAfterPartialApply(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Networks.VPC.Name).To(Equal("vpc"))
+			Expect(status.Networks.Subnets).To(Equal([]gcpv1alpha1.Subnet{
+				{Purpose: gcpv1alpha1.PurposeInternal, Name: "internal"},
+			}))
+			Expect(status.Networks.FlowLogsExportDestination).To(BeEmpty())
+			Expect(status.ServiceAccountEmail).To(BeEmpty())
+			Expect(status.MissingOutputs).To(Equal([]string{TerraformerOutputKeySubnetNodes, TerraformerOutputKeyServiceAccountEmail}))
+		})
+
+		It("should return the complete status without missing outputs if all outputs exist", func() {
+			tf := newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, map[string]string{
+				TerraformerOutputKeyVPCName:             "vpc",
+				TerraformerOutputKeyServiceAccountEmail: "email",
+				TerraformerOutputKeySubnetNodes:         "nodes",
+				TerraformerOutputKeySubnetInternal:      "internal",
+			})
+
+			expected, err := ComputeFullStatus(tf, config)
+			Expect(err).NotTo(HaveOccurred())
+
+			status, err := ComputeStatusAfterPartialApply(tf, config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status).To(Equal(expected))
+			Expect(status.MissingOutputs).To(BeEmpty())
+		})
+
+		It("should return errors other than missing outputs", func() {
+			tf := mockinfrastructure.NewMockTerraformStateReader(ctrl)
+			tf.EXPECT().GetStateOutputVariables(TerraformerOutputKeyVPCName).Return(nil, fmt.Errorf("error"))
+
+			_, err := ComputeStatusAfterPartialApply(tf, config)
+
+			Expect(err).To(MatchError("error"))
+		})
+	})
+
+	Describe("#ExpectedOutputs", func() {
+		It("should include the internal subnet only if it is configured", func() {
+			outputs, err := ExpectedOutputs(config, map[string]string{TerraformerOutputKeySubnetInternal: "internal"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(HaveKeyWithValue(TerraformerOutputKeySubnetInternal, "internal"))
+			Expect(outputs).To(HaveKeyWithValue(TerraformerOutputKeySubnetInternalSelfLink, TerraformerOutputKeySubnetInternalSelfLink))
+
+			config.Networks.Internal = nil
+
+			outputs, err = ExpectedOutputs(config, nil)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).NotTo(HaveKey(TerraformerOutputKeySubnetInternal))
+			Expect(outputs).NotTo(HaveKey(TerraformerOutputKeySubnetInternalSelfLink))
+		})
+
+		It("should use the overridden output keys", func() {
+			config.OutputKeyOverrides = map[string]string{TerraformerOutputKeyVPCName: "network_name"}
+
+			outputs, err := ExpectedOutputs(config, map[string]string{TerraformerOutputKeyVPCName: "vpc"})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(outputs).To(HaveKeyWithValue("network_name", "vpc"))
+			Expect(outputs).NotTo(HaveKey(TerraformerOutputKeyVPCName))
+		})
+
+		It("should produce outputs the state can be extracted from", func() {
+			config.Networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+			outputs, err := ExpectedOutputs(config, map[string]string{
+				TerraformerOutputKeySubnetNodes: "nodes",
+				TerraformerOutputKeyNATIPs:      "1.2.3.4",
+				TerraformerOutputKeyNATGateways: "foo-cloud-nat=1.2.3.4",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			state, err := ExtractTerraformState(newTerraformerWithOutputs(ctrl, infra.Namespace, infra.Name, outputs), config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(state.SubnetNodes).To(Equal("nodes"))
+			Expect(state.NatIPs).To(Equal([]string{"1.2.3.4"}))
+		})
+	})
+
+	Describe("#OutputsDiff", func() {
+		It("should only contain the changed output variables", func() {
+			diff := OutputsDiff(map[string]string{
+				TerraformerOutputKeyVPCName:        "vpc",
+				TerraformerOutputKeySubnetNodes:    "nodes",
+				TerraformerOutputKeySubnetInternal: "internal",
+			}, map[string]string{
+				TerraformerOutputKeyVPCName:     "vpc",
+				TerraformerOutputKeySubnetNodes: "nodes-2",
+				TerraformerOutputKeySubnetPSC:   "psc",
+			})
+
+			Expect(diff).To(Equal(map[string][2]string{
+				TerraformerOutputKeySubnetNodes:    {"nodes", "nodes-2"},
+				TerraformerOutputKeySubnetInternal: {"internal", ""},
+				TerraformerOutputKeySubnetPSC:      {"", "psc"},
+			}))
+		})
+
+		It("should be empty for unchanged output variables", func() {
+			outputs := map[string]string{TerraformerOutputKeyVPCName: "vpc"}
+
+			Expect(OutputsDiff(outputs, outputs)).To(BeEmpty())
+		})
+	})
+
+	Describe("#AllSubnetPurposes", func() {
+		It("should contain the purposes of all rendered subnets", func() {
+			Expect(AllSubnetPurposes()).To(ContainElement(gcpv1alpha1.PurposeNodes))
+			Expect(AllSubnetPurposes()).To(ContainElement(gcpv1alpha1.PurposeInternal))
+			Expect(AllSubnetPurposes()).To(ContainElement(gcpv1alpha1.PurposePrivateServiceConnect))
+		})
+	})
+
+	Describe("#StateRefresh", func() {
+		It("should refresh the state by default", func() {
+			Expect(StateRefresh(&gcpv1alpha1.InfrastructureConfig{})).To(BeTrue())
+		})
+
+		It("should not refresh the state if disabled", func() {
+			stateRefresh := false
+			Expect(StateRefresh(&gcpv1alpha1.InfrastructureConfig{StateRefresh: &stateRefresh})).To(BeFalse())
+		})
+	})
+
+	Describe("#PreviousNetworkConfig", func() {
+		It("should return the ranges recorded in the status", func() {
+			state := &TerraformState{VPCName: "vpc", SubnetNodes: "nodes"}
+			status := StatusFromTerraformStateWithConfig(state, config)
+
+			Expect(PreviousNetworkConfig(status)).To(Equal(&gcpv1alpha1.NetworkConfig{
+				Worker:   config.Networks.Worker,
+				Internal: config.Networks.Internal,
+			}))
+		})
+
+		It("should return whether the internal subnet is proxy-only", func() {
+			config.Networks.InternalProxyOnly = true
+			state := &TerraformState{VPCName: "vpc", SubnetNodes: "nodes"}
+			status := StatusFromTerraformStateWithConfig(state, config)
+
+			Expect(PreviousNetworkConfig(status).InternalProxyOnly).To(BeTrue())
+		})
+
+		It("should return nil if the status does not record the ranges", func() {
+			Expect(PreviousNetworkConfig(&gcpv1alpha1.InfrastructureStatus{})).To(BeNil())
+			Expect(PreviousNetworkConfig(nil)).To(BeNil())
+		})
+	})
+
+	Describe("#IsVPCModeTransition", func() {
+		var status *gcpv1alpha1.InfrastructureStatus
+
+		BeforeEach(func() {
+			vpcManaged := true
+			status = &gcpv1alpha1.InfrastructureStatus{
+				Networks: gcpv1alpha1.NetworkStatus{
+					VPC:            gcpv1alpha1.VPC{Name: "shoot--foo--bar"},
+					VPCManaged:     &vpcManaged,
+					NetworkingMode: gcpv1alpha1.NetworkingModeDefault,
+				},
+			}
+		})
+
+		It("should detect that a previously managed VPC is now referenced", func() {
+			Expect(IsVPCModeTransition(config, status)).To(BeTrue())
+		})
+
+		It("should not detect a transition if the VPC is still managed", func() {
+			config.Networks.VPC = nil
+
+			Expect(IsVPCModeTransition(config, status)).To(BeFalse())
+		})
+
+		It("should not detect a transition if the previous status does not state whether the VPC was managed", func() {
+			status.Networks.VPCManaged = nil
+
+			Expect(IsVPCModeTransition(config, status)).To(BeFalse())
+			Expect(IsVPCModeTransition(config, nil)).To(BeFalse())
+		})
+	})
+
+	Describe("#RecordApplyMetrics", func() {
+		It("should stamp the duration and time of the last apply", func() {
+			var (
+				status    = &gcpv1alpha1.InfrastructureStatus{}
+				applyTime = time.Date(2019, time.June, 1, 12, 0, 0, 0, time.UTC)
+			)
+
+			RecordApplyMetrics(status, 3*time.Minute, applyTime)
+
+			Expect(status.LastApplyDuration).To(Equal(&metav1.Duration{Duration: 3 * time.Minute}))
+			Expect(status.LastApplyTime).To(Equal(&metav1.Time{Time: applyTime}))
+		})
+	})
+
+	Describe("#StatusFingerprint", func() {
+		var status *gcpv1alpha1.InfrastructureStatus
+
+		BeforeEach(func() {
+			status = &gcpv1alpha1.InfrastructureStatus{
+				TypeMeta: StatusTypeMeta,
+				Networks: gcpv1alpha1.NetworkStatus{
+					VPC: gcpv1alpha1.VPC{Name: "vpc"},
+					Subnets: []gcpv1alpha1.Subnet{
+						{Purpose: gcpv1alpha1.PurposeNodes, Name: "nodes"},
+						{Purpose: gcpv1alpha1.PurposeInternal, Name: "internal"},
+					},
+				},
+				ServiceAccountEmail: "email",
+			}
+		})
+
+		It("should compute the same fingerprint for identical statuses", func() {
+			fingerprint := StatusFingerprint(status)
+
+			Expect(fingerprint).To(HaveLen(16))
+			Expect(StatusFingerprint(status.DeepCopy())).To(Equal(fingerprint))
+		})
+
+		It("should ignore the fields describing the last apply", func() {
+			fingerprint := StatusFingerprint(status)
+			serial := int64(7)
+			RecordApplyMetrics(status, time.Minute, time.Date(2019, time.June, 1, 12, 0, 0, 0, time.UTC))
+			status.StateSerial = &serial
+
+			Expect(StatusFingerprint(status)).To(Equal(fingerprint))
+		})
+
+		It("should change the fingerprint if a subnet changes", func() {
+			fingerprint := StatusFingerprint(status)
+			status.Networks.Subnets[1].Name = "internal2"
+
+			Expect(StatusFingerprint(status)).NotTo(Equal(fingerprint))
+		})
+	})
+
+	Describe("#ReadStateSerial", func() {
+		It("should read the serial of the terraform state", func() {
+			tf := newTerraformerWithState(ctrl, infra.Namespace, infra.Name, map[string]interface{}{
+				"version": 3,
+				"serial":  42,
+				"modules": []interface{}{},
+			})
+
+			serial, err := ReadStateSerial(tf)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serial).To(Equal(int64(42)))
+		})
+
+		It("should fail if the terraform state has no serial", func() {
+			tf := newTerraformerWithState(ctrl, infra.Namespace, infra.Name, map[string]interface{}{
+				"modules": []interface{}{},
+			})
+
+			_, err := ReadStateSerial(tf)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#ValidateInfrastructureConfigComplete", func() {
+		BeforeEach(func() {
+			cluster.Shoot.Spec.Cloud.Region = "eu-west-1"
+			cluster.Shoot.Status.TechnicalID = "shoot--foo--bar"
+		})
+
+		It("should allow a valid config", func() {
+			Expect(ValidateInfrastructureConfigComplete(config, cluster, serviceAccount)).To(BeEmpty())
+		})
+
+		It("should report all independent errors together", func() {
+			internalPurpose := gcpv1alpha1.SubnetPurpose("")
+			config.Networks.InternalPurpose = &internalPurpose
+			config.DiskEncryptionKey = "projects/project/keyRings/ring/cryptoKeys/key"
+			cluster.Shoot.Spec.Cloud.Region = "eu west 1"
+
+			errs := ValidateInfrastructureConfigComplete(config, cluster, serviceAccount)
+
+			Expect(errs).To(HaveLen(3))
+			Expect(errs[0].Field).To(Equal("region"))
+			Expect(errs[1].Field).To(Equal("diskEncryptionKey"))
+			Expect(errs[2].Field).To(Equal("networks.internalPurpose"))
+		})
+
+		It("should report an invalid technical ID", func() {
+			cluster.Shoot.Status.TechnicalID = ""
+
+			errs := ValidateInfrastructureConfigComplete(config, cluster, serviceAccount)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("shoot.status.technicalID"))
+		})
+	})
+
+	Describe("#SubnetName", func() {
+		It("should match the names of the nodes and the internal subnet of the chart", func() {
+			values, err := ComputeTerraformerChartValues(infra, serviceAccount, config, cluster)
+			Expect(err).NotTo(HaveOccurred())
+
+			nodesName, err := SubnetName(infra.Namespace, gcpv1alpha1.PurposeNodes)
+			Expect(err).NotTo(HaveOccurred())
+			internalName, err := SubnetName(infra.Namespace, gcpv1alpha1.PurposeInternal)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(values["resourceNames"]).To(HaveKeyWithValue("subnetNodes", nodesName))
+			Expect(values["resourceNames"]).To(HaveKeyWithValue("subnetInternal", internalName))
+
+			files, err := RenderTerraformerChart(renderer, infra, serviceAccount, config, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files.Main).To(ContainSubstring(`name          = "` + nodesName + `"`))
+			Expect(files.Main).To(ContainSubstring(`name          = "` + internalName + `"`))
 		})
 	})
 })
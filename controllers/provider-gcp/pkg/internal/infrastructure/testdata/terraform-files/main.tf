@@ -0,0 +1,10 @@
+provider "google" {
+  credentials = "${var.SERVICEACCOUNT}"
+  project     = "project"
+  region      = "eu-west-1"
+}
+
+resource "google_compute_network" "network" {
+  name                    = "shoot--foo--bar"
+  auto_create_subnetworks = "false"
+}
@@ -0,0 +1,4 @@
+variable "SERVICEACCOUNT" {
+  description = "ServiceAccount"
+  type        = "string"
+}
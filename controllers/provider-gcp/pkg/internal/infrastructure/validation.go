@@ -0,0 +1,1940 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
+	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/gcp"
+	"github.com/gardener/gardener-extensions/pkg/controller"
+
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// MaxLabels is the maximum number of labels a GCP resource can carry.
+	MaxLabels = 64
+	// MaxLabelLength is the maximum length of a GCP label key or value.
+	MaxLabelLength = 63
+
+	// MinNATPortsPerVM is the minimum number of Cloud NAT ports that can be allocated to a VM.
+	MinNATPortsPerVM = 2
+	// MaxNATPortsPerVM is the maximum number of Cloud NAT ports that can be allocated to a VM.
+	MaxNATPortsPerVM = 65536
+	// MaxNATIPCount is the maximum number of external IPs that can be reserved for a NAT gateway.
+	MaxNATIPCount = 300
+
+	// MaxControlPlanePeeringPrefixLength is the largest prefix length of a range reserved for private services
+	// access.
+	MaxControlPlanePeeringPrefixLength = 24
+)
+
+var (
+	// FlowLogsAggregationIntervals are the aggregation intervals of VPC flow logs supported by GCP.
+	FlowLogsAggregationIntervals = []string{
+		"INTERVAL_5_SEC",
+		"INTERVAL_30_SEC",
+		"INTERVAL_1_MIN",
+		"INTERVAL_5_MIN",
+		"INTERVAL_10_MIN",
+		"INTERVAL_15_MIN",
+	}
+
+	labelKeyRegex   = regexp.MustCompile(`^[\p{Ll}][\p{Ll}0-9_-]*$`)
+	labelValueRegex = regexp.MustCompile(`^[\p{Ll}0-9_-]*$`)
+
+	terraformIdentifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+	routeNameRegex      = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	vpnTunnelRegex      = regexp.MustCompile(`^(https://www\.googleapis\.com/compute/v1/)?projects/[^/]+/regions/[^/]+/vpnTunnels/[^/]+$`)
+	forwardingRuleRegex = regexp.MustCompile(`^(https://www\.googleapis\.com/compute/v1/)?projects/[^/]+/regions/[^/]+/forwardingRules/[^/]+$`)
+
+	projectIDRegex = regexp.MustCompile(`^[a-z][-a-z0-9]{4,28}[a-z0-9]$`)
+	regionRegex    = regexp.MustCompile(`^[a-z]+(-[a-z]+)+-?[0-9]+$`)
+
+	serviceAccountEmailRegex        = regexp.MustCompile(`^[a-z][-a-z0-9]{4,28}[a-z0-9]@[a-z][-a-z0-9]{4,28}[a-z0-9]\.iam\.gserviceaccount\.com$`)
+	computeServiceAccountEmailRegex = regexp.MustCompile(`^[0-9]+-compute@developer\.gserviceaccount\.com$`)
+
+	// flowLogsBigQueryDestinationRegex matches the BigQuery dataset destinations of logging sinks.
+	flowLogsBigQueryDestinationRegex = regexp.MustCompile(`^bigquery\.googleapis\.com/projects/[a-z][-a-z0-9]{4,28}[a-z0-9]/datasets/[a-zA-Z0-9_]+$`)
+	// flowLogsExportDestinationRegexes match the destinations of logging sinks supported by GCP.
+	flowLogsExportDestinationRegexes = []*regexp.Regexp{
+		regexp.MustCompile(`^storage\.googleapis\.com/[a-z0-9][-a-z0-9_.]{1,61}[a-z0-9]$`),
+		flowLogsBigQueryDestinationRegex,
+		regexp.MustCompile(`^pubsub\.googleapis\.com/projects/[a-z][-a-z0-9]{4,28}[a-z0-9]/topics/[a-zA-Z][-a-zA-Z0-9_.~+%]{2,254}$`),
+		regexp.MustCompile(`^logging\.googleapis\.com/projects/[a-z][-a-z0-9]{4,28}[a-z0-9]/locations/[a-z0-9-]+/buckets/[a-zA-Z0-9_-]{1,100}$`),
+	}
+
+	servicePerimeterRegex = regexp.MustCompile(`^accessPolicies/[0-9]+/servicePerimeters/[a-zA-Z][a-zA-Z0-9_]{0,49}$`)
+
+	kmsKeyRegex = regexp.MustCompile(`^projects/[a-z][-a-z0-9]{4,28}[a-z0-9]/locations/[a-z0-9-]+/keyRings/[a-zA-Z0-9_-]{1,63}/cryptoKeys/[a-zA-Z0-9_-]{1,63}$`)
+)
+
+// MTURange is an inclusive range of maximum transmission units in bytes.
+type MTURange struct {
+	// Min is the smallest MTU of the range.
+	Min int32
+	// Max is the largest MTU of the range.
+	Max int32
+}
+
+// Contains checks whether the given MTU is within the range.
+func (r MTURange) Contains(mtu int32) bool {
+	return r.Min <= mtu && mtu <= r.Max
+}
+
+// DefaultMTURange is the range of MTUs supported by GCP.
+var DefaultMTURange = MTURange{Min: 1300, Max: 8896}
+
+// ASNRange is an inclusive range of BGP autonomous system numbers.
+type ASNRange struct {
+	// Min is the smallest ASN of the range.
+	Min int64
+	// Max is the largest ASN of the range.
+	Max int64
+}
+
+// Contains checks whether the given ASN is within the range.
+func (r ASNRange) Contains(asn int64) bool {
+	return r.Min <= asn && asn <= r.Max
+}
+
+// PrivateASNRanges are the private 16-bit and 32-bit ASN ranges a Cloud Router can use for dynamic routing.
+var PrivateASNRanges = []ASNRange{
+	{Min: 64512, Max: 65534},
+	{Min: 4200000000, Max: 4294967294},
+}
+
+const (
+	// MinFirewallPriority is the highest firewall rule priority supported by GCP.
+	MinFirewallPriority int32 = 0
+	// MaxFirewallPriority is the lowest firewall rule priority supported by GCP.
+	MaxFirewallPriority int32 = 65535
+	// DefaultFirewallPriority is the priority of the created firewall rules that allow traffic.
+	DefaultFirewallPriority int32 = 1000
+	// DenyAllEgressFirewallPriority is the priority of the created firewall rule that denies all egress traffic.
+	// It is chosen so that user-managed allow rules with the default priority take precedence.
+	DenyAllEgressFirewallPriority int32 = 65534
+	// MaxRoutePriority is the lowest route priority supported by GCP.
+	MaxRoutePriority int32 = 65535
+)
+
+const (
+	// ReservedSubnetAddresses is the number of addresses GCP reserves in the primary range of every subnet.
+	ReservedSubnetAddresses = 4
+	// DefaultMinInternalSubnetAddresses is the default minimum number of addresses the internal subnet has to
+	// provide for internal load balancers.
+	DefaultMinInternalSubnetAddresses = 16
+	// MaxSecondaryRangesPerSubnet is the maximum number of secondary ranges GCP supports per subnet.
+	MaxSecondaryRangesPerSubnet = 30
+)
+
+// RoutingMode is the dynamic routing mode of a VPC.
+type RoutingMode string
+
+const (
+	// RoutingModeRegional is a RoutingMode in which routes are only advertised within their region.
+	RoutingModeRegional RoutingMode = "REGIONAL"
+	// RoutingModeGlobal is a RoutingMode in which routes are advertised across all regions.
+	RoutingModeGlobal RoutingMode = "GLOBAL"
+)
+
+// RegionalCIDR is a subnet CIDR that is requested in a region.
+type RegionalCIDR struct {
+	// Region is the region of the subnet.
+	Region string
+	// CIDR is the IP range of the subnet.
+	CIDR string
+}
+
+// cidrsOverlap checks whether the two given networks share any address.
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// cidrIsProperSubset checks whether the network inner is contained in but smaller than the network outer.
+func cidrIsProperSubset(inner, outer *net.IPNet) bool {
+	innerOnes, _ := inner.Mask.Size()
+	outerOnes, _ := outer.Mask.Size()
+	return outer.Contains(inner.IP) && innerOnes > outerOnes
+}
+
+// ReservedSubnetCIDRs are the ranges GCP prohibits for subnets, e.g. the link-local range of the metadata server.
+var ReservedSubnetCIDRs = []gardencorev1alpha1.CIDR{
+	"0.0.0.0/8",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"224.0.0.0/4",
+	"255.255.255.255/32",
+}
+
+// ValidateWorkerSubnet validates that the worker CIDR of the nodes subnet of the given NetworkConfig is set. The
+// nodes subnet is always created, and the other subnets, e.g. the internal one, cannot be requested without it.
+func ValidateWorkerSubnet(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.Worker != "" {
+		return allErrs
+	}
+
+	detail := "must specify the CIDR of the nodes subnet"
+	if networks.Internal != nil {
+		detail += ", the internal subnet cannot be the only subnet"
+	}
+	allErrs = append(allErrs, field.Required(fldPath.Child("worker"), detail))
+
+	return allErrs
+}
+
+// ValidateReservedCIDRs validates that neither the worker nor the internal CIDR of the given NetworkConfig
+// overlaps with any of the ReservedSubnetCIDRs.
+func ValidateReservedCIDRs(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	reserved := make([]namedCIDR, 0, len(ReservedSubnetCIDRs))
+	for i := range ReservedSubnetCIDRs {
+		reserved = append(reserved, namedCIDR{"reserved", &ReservedSubnetCIDRs[i]})
+	}
+
+	allErrs = append(allErrs, validateCIDRDisjoint(networks.Worker, fldPath.Child("worker"), reserved)...)
+	if networks.Internal != nil {
+		allErrs = append(allErrs, validateCIDRDisjoint(*networks.Internal, fldPath.Child("internal"), reserved)...)
+	}
+
+	return allErrs
+}
+
+// ValidateSubnetIPFamilies validates that the CIDRs of all subnets of the given NetworkConfig are IPv4 CIDRs
+// unless dualStack is set, in which case IPv6 CIDRs are accepted as well. CIDRs that cannot be parsed are skipped.
+func ValidateSubnetIPFamilies(networks *gcpv1alpha1.NetworkConfig, dualStack bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if dualStack {
+		return allErrs
+	}
+
+	validateIPv4 := func(cidr *gardencorev1alpha1.CIDR, path *field.Path) {
+		if cidr == nil {
+			return
+		}
+		if ip, _, err := net.ParseCIDR(string(*cidr)); err == nil && ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(path, *cidr, "must be an IPv4 CIDR unless dual-stack is enabled"))
+		}
+	}
+
+	validateIPv4(&networks.Worker, fldPath.Child("worker"))
+	validateIPv4(networks.Internal, fldPath.Child("internal"))
+	if networks.ProxyOnly != nil {
+		validateIPv4(&networks.ProxyOnly.CIDR, fldPath.Child("proxyOnly", "cidr"))
+	}
+	validateIPv4(networks.PrivateServiceConnect, fldPath.Child("privateServiceConnect"))
+	if networks.DisasterRecovery != nil {
+		validateIPv4(&networks.DisasterRecovery.CIDR, fldPath.Child("disasterRecovery", "cidr"))
+	}
+	if networks.EgressAppliance != nil {
+		validateIPv4(&networks.EgressAppliance.CIDR, fldPath.Child("egressAppliance", "cidr"))
+	}
+
+	return allErrs
+}
+
+// ValidateInternalCIDRDisjoint validates that the internal CIDR of the given NetworkConfig does not overlap with the
+// worker CIDR, as GCP rejects overlapping subnets in a VPC.
+func ValidateInternalCIDRDisjoint(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	if networks.Internal == nil {
+		return field.ErrorList{}
+	}
+	return validateCIDRDisjoint(*networks.Internal, fldPath.Child("internal"), []namedCIDR{{"worker", &networks.Worker}})
+}
+
+// ValidateCloudNAT validates the Cloud NAT configuration of the given NetworkConfig. Cloud NAT requires a Cloud
+// Router, which is only created along with the VPC. Hence, if an existing VPC is used, the name of an existing
+// Cloud Router of that VPC has to be specified.
+func ValidateCloudNAT(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.CloudNAT == nil || networks.VPC == nil {
+		return allErrs
+	}
+
+	if networks.VPC.CloudRouter == nil || networks.VPC.CloudRouter.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("vpc", "cloudRouter", "name"), fmt.Sprintf("the name of an existing Cloud Router of VPC '%s' must be specified to enable Cloud NAT", networks.VPC.Name)))
+	}
+
+	return allErrs
+}
+
+// CloudNATSubnetPurposes are the purposes of the subnets a Cloud NAT can be applied to.
+var CloudNATSubnetPurposes = []string{
+	string(gcpv1alpha1.PurposeNodes),
+	string(gcpv1alpha1.PurposeInternal),
+}
+
+// ValidateCloudNATSubnets validates the subnets the Cloud NAT of the given NetworkConfig is applied to. Each
+// purpose must be supported and selected at most once, and the internal subnet can only be selected if it is
+// configured.
+func ValidateCloudNATSubnets(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.CloudNAT == nil {
+		return allErrs
+	}
+
+	var (
+		supported = sets.NewString(CloudNATSubnetPurposes...)
+		seen      = sets.NewString()
+	)
+	for i, purpose := range networks.CloudNAT.Subnets {
+		idxPath := fldPath.Index(i)
+		switch {
+		case !supported.Has(string(purpose)):
+			allErrs = append(allErrs, field.NotSupported(idxPath, purpose, CloudNATSubnetPurposes))
+		case seen.Has(string(purpose)):
+			allErrs = append(allErrs, field.Duplicate(idxPath, purpose))
+		case purpose == gcpv1alpha1.PurposeInternal && networks.Internal == nil:
+			allErrs = append(allErrs, field.Invalid(idxPath, purpose, "the internal subnet is not configured"))
+		}
+		seen.Insert(string(purpose))
+	}
+
+	return allErrs
+}
+
+// ValidateCloudNATGateways validates the additional NAT gateways of the Cloud NAT of the given NetworkConfig. Their
+// names must be unique, and as GCP translates the traffic of a subnet by a single gateway only, the subnets of all
+// gateways, including the ones selected for the Cloud NAT itself, must be disjoint. Hence, the latter have to be
+// selected explicitly if gateways are set.
+func ValidateCloudNATGateways(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.CloudNAT == nil || len(networks.CloudNAT.Gateways) == 0 {
+		return allErrs
+	}
+
+	if len(networks.CloudNAT.Subnets) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("subnets"), "must be set if gateways are set"))
+	}
+
+	var (
+		supported = sets.NewString(CloudNATSubnetPurposes...)
+		claimed   = sets.NewString()
+		names     = sets.NewString()
+	)
+	for _, purpose := range networks.CloudNAT.Subnets {
+		claimed.Insert(string(purpose))
+	}
+	for i, gateway := range networks.CloudNAT.Gateways {
+		gatewayPath := fldPath.Child("gateways").Index(i)
+
+		if !routeNameRegex.MatchString(gateway.Name) {
+			allErrs = append(allErrs, field.Invalid(gatewayPath.Child("name"), gateway.Name, "must consist of lowercase letters, digits and '-' and start and end with a letter or digit"))
+		} else if names.Has(gateway.Name) {
+			allErrs = append(allErrs, field.Duplicate(gatewayPath.Child("name"), gateway.Name))
+		}
+		names.Insert(gateway.Name)
+
+		if len(gateway.Subnets) == 0 {
+			allErrs = append(allErrs, field.Required(gatewayPath.Child("subnets"), "at least one subnet has to be selected"))
+		}
+		for j, purpose := range gateway.Subnets {
+			idxPath := gatewayPath.Child("subnets").Index(j)
+			switch {
+			case !supported.Has(string(purpose)):
+				allErrs = append(allErrs, field.NotSupported(idxPath, purpose, CloudNATSubnetPurposes))
+			case claimed.Has(string(purpose)):
+				allErrs = append(allErrs, field.Invalid(idxPath, purpose, "the subnet is already translated by another NAT gateway"))
+			case purpose == gcpv1alpha1.PurposeInternal && networks.Internal == nil:
+				allErrs = append(allErrs, field.Invalid(idxPath, purpose, "the internal subnet is not configured"))
+			case purpose == gcpv1alpha1.PurposeInternal && networks.InternalProxyOnly:
+				allErrs = append(allErrs, field.Forbidden(idxPath, "the internal subnet is proxy-only"))
+			}
+			claimed.Insert(string(purpose))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateVPCSource validates that the given NetworkConfig specifies the VPC unambiguously: Either an existing VPC
+// is referenced by its name, or the VPC is created and may then be configured by the settings only applicable to
+// created VPCs, i.e. its MTU, description and control plane peering.
+func ValidateVPCSource(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.VPC == nil {
+		return allErrs
+	}
+
+	if networks.VPC.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("vpc", "name"), "must name the existing VPC, networks.vpc has to be omitted to create the VPC"))
+	}
+	if networks.MTU != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("mtu"), "can only be set if the VPC is created"))
+	}
+	if networks.VPCDescription != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("vpcDescription"), "can only be set if the VPC is created"))
+	}
+	if networks.ControlPlanePeering != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("controlPlanePeering"), "can only be set if the VPC is created"))
+	}
+
+	return allErrs
+}
+
+// ValidateFeatureCoherence validates the constraints between the features of the given InfrastructureConfig, e.g.
+// that features only applicable to created resources are not combined with existing ones. The features themselves
+// are validated separately.
+func ValidateFeatureCoherence(config *gcpv1alpha1.InfrastructureConfig) field.ErrorList {
+	var (
+		allErrs      = field.ErrorList{}
+		networks     = &config.Networks
+		networksPath = field.NewPath("networks")
+	)
+
+	allErrs = append(allErrs, ValidateInternalSubnetRequired(networks, networksPath)...)
+	if networks.NetworkingMode != nil && *networks.NetworkingMode == gcpv1alpha1.NetworkingModeCilium && networks.AliasIPs != nil {
+		allErrs = append(allErrs, field.Forbidden(networksPath.Child("networkingMode"), "the Cilium layout is mutually exclusive with alias IPs"))
+	}
+
+	allErrs = append(allErrs, ValidateVPCSource(networks, networksPath)...)
+	allErrs = append(allErrs, ValidateCloudNAT(networks, networksPath)...)
+	allErrs = append(allErrs, ValidateCloudNATSubnets(networks, networksPath.Child("cloudNAT", "subnets"))...)
+	allErrs = append(allErrs, ValidateCloudNATGateways(networks, networksPath.Child("cloudNAT"))...)
+
+	if !createServiceAccount(config) {
+		if len(config.ServiceAccount.WorkloadIdentityUsers) > 0 {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("serviceAccount", "workloadIdentityUsers"), "can only be set if the service account is created"))
+		}
+		if config.ServiceAccount.Project != nil {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("serviceAccount", "project"), "can only be set if the service account is created"))
+		}
+	} else if config.ServiceAccount != nil && config.ServiceAccount.Email != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("serviceAccount", "email"), "can only be set if no service account is created"))
+	}
+
+	return allErrs
+}
+
+// ValidateInternalSubnetRequired validates that the internal subnet of the given NetworkConfig is configured if a
+// feature requiring it is enabled, i.e. if its purpose label for internal load balancers is customized or if it is
+// proxy-only.
+func ValidateInternalSubnetRequired(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.Internal != nil {
+		return allErrs
+	}
+
+	var features []string
+	if networks.InternalPurpose != nil {
+		features = append(features, fldPath.Child("internalPurpose").String())
+	}
+	if networks.InternalProxyOnly {
+		features = append(features, fldPath.Child("internalProxyOnly").String())
+	}
+	if len(features) > 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("internal"), fmt.Sprintf("an internal subnet is required by %s", strings.Join(features, ", "))))
+	}
+
+	return allErrs
+}
+
+// ValidateCloudNATPorts validates the port allocation of the given CloudNAT. The numbers of ports have to be
+// within [MinNATPortsPerVM, MaxNATPortsPerVM], the minimum has to be a power of two and must not exceed the
+// maximum, and a maximum can only be set with dynamic port allocation.
+func ValidateCloudNATPorts(cloudNAT *gcpv1alpha1.CloudNAT, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cloudNAT == nil {
+		return allErrs
+	}
+
+	minPath, maxPath := fldPath.Child("minPortsPerVM"), fldPath.Child("maxPortsPerVM")
+	for _, ports := range []struct {
+		path  *field.Path
+		value *int32
+	}{
+		{minPath, cloudNAT.MinPortsPerVM},
+		{maxPath, cloudNAT.MaxPortsPerVM},
+	} {
+		if ports.value != nil && (*ports.value < MinNATPortsPerVM || *ports.value > MaxNATPortsPerVM) {
+			allErrs = append(allErrs, field.Invalid(ports.path, *ports.value, fmt.Sprintf("must be between %d and %d", MinNATPortsPerVM, MaxNATPortsPerVM)))
+		}
+	}
+
+	dynamicPortAllocation := cloudNAT.EnableDynamicPortAllocation != nil && *cloudNAT.EnableDynamicPortAllocation
+	if cloudNAT.MaxPortsPerVM != nil && !dynamicPortAllocation {
+		allErrs = append(allErrs, field.Forbidden(maxPath, "can only be set if dynamic port allocation is enabled"))
+	}
+	if cloudNAT.MinPortsPerVM != nil && cloudNAT.MaxPortsPerVM != nil && *cloudNAT.MinPortsPerVM > *cloudNAT.MaxPortsPerVM {
+		allErrs = append(allErrs, field.Invalid(minPath, *cloudNAT.MinPortsPerVM, fmt.Sprintf("must not be greater than maxPortsPerVM %d", *cloudNAT.MaxPortsPerVM)))
+	}
+	if min := cloudNAT.MinPortsPerVM; min != nil && *min > 0 && *min&(*min-1) != 0 {
+		allErrs = append(allErrs, field.Invalid(minPath, *min, "must be a power of two"))
+	}
+
+	return allErrs
+}
+
+// ValidateCloudNATIPCounts validates the numbers of external IPs reserved for the NAT gateways of the given
+// CloudNAT. They have to be within [1, MaxNATIPCount].
+func ValidateCloudNATIPCounts(cloudNAT *gcpv1alpha1.CloudNAT, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cloudNAT == nil {
+		return allErrs
+	}
+
+	validate := func(ipCount *int32, fldPath *field.Path) {
+		if ipCount != nil && (*ipCount < 1 || *ipCount > MaxNATIPCount) {
+			allErrs = append(allErrs, field.Invalid(fldPath, *ipCount, fmt.Sprintf("must be between 1 and %d", MaxNATIPCount)))
+		}
+	}
+	validate(cloudNAT.IPCount, fldPath.Child("ipCount"))
+	for i, gateway := range cloudNAT.Gateways {
+		validate(gateway.IPCount, fldPath.Child("gateways").Index(i).Child("ipCount"))
+	}
+
+	return allErrs
+}
+
+// NetworkTiers are the supported network tiers of the external IPs of a Cloud NAT.
+var NetworkTiers = []string{
+	string(gcpv1alpha1.NetworkTierPremium),
+	string(gcpv1alpha1.NetworkTierStandard),
+}
+
+// ValidateCloudNATNetworkTier validates the network tier of the external IPs of the given CloudNAT, which has to be
+// one of NetworkTiers.
+func ValidateCloudNATNetworkTier(cloudNAT *gcpv1alpha1.CloudNAT, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cloudNAT == nil || cloudNAT.NetworkTier == nil {
+		return allErrs
+	}
+
+	if tier := *cloudNAT.NetworkTier; !sets.NewString(NetworkTiers...).Has(string(tier)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("networkTier"), tier, NetworkTiers))
+	}
+
+	return allErrs
+}
+
+// ValidateWorkloadIdentityUsers validates the workload identity users of the given ServiceAccountConfig. They
+// have to reference distinct Kubernetes service accounts in the form <namespace>/<name>.
+func ValidateWorkloadIdentityUsers(serviceAccount *gcpv1alpha1.ServiceAccountConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if serviceAccount == nil || len(serviceAccount.WorkloadIdentityUsers) == 0 {
+		return allErrs
+	}
+
+	usersPath := fldPath.Child("workloadIdentityUsers")
+	users := sets.NewString()
+	for i, user := range serviceAccount.WorkloadIdentityUsers {
+		userPath := usersPath.Index(i)
+		if users.Has(user) {
+			allErrs = append(allErrs, field.Duplicate(userPath, user))
+			continue
+		}
+		users.Insert(user)
+
+		parts := strings.Split(user, "/")
+		if len(parts) != 2 {
+			allErrs = append(allErrs, field.Invalid(userPath, user, "must be of the form <namespace>/<name>"))
+			continue
+		}
+		for _, msg := range validation.IsDNS1123Label(parts[0]) {
+			allErrs = append(allErrs, field.Invalid(userPath, user, fmt.Sprintf("invalid namespace: %s", msg)))
+		}
+		for _, msg := range validation.IsDNS1123Subdomain(parts[1]) {
+			allErrs = append(allErrs, field.Invalid(userPath, user, fmt.Sprintf("invalid name: %s", msg)))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateFlowLogs validates the given FlowLogs. The aggregation interval has to be one of
+// FlowLogsAggregationIntervals and the export destination has to be a Cloud Storage bucket, a BigQuery dataset,
+// a Pub/Sub topic or a Cloud Logging bucket in the format of a logging sink destination. Partitioned tables can
+// only be used for a BigQuery dataset.
+func ValidateFlowLogs(flowLogs *gcpv1alpha1.FlowLogs, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if flowLogs == nil {
+		return allErrs
+	}
+
+	if interval := flowLogs.AggregationInterval; interval != nil && !sets.NewString(FlowLogsAggregationIntervals...).Has(*interval) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("aggregationInterval"), *interval, FlowLogsAggregationIntervals))
+	}
+	if destination := flowLogs.ExportDestination; destination != nil && !isFlowLogsExportDestination(*destination) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("exportDestination"), *destination, "must be one of storage.googleapis.com/<bucket>, bigquery.googleapis.com/projects/<project>/datasets/<dataset>, pubsub.googleapis.com/projects/<project>/topics/<topic> or logging.googleapis.com/projects/<project>/locations/<location>/buckets/<bucket>"))
+	}
+	if flowLogs.BigQueryPartitionedTables && (flowLogs.ExportDestination == nil || !flowLogsBigQueryDestinationRegex.MatchString(*flowLogs.ExportDestination)) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("bigQueryPartitionedTables"), "can only be set if the export destination is a BigQuery dataset"))
+	}
+
+	return allErrs
+}
+
+// ValidateDefaultFlowLogsAggregationInterval validates the default aggregation interval of flow logs of the
+// provider. It has to be empty or one of FlowLogsAggregationIntervals.
+func ValidateDefaultFlowLogsAggregationInterval(interval string) error {
+	if interval != "" && !sets.NewString(FlowLogsAggregationIntervals...).Has(interval) {
+		return fmt.Errorf("default flow logs aggregation interval %q must be one of %v", interval, FlowLogsAggregationIntervals)
+	}
+	return nil
+}
+
+// ValidateFlowLogsExportFilters validates the export filters of the flow logs of the given NetworkConfig. Each
+// filter is validated independently: it has to be set for the nodes or the internal subnet only, must not be empty
+// and its parentheses and quotes have to be balanced, as it is combined with the filter selecting the flow logs of
+// the subnet. The flow logs of the internal subnet can only be exported if it exists and is not proxy-only.
+func ValidateFlowLogsExportFilters(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.FlowLogs == nil || len(networks.FlowLogs.ExportFilters) == 0 {
+		return allErrs
+	}
+
+	filtersPath := fldPath.Child("flowLogs", "exportFilters")
+	if networks.FlowLogs.ExportDestination == nil {
+		allErrs = append(allErrs, field.Forbidden(filtersPath, "can only be set if the export destination is set"))
+	}
+
+	purposes := make([]gcpv1alpha1.SubnetPurpose, 0, len(networks.FlowLogs.ExportFilters))
+	for purpose := range networks.FlowLogs.ExportFilters {
+		purposes = append(purposes, purpose)
+	}
+	sortSubnetPurposes(purposes)
+
+	for _, purpose := range purposes {
+		filter, filterPath := networks.FlowLogs.ExportFilters[purpose], filtersPath.Key(string(purpose))
+
+		switch purpose {
+		case gcpv1alpha1.PurposeNodes:
+		case gcpv1alpha1.PurposeInternal:
+			if networks.Internal == nil {
+				allErrs = append(allErrs, field.Forbidden(filterPath, "can only be set if the internal subnet is set"))
+			} else if networks.InternalProxyOnly {
+				allErrs = append(allErrs, field.Forbidden(filterPath, "cannot be set if the internal subnet is proxy-only"))
+			}
+		default:
+			allErrs = append(allErrs, field.NotSupported(filterPath, string(purpose), []string{string(gcpv1alpha1.PurposeNodes), string(gcpv1alpha1.PurposeInternal)}))
+			continue
+		}
+
+		if strings.TrimSpace(filter) == "" {
+			allErrs = append(allErrs, field.Invalid(filterPath, filter, "must not be empty"))
+		} else if !isBalancedLoggingFilter(filter) {
+			allErrs = append(allErrs, field.Invalid(filterPath, filter, "must have balanced parentheses and quotes"))
+		}
+	}
+
+	return allErrs
+}
+
+// isBalancedLoggingFilter checks whether the parentheses outside of quoted strings and the quotes of the given
+// logging filter are balanced.
+func isBalancedLoggingFilter(filter string) bool {
+	var (
+		depth   int
+		quoted  bool
+		escaped bool
+	)
+
+	for _, r := range filter {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case !quoted && r == '(':
+			depth++
+		case !quoted && r == ')':
+			if depth--; depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0 && !quoted
+}
+
+// isFlowLogsExportDestination checks whether the given destination is a supported logging sink destination.
+func isFlowLogsExportDestination(destination string) bool {
+	for _, regex := range flowLogsExportDestinationRegexes {
+		if regex.MatchString(destination) {
+			return true
+		}
+	}
+	return false
+}
+
+// cloudProfileRegions returns the regions of the zones of the given CloudProfile.
+func cloudProfileRegions(profile *gardenv1beta1.CloudProfile) sets.String {
+	regions := sets.NewString()
+	if profile == nil || profile.Spec.GCP == nil {
+		return regions
+	}
+
+	for _, zone := range profile.Spec.GCP.Constraints.Zones {
+		regions.Insert(zone.Region)
+	}
+	return regions
+}
+
+// ValidateAgainstCloudProfile validates that the given region is permitted by the zone constraints of the given
+// CloudProfile. The InfrastructureConfig itself does not specify a region, it is taken from the Infrastructure.
+// A CloudProfile without zone constraints, or no CloudProfile at all, permits every region.
+func ValidateAgainstCloudProfile(region string, profile *gardenv1beta1.CloudProfile, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if regions := cloudProfileRegions(profile); regions.Len() > 0 && !regions.Has(region) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("region"), region, regions.List()))
+	}
+
+	return allErrs
+}
+
+// ValidateMTU validates the MTU of the given NetworkConfig. It has to be within DefaultMTURange as well as within
+// the given allowed range, which operators can narrow down.
+func ValidateMTU(networks *gcpv1alpha1.NetworkConfig, allowed MTURange, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.MTU == nil {
+		return allErrs
+	}
+
+	mtuPath := fldPath.Child("mtu")
+	mtu := *networks.MTU
+	for _, mtuRange := range []MTURange{DefaultMTURange, allowed} {
+		if !mtuRange.Contains(mtu) {
+			allErrs = append(allErrs, field.Invalid(mtuPath, mtu, fmt.Sprintf("must be between %d and %d", mtuRange.Min, mtuRange.Max)))
+			break
+		}
+	}
+
+	return allErrs
+}
+
+// firewallPriorities returns the priorities of the firewall rules created for the given FirewallConfig, offset by
+// its priority base.
+func firewallPriorities(firewall *gcpv1alpha1.FirewallConfig) (allow, denyAllEgress int32) {
+	allow, denyAllEgress = DefaultFirewallPriority, DenyAllEgressFirewallPriority
+	if firewall != nil && firewall.PriorityBase != nil {
+		allow += *firewall.PriorityBase
+		denyAllEgress += *firewall.PriorityBase
+	}
+	return allow, denyAllEgress
+}
+
+// ValidateUnmanagedFirewall validates that none of the options of the given FirewallConfig affecting the created
+// firewall rules is set if the firewall is unmanaged, as they would silently have no effect.
+func ValidateUnmanagedFirewall(firewall *gcpv1alpha1.FirewallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if firewall == nil || !firewall.Unmanaged {
+		return allErrs
+	}
+
+	if firewall.DenyAllEgress {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("denyAllEgress"), "cannot be set if the firewall is unmanaged"))
+	}
+	if firewall.DisableAllowInternalAccess {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("disableAllowInternalAccess"), "cannot be set if the firewall is unmanaged"))
+	}
+	if firewall.DisableAllowHealthChecks {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("disableAllowHealthChecks"), "cannot be set if the firewall is unmanaged"))
+	}
+	if firewall.PriorityBase != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("priorityBase"), "cannot be set if the firewall is unmanaged"))
+	}
+
+	return allErrs
+}
+
+// ValidateFirewallPriorities validates that the priorities of the firewall rules created for the given
+// FirewallConfig are within the range supported by GCP once offset by its priority base. The priority of the
+// rule denying all egress traffic is only checked if the rule is created.
+func ValidateFirewallPriorities(firewall *gcpv1alpha1.FirewallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if firewall == nil || firewall.PriorityBase == nil {
+		return allErrs
+	}
+
+	basePath := fldPath.Child("priorityBase")
+	base := *firewall.PriorityBase
+	allow, denyAllEgress := firewallPriorities(firewall)
+	priorities := []int32{allow}
+	if firewall.DenyAllEgress {
+		priorities = append(priorities, denyAllEgress)
+	}
+	for _, priority := range priorities {
+		if priority < MinFirewallPriority || priority > MaxFirewallPriority {
+			allErrs = append(allErrs, field.Invalid(basePath, base, fmt.Sprintf("resulting priority %d must be between %d and %d", priority, MinFirewallPriority, MaxFirewallPriority)))
+			break
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateCloudRouterASN validates that the given BGP ASN of a Cloud Router is within one of the PrivateASNRanges.
+func ValidateCloudRouterASN(asn int64, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, r := range PrivateASNRanges {
+		if r.Contains(asn) {
+			return allErrs
+		}
+	}
+
+	ranges := make([]string, 0, len(PrivateASNRanges))
+	for _, r := range PrivateASNRanges {
+		ranges = append(ranges, fmt.Sprintf("%d-%d", r.Min, r.Max))
+	}
+	return append(allErrs, field.Invalid(fldPath, asn, fmt.Sprintf("must be within the private ASN ranges %s", strings.Join(ranges, ", "))))
+}
+
+// CloudRouterAdvertiseModes are the supported advertise modes of a Cloud Router.
+var CloudRouterAdvertiseModes = []string{
+	string(gcpv1alpha1.BGPAdvertiseModeDefault),
+	string(gcpv1alpha1.BGPAdvertiseModeCustom),
+}
+
+// ValidateCloudRouterBGP validates the BGP configuration of the Cloud Router of the given NetworkConfig. It can only
+// be set if the Cloud Router is created, i.e. if Cloud NAT is enabled for a created VPC, and its ASN has to be
+// private. The advertised subnets and ranges can only be configured, and at least one of them has to be, if the
+// advertise mode is custom. The advertised ranges have to be unique CIDRs.
+func ValidateCloudRouterBGP(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	bgp := networks.CloudRouterBGP
+	if bgp == nil {
+		return allErrs
+	}
+
+	if networks.VPC != nil || networks.CloudNAT == nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "can only be set if the Cloud Router is created, i.e. if Cloud NAT is enabled for a created VPC"))
+	}
+	allErrs = append(allErrs, ValidateCloudRouterASN(bgp.ASN, fldPath.Child("asn"))...)
+
+	rangesPath := fldPath.Child("advertisedIPRanges")
+	switch mode := cloudRouterAdvertiseMode(bgp); mode {
+	case gcpv1alpha1.BGPAdvertiseModeDefault:
+		if bgp.AdvertiseAllSubnets {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("advertiseAllSubnets"), fmt.Sprintf("can only be set if the advertise mode is %s", gcpv1alpha1.BGPAdvertiseModeCustom)))
+		}
+		if len(bgp.AdvertisedIPRanges) > 0 {
+			allErrs = append(allErrs, field.Forbidden(rangesPath, fmt.Sprintf("can only be set if the advertise mode is %s", gcpv1alpha1.BGPAdvertiseModeCustom)))
+		}
+	case gcpv1alpha1.BGPAdvertiseModeCustom:
+		if !bgp.AdvertiseAllSubnets && len(bgp.AdvertisedIPRanges) == 0 {
+			allErrs = append(allErrs, field.Required(rangesPath, "the custom advertise mode requires advertised ranges unless all subnets are advertised"))
+		}
+		ranges := sets.NewString()
+		for i, r := range bgp.AdvertisedIPRanges {
+			_, network, err := net.ParseCIDR(string(r))
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(rangesPath.Index(i), r, err.Error()))
+				continue
+			}
+			if ranges.Has(network.String()) {
+				allErrs = append(allErrs, field.Duplicate(rangesPath.Index(i), r))
+			}
+			ranges.Insert(network.String())
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("advertiseMode"), mode, CloudRouterAdvertiseModes))
+	}
+
+	return allErrs
+}
+
+// ValidateInternalSubnetCapacity validates that the internal subnet of the given NetworkConfig provides at least
+// the given number of addresses for internal load balancers, i.e. apart from the ones GCP reserves.
+//
+// It must only be enforced when an infrastructure is created, as existing internal subnets may be smaller.
+func ValidateInternalSubnetCapacity(networks *gcpv1alpha1.NetworkConfig, minAddresses int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.Internal == nil {
+		return allErrs
+	}
+
+	internalPath := fldPath.Child("internal")
+	_, network, err := net.ParseCIDR(string(*networks.Internal))
+	if err != nil {
+		return append(allErrs, field.Invalid(internalPath, *networks.Internal, err.Error()))
+	}
+
+	ones, bits := network.Mask.Size()
+	if hostBits := uint(bits - ones); hostBits < 31 {
+		if addresses := 1<<hostBits - ReservedSubnetAddresses; addresses < minAddresses {
+			allErrs = append(allErrs, field.Invalid(internalPath, *networks.Internal, fmt.Sprintf("provides %d addresses for internal load balancers, at least %d are required", addresses, minAddresses)))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateInternalPurpose validates the purpose label of the internal subnet of the given NetworkConfig. It must
+// not be empty if it is set, and it must not be one of AllSubnetPurposes, which would make the internal subnet
+// indistinguishable from the subnet of that purpose in the status.
+func ValidateInternalPurpose(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.InternalPurpose == nil {
+		return allErrs
+	}
+
+	purpose := *networks.InternalPurpose
+	if len(purpose) == 0 {
+		return append(allErrs, field.Required(fldPath.Child("internalPurpose"), "must not be empty if set"))
+	}
+	for _, reserved := range AllSubnetPurposes() {
+		if purpose == reserved {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("internalPurpose"), purpose, "must not be a reserved subnet purpose"))
+			break
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateSubnetPurposePrefix validates the prefix of the subnet purposes of the given NetworkConfig. It must not
+// be empty if it is set.
+func ValidateSubnetPurposePrefix(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.SubnetPurposePrefix != nil && len(*networks.SubnetPurposePrefix) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("subnetPurposePrefix"), "must not be empty if set"))
+	}
+
+	return allErrs
+}
+
+// ValidateTerraformIdentifier validates that the given name is a legal terraform identifier, i.e. that it starts
+// with a letter or an underscore and only contains letters, digits, underscores and dashes.
+func ValidateTerraformIdentifier(name string) error {
+	if !terraformIdentifierRegex.MatchString(name) {
+		return fmt.Errorf("%q is not a legal terraform identifier: it has to start with a letter or an underscore and may only contain letters, digits, underscores and dashes", name)
+	}
+	return nil
+}
+
+// ReservedSecondaryRangeNamePrefixes are the prefixes of the secondary range names GKE uses for the ranges it
+// manages, ranges with such names conflict with them.
+var ReservedSecondaryRangeNamePrefixes = []string{
+	"gke-",
+}
+
+// ValidateSecondaryRangeNames validates that none of the given names of the secondary ranges requested per subnet
+// purpose starts with one of the ReservedSecondaryRangeNamePrefixes.
+func ValidateSecondaryRangeNames(ranges map[gcpv1alpha1.SubnetPurpose][]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	purposes := make([]string, 0, len(ranges))
+	for purpose := range ranges {
+		purposes = append(purposes, string(purpose))
+	}
+	sort.Strings(purposes)
+
+	for _, purpose := range purposes {
+		for i, name := range ranges[gcpv1alpha1.SubnetPurpose(purpose)] {
+			for _, prefix := range ReservedSecondaryRangeNamePrefixes {
+				if strings.HasPrefix(name, prefix) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Key(purpose).Index(i), name, fmt.Sprintf("must not start with %q, which is reserved for the secondary ranges managed by GKE", prefix)))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateSecondaryRangeCount validates that the given names of the secondary ranges requested per subnet purpose
+// do not exceed MaxSecondaryRangesPerSubnet, listing the excess ranges otherwise.
+func ValidateSecondaryRangeCount(ranges map[gcpv1alpha1.SubnetPurpose][]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	purposes := make([]string, 0, len(ranges))
+	for purpose := range ranges {
+		purposes = append(purposes, string(purpose))
+	}
+	sort.Strings(purposes)
+
+	for _, purpose := range purposes {
+		names := ranges[gcpv1alpha1.SubnetPurpose(purpose)]
+		if len(names) <= MaxSecondaryRangesPerSubnet {
+			continue
+		}
+		allErrs = append(allErrs, field.Invalid(fldPath.Key(purpose), len(names), fmt.Sprintf("must not exceed %d secondary ranges, excess ranges: %s", MaxSecondaryRangesPerSubnet, strings.Join(names[MaxSecondaryRangesPerSubnet:], ", "))))
+	}
+
+	return allErrs
+}
+
+// ValidateSubnetNames validates that the given subnets have one of AllSubnetPurposes and that their names are
+// unique across all purposes, as subnets sharing a name conflict with each other when being applied.
+func ValidateSubnetNames(subnets []gcpv1alpha1.Subnet, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	validPurposes := sets.NewString()
+	for _, purpose := range AllSubnetPurposes() {
+		validPurposes.Insert(string(purpose))
+	}
+
+	purposesByName := make(map[string]gcpv1alpha1.SubnetPurpose, len(subnets))
+	for i, subnet := range subnets {
+		if !validPurposes.Has(string(subnet.Purpose)) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Index(i).Child("purpose"), subnet.Purpose, validPurposes.List()))
+		}
+		if purpose, ok := purposesByName[subnet.Name]; ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("name"), subnet.Name, fmt.Sprintf("the %s subnet has the same name as the %s subnet", subnet.Purpose, purpose)))
+			continue
+		}
+		purposesByName[subnet.Name] = subnet.Purpose
+	}
+
+	return allErrs
+}
+
+// ReservedVPCNames are the network names a configured VPC must not use, e.g. the name of the auto-mode network GCP
+// creates in new projects.
+var ReservedVPCNames = []string{
+	"default",
+}
+
+// ValidateVPCName validates that the given user-supplied name of a VPC is none of the ReservedVPCNames.
+func ValidateVPCName(name string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, reserved := range ReservedVPCNames {
+		if name == reserved {
+			allErrs = append(allErrs, field.Invalid(fldPath, name, fmt.Sprintf("%q is reserved and collides with the default network of GCP projects", reserved)))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateStateRefresh validates that the refresh of the terraform state is only disabled if the status computed
+// from it cannot become stale. The NAT IPs of a Cloud NAT are allocated by GCP, hence they are only kept up to
+// date by refreshing the state.
+func ValidateStateRefresh(stateRefresh *bool, networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if stateRefresh == nil || *stateRefresh {
+		return allErrs
+	}
+
+	if networks.CloudNAT != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cannot be disabled if a Cloud NAT is configured, as its NAT IPs in the status would become stale"))
+	}
+
+	return allErrs
+}
+
+// ValidateTimeouts validates that all given timeouts of the google terraform provider are positive.
+func ValidateTimeouts(timeouts *gcpv1alpha1.Timeouts, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if timeouts == nil {
+		return allErrs
+	}
+
+	byName := map[string]*metav1.Duration{
+		"request": timeouts.Request,
+		"create":  timeouts.Create,
+		"update":  timeouts.Update,
+		"delete":  timeouts.Delete,
+	}
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if timeout := byName[name]; timeout != nil && timeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(name), timeout.Duration.String(), "must be positive"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateProjectID validates that the given project ID is a valid GCP project ID, i.e. that it consists of 6 to 30
+// lowercase letters, digits and '-', starts with a letter and does not end with '-'.
+func ValidateProjectID(projectID string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !projectIDRegex.MatchString(projectID) {
+		allErrs = append(allErrs, field.Invalid(fldPath, projectID, "must be a valid project ID consisting of 6 to 30 lowercase letters, digits and '-', starting with a letter and not ending with '-'"))
+	}
+
+	return allErrs
+}
+
+// ValidateProjectMatch validates that the given project specified in the config matches the given project ID of the
+// credentials. Differing projects are only allowed if allowCrossProject is set. A nil project is always valid.
+func ValidateProjectMatch(project *string, accountProjectID string, allowCrossProject bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if project != nil && *project != accountProjectID && !allowCrossProject {
+		allErrs = append(allErrs, field.Invalid(fldPath, *project, fmt.Sprintf("must match the project %s of the credentials unless cross-project usage is allowed", accountProjectID)))
+	}
+
+	return allErrs
+}
+
+// NormalizeRegion returns the given region in lowercase, as GCP regions are, so that a casing slip does not cause
+// a mismatch. The normalized region has to be a valid region name like europe-west1.
+func NormalizeRegion(region string, fldPath *field.Path) (string, field.ErrorList) {
+	allErrs := field.ErrorList{}
+
+	normalized := strings.ToLower(region)
+	if !regionRegex.MatchString(normalized) {
+		allErrs = append(allErrs, field.Invalid(fldPath, region, "must be a valid region name like europe-west1"))
+		return "", allErrs
+	}
+
+	return normalized, allErrs
+}
+
+// ValidateServiceAccountEmail validates that the given email is the one of a user-managed service account, i.e.
+// <account>@<project>.iam.gserviceaccount.com, or the one of the Compute Engine default service account, i.e.
+// <project-number>-compute@developer.gserviceaccount.com.
+func ValidateServiceAccountEmail(email string) error {
+	if !serviceAccountEmailRegex.MatchString(email) && !computeServiceAccountEmailRegex.MatchString(email) {
+		return fmt.Errorf("service account email %q must have the format <account>@<project>.iam.gserviceaccount.com or <project-number>-compute@developer.gserviceaccount.com", email)
+	}
+	return nil
+}
+
+// ValidateDiskEncryptionKey validates that the given disk encryption key is the resource name of a Cloud KMS key.
+func ValidateDiskEncryptionKey(key string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if key != "" && !kmsKeyRegex.MatchString(key) {
+		allErrs = append(allErrs, field.Invalid(fldPath, key, "must have the format projects/<project>/locations/<location>/keyRings/<key-ring>/cryptoKeys/<key>"))
+	}
+
+	return allErrs
+}
+
+// ValidateAliasIPPrefixLengths validates that the prefix lengths of the given AliasIPs fit within the given pod
+// and service networks, i.e. that they are not shorter than the ones of the networks.
+func ValidateAliasIPPrefixLengths(aliasIPs *gcpv1alpha1.AliasIPs, pods, services *gardencorev1alpha1.CIDR, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if aliasIPs == nil {
+		return allErrs
+	}
+
+	for _, r := range []struct {
+		name         string
+		cidr         *gardencorev1alpha1.CIDR
+		prefixLength *int32
+	}{
+		{"podsPrefixLength", pods, aliasIPs.PodsPrefixLength},
+		{"servicesPrefixLength", services, aliasIPs.ServicesPrefixLength},
+	} {
+		if r.cidr == nil || r.prefixLength == nil {
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(string(*r.cidr))
+		if err != nil {
+			continue
+		}
+		ones, bits := network.Mask.Size()
+		if prefixLength := int(*r.prefixLength); prefixLength < ones || prefixLength > bits {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(r.name), *r.prefixLength, fmt.Sprintf("must be between %d and %d to fit within %s", ones, bits, *r.cidr)))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateNodeCIDRMaskSize validates that the pods secondary range of the given AliasIPs provides a pod CIDR of
+// the node CIDR mask size for every address of the given worker subnet, apart from the ones GCP reserves.
+func ValidateNodeCIDRMaskSize(aliasIPs *gcpv1alpha1.AliasIPs, worker gardencorev1alpha1.CIDR, pods *gardencorev1alpha1.CIDR, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if aliasIPs == nil || aliasIPs.NodeCIDRMaskSize == nil || pods == nil {
+		return allErrs
+	}
+
+	_, podsNetwork, err := net.ParseCIDR(string(*pods))
+	if err != nil {
+		return allErrs
+	}
+	_, workerNetwork, err := net.ParseCIDR(string(worker))
+	if err != nil {
+		return allErrs
+	}
+
+	maskPath := fldPath.Child("nodeCIDRMaskSize")
+	podsPrefixLength, bits := podsNetwork.Mask.Size()
+	if aliasIPs.PodsPrefixLength != nil {
+		podsPrefixLength = int(*aliasIPs.PodsPrefixLength)
+	}
+	maskSize := int(*aliasIPs.NodeCIDRMaskSize)
+	if maskSize < podsPrefixLength || maskSize >= bits {
+		return append(allErrs, field.Invalid(maskPath, maskSize, fmt.Sprintf("must be between %d and %d to fit within the pods secondary range", podsPrefixLength, bits-1)))
+	}
+
+	workerPrefixLength, _ := workerNetwork.Mask.Size()
+	podCIDRs := 1 << uint(maskSize-podsPrefixLength)
+	nodes := 1<<uint(bits-workerPrefixLength) - ReservedSubnetAddresses
+	if podCIDRs < nodes {
+		allErrs = append(allErrs, field.Invalid(maskPath, maskSize, fmt.Sprintf("the pods secondary range of prefix length %d provides %d pod CIDRs, but the worker subnet %s provides %d node addresses", podsPrefixLength, podCIDRs, worker, nodes)))
+	}
+
+	return allErrs
+}
+
+// NodePodCIDRPrefixLength computes the prefix length of the pod CIDR slice of each node for the given maximum
+// number of pods per node. Like GKE, the slice provides at least twice as many addresses as pods, so that the
+// addresses of deleted pods are not reused immediately, e.g. a /24 for 110 pods.
+func NodePodCIDRPrefixLength(maxPodsPerNode int32) int {
+	prefixLength := 32
+	for addresses := int64(1); addresses < 2*int64(maxPodsPerNode) && prefixLength > 0; addresses <<= 1 {
+		prefixLength--
+	}
+	return prefixLength
+}
+
+// ValidatePodCIDRCapacity validates that the given pod CIDR can accommodate the pod CIDR slices of the given number
+// of nodes, each of them sized for the given maximum number of pods per node by NodePodCIDRPrefixLength.
+func ValidatePodCIDRCapacity(pods gardencorev1alpha1.CIDR, maxPodsPerNode int32, nodes int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	_, network, err := net.ParseCIDR(string(pods))
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, pods, err.Error()))
+	}
+
+	podsPrefixLength, _ := network.Mask.Size()
+	nodePrefixLength := NodePodCIDRPrefixLength(maxPodsPerNode)
+	if nodePrefixLength < podsPrefixLength {
+		return append(allErrs, field.Invalid(fldPath, pods, fmt.Sprintf("is smaller than the /%d pod CIDR of a single node with %d pods", nodePrefixLength, maxPodsPerNode)))
+	}
+
+	if slices := 1 << uint(nodePrefixLength-podsPrefixLength); slices < nodes {
+		allErrs = append(allErrs, field.Invalid(fldPath, pods, fmt.Sprintf("provides %d pod CIDRs of prefix length %d for %d pods per node, but %d nodes require one each", slices, nodePrefixLength, maxPodsPerNode, nodes)))
+	}
+
+	return allErrs
+}
+
+// ValidatePodsSecondaryRangeCapacity validates that the pods secondary range of the given AliasIPs, carved from
+// the given pod network, can accommodate the pod CIDR slices of the given number of nodes with the given maximum
+// number of pods per node, see ValidatePodCIDRCapacity. GKE enforces the same relation between the size of the
+// pods secondary range and the maximum number of pods per node.
+func ValidatePodsSecondaryRangeCapacity(aliasIPs *gcpv1alpha1.AliasIPs, pods *gardencorev1alpha1.CIDR, maxPodsPerNode int32, nodes int, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if aliasIPs == nil || pods == nil {
+		return allErrs
+	}
+
+	podsRange, err := carveCIDR(*pods, aliasIPs.PodsPrefixLength)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, *pods, err.Error()))
+	}
+
+	return ValidatePodCIDRCapacity(*podsRange, maxPodsPerNode, nodes, fldPath)
+}
+
+// ValidateNetworkingMode validates the given NetworkingMode. Only NetworkingModeDefault and NetworkingModeCilium
+// can be set. The Cilium layout requires a service range disjoint from the given worker range.
+func ValidateNetworkingMode(mode *gcpv1alpha1.NetworkingMode, worker gardencorev1alpha1.CIDR, services *gardencorev1alpha1.CIDR, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if mode == nil {
+		return allErrs
+	}
+
+	switch *mode {
+	case gcpv1alpha1.NetworkingModeDefault:
+	case gcpv1alpha1.NetworkingModeCilium:
+		if services == nil {
+			return append(allErrs, field.Required(fldPath.Child("services"), "is required for the Cilium layout"))
+		}
+		allErrs = append(allErrs, validateCIDRDisjoint(*services, fldPath.Child("services"), []namedCIDR{{"worker", &worker}})...)
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("networkingMode"), *mode, []string{string(gcpv1alpha1.NetworkingModeDefault), string(gcpv1alpha1.NetworkingModeCilium)}))
+	}
+
+	return allErrs
+}
+
+// NetworkingModes are the networking modes a shoot can declare.
+var NetworkingModes = []string{
+	string(gcpv1alpha1.NetworkingModeDefault),
+	string(gcpv1alpha1.NetworkingModeAliasIPs),
+	string(gcpv1alpha1.NetworkingModeCilium),
+}
+
+// ValidateNetworkingConsistency validates that the networking mode declared by the shoot of the given cluster via
+// the gcp.NetworkingModeAnnotation matches the one of the given InfrastructureConfig. Shoots without the
+// annotation are consistent with any InfrastructureConfig.
+func ValidateNetworkingConsistency(config *gcpv1alpha1.InfrastructureConfig, cluster *controller.Cluster) error {
+	if cluster == nil || cluster.Shoot == nil {
+		return nil
+	}
+
+	declared, ok := cluster.Shoot.Annotations[gcp.NetworkingModeAnnotation]
+	if !ok {
+		return nil
+	}
+	if !sets.NewString(NetworkingModes...).Has(declared) {
+		return fmt.Errorf("shoot %s/%s declares unsupported networking mode %q, supported are %v",
+			cluster.Shoot.Namespace, cluster.Shoot.Name, declared, NetworkingModes)
+	}
+
+	if mode := networkingMode(config); string(mode) != declared {
+		return fmt.Errorf("shoot %s/%s declares networking mode %q but the infrastructure config uses %q",
+			cluster.Shoot.Namespace, cluster.Shoot.Name, declared, mode)
+	}
+	return nil
+}
+
+// ValidatePodServiceCIDRs validates that the pod and the service CIDR of the cluster are disjoint, as overlapping
+// ranges break the cluster networking. CIDRs that are not set are skipped.
+func ValidatePodServiceCIDRs(pods, services *gardencorev1alpha1.CIDR, fldPath *field.Path) field.ErrorList {
+	if pods == nil || services == nil {
+		return field.ErrorList{}
+	}
+
+	return validateCIDRDisjoint(*services, fldPath.Child("services"), []namedCIDR{{"pods", pods}})
+}
+
+// ValidateAliasIPRanges validates the ranges of the nodes subnet in alias IP mode. GCP requires the primary worker
+// range and the secondary pod and service ranges to be pairwise disjoint.
+func ValidateAliasIPRanges(worker gardencorev1alpha1.CIDR, pods, services *gardencorev1alpha1.CIDR, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if pods == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("pods"), "is required for alias IPs"))
+	}
+	if services == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("services"), "is required for alias IPs"))
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	var (
+		names    = []string{"worker", "pods", "services"}
+		cidrs    = []gardencorev1alpha1.CIDR{worker, *pods, *services}
+		networks = make([]*net.IPNet, len(cidrs))
+	)
+
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(string(cidr))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(names[i]), cidr, err.Error()))
+			continue
+		}
+		networks[i] = network
+	}
+
+	for i := range cidrs {
+		for j := i + 1; j < len(cidrs); j++ {
+			if networks[i] != nil && networks[j] != nil && cidrsOverlap(networks[i], networks[j]) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child(names[j]), cidrs[j], fmt.Sprintf("must not overlap with the %s CIDR %s", names[i], cidrs[i])))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateProxyOnlySubnet validates the proxy-only subnet of the given NetworkConfig. Its role has to be ACTIVE or
+// BACKUP and its range must not overlap with the worker and the internal subnet.
+func ValidateProxyOnlySubnet(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.ProxyOnly == nil {
+		return allErrs
+	}
+
+	proxyOnlyPath := fldPath.Child("proxyOnly")
+	if role := networks.ProxyOnly.Role; role != nil && *role != gcpv1alpha1.ProxyOnlySubnetRoleActive && *role != gcpv1alpha1.ProxyOnlySubnetRoleBackup {
+		allErrs = append(allErrs, field.NotSupported(proxyOnlyPath.Child("role"), *role, []string{string(gcpv1alpha1.ProxyOnlySubnetRoleActive), string(gcpv1alpha1.ProxyOnlySubnetRoleBackup)}))
+	}
+
+	allErrs = append(allErrs, validateCIDRDisjoint(networks.ProxyOnly.CIDR, proxyOnlyPath.Child("cidr"), []namedCIDR{
+		{"worker", &networks.Worker},
+		{"internal", networks.Internal},
+	})...)
+
+	return allErrs
+}
+
+// ValidateInternalProxyOnly validates that an internal subnet of the given NetworkConfig that is proxy-only is not
+// used for regular endpoints, i.e. that it is not translated via Cloud NAT, and that there is no other active
+// proxy-only subnet, as GCP only supports one per region and VPC.
+func ValidateInternalProxyOnly(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !networks.InternalProxyOnly {
+		return allErrs
+	}
+
+	if proxyOnly := networks.ProxyOnly; proxyOnly != nil && (proxyOnly.Role == nil || *proxyOnly.Role == gcpv1alpha1.ProxyOnlySubnetRoleActive) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("proxyOnly", "role"), "must not be active if the internal subnet is proxy-only"))
+	}
+	if networks.CloudNAT != nil {
+		for i, purpose := range networks.CloudNAT.Subnets {
+			if purpose == gcpv1alpha1.PurposeInternal {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("cloudNAT", "subnets").Index(i), "the internal subnet is proxy-only"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateInternalAddresses validates that the internal addresses of the given NetworkConfig are reserved in an
+// internal subnet that is not proxy-only and that has room for them, considering the four addresses GCP reserves in
+// every subnet.
+func ValidateInternalAddresses(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.InternalAddresses == nil {
+		return allErrs
+	}
+
+	count, countPath := *networks.InternalAddresses, fldPath.Child("internalAddresses")
+	if count < 1 {
+		return append(allErrs, field.Invalid(countPath, count, "must be positive"))
+	}
+	if networks.Internal == nil {
+		return append(allErrs, field.Forbidden(countPath, "can only be set if the internal subnet is set"))
+	}
+	if networks.InternalProxyOnly {
+		return append(allErrs, field.Forbidden(countPath, "cannot be set if the internal subnet is proxy-only"))
+	}
+
+	_, internal, err := net.ParseCIDR(string(*networks.Internal))
+	if err != nil {
+		return allErrs
+	}
+	ones, bits := internal.Mask.Size()
+	if usable := int64(1)<<uint(bits-ones) - ReservedSubnetAddresses; int64(count) > usable {
+		allErrs = append(allErrs, field.Invalid(countPath, count, fmt.Sprintf("exceeds the %d usable addresses of the internal subnet", usable)))
+	}
+
+	return allErrs
+}
+
+// ValidateAirGapped validates that an air-gapped NetworkConfig contains no element that would create external
+// connectivity, i.e. neither Cloud NAT nor a custom route for the default destination.
+func ValidateAirGapped(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if !networks.AirGapped {
+		return allErrs
+	}
+
+	if networks.CloudNAT != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("cloudNAT"), "cannot be set if the network is air-gapped"))
+	}
+	for i, route := range networks.CustomRoutes {
+		_, destination, err := net.ParseCIDR(string(route.Destination))
+		if err != nil {
+			continue
+		}
+		if ones, _ := destination.Mask.Size(); ones == 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("customRoutes").Index(i).Child("destination"), "must not be a default route if the network is air-gapped"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateServicePerimeter validates the service perimeter reference of the given NetworkConfig. It must be of the form
+// accessPolicies/<policy>/servicePerimeters/<perimeter>, and no custom route may shadow the restricted VIP range as
+// that one is routed by the extension itself.
+func ValidateServicePerimeter(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.ServicePerimeter == nil {
+		return allErrs
+	}
+
+	if !servicePerimeterRegex.MatchString(*networks.ServicePerimeter) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("servicePerimeter"), *networks.ServicePerimeter, "must be of the form accessPolicies/<policy>/servicePerimeters/<perimeter>"))
+	}
+	_, restricted, _ := net.ParseCIDR(RestrictedGoogleAPIsRange)
+	restrictedOnes, _ := restricted.Mask.Size()
+	for i, route := range networks.CustomRoutes {
+		_, destination, err := net.ParseCIDR(string(route.Destination))
+		if err != nil {
+			continue
+		}
+		if ones, _ := destination.Mask.Size(); ones >= restrictedOnes && restricted.Contains(destination.IP) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("customRoutes").Index(i).Child("destination"), route.Destination, fmt.Sprintf("must not shadow the restricted Google APIs range %s if a service perimeter is set", RestrictedGoogleAPIsRange)))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidatePrivateServiceConnectSubnet validates the Private Service Connect subnet of the given NetworkConfig. Its
+// range must not overlap with the worker, the internal and the proxy-only subnet.
+func ValidatePrivateServiceConnectSubnet(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	if networks.PrivateServiceConnect == nil {
+		return field.ErrorList{}
+	}
+
+	others := []namedCIDR{
+		{"worker", &networks.Worker},
+		{"internal", networks.Internal},
+	}
+	if networks.ProxyOnly != nil {
+		others = append(others, namedCIDR{"proxy-only", &networks.ProxyOnly.CIDR})
+	}
+
+	return validateCIDRDisjoint(*networks.PrivateServiceConnect, fldPath.Child("privateServiceConnect"), others)
+}
+
+// ValidateDisasterRecoverySubnet validates the disaster recovery subnet of the given NetworkConfig. Its region is
+// required and has to differ from the given region of the infrastructure, and its range must not overlap with the
+// other subnets.
+func ValidateDisasterRecoverySubnet(networks *gcpv1alpha1.NetworkConfig, region string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.DisasterRecovery == nil {
+		return allErrs
+	}
+
+	disasterRecoveryPath := fldPath.Child("disasterRecovery")
+	if drRegion := networks.DisasterRecovery.Region; drRegion == "" {
+		allErrs = append(allErrs, field.Required(disasterRecoveryPath.Child("region"), "must specify the secondary region"))
+	} else if drRegion == region {
+		allErrs = append(allErrs, field.Invalid(disasterRecoveryPath.Child("region"), drRegion, "must differ from the region of the infrastructure"))
+	}
+
+	others := []namedCIDR{
+		{"worker", &networks.Worker},
+		{"internal", networks.Internal},
+		{"private-service-connect", networks.PrivateServiceConnect},
+	}
+	if networks.ProxyOnly != nil {
+		others = append(others, namedCIDR{"proxy-only", &networks.ProxyOnly.CIDR})
+	}
+	allErrs = append(allErrs, validateCIDRDisjoint(networks.DisasterRecovery.CIDR, disasterRecoveryPath.Child("cidr"), others)...)
+
+	return allErrs
+}
+
+// ValidateEgressApplianceSubnet validates the egress appliance subnet of the given NetworkConfig. Its range must
+// not overlap with the other subnets and its next hop has to be an IP within it. The destinations of the steering
+// routes have to be unique, must neither lie within a subnet, whose routes take precedence, nor be the destination
+// of a custom route, and the names of the steering routes must not be taken by custom routes.
+func ValidateEgressApplianceSubnet(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.EgressAppliance == nil {
+		return allErrs
+	}
+
+	egressAppliancePath := fldPath.Child("egressAppliance")
+	egressAppliance := networks.EgressAppliance
+
+	subnets := []namedCIDR{
+		{"worker", &networks.Worker},
+		{"internal", networks.Internal},
+		{"private-service-connect", networks.PrivateServiceConnect},
+	}
+	if networks.ProxyOnly != nil {
+		subnets = append(subnets, namedCIDR{"proxy-only", &networks.ProxyOnly.CIDR})
+	}
+	if networks.DisasterRecovery != nil {
+		subnets = append(subnets, namedCIDR{"disaster-recovery", &networks.DisasterRecovery.CIDR})
+	}
+	allErrs = append(allErrs, validateCIDRDisjoint(egressAppliance.CIDR, egressAppliancePath.Child("cidr"), subnets)...)
+
+	nextHopPath := egressAppliancePath.Child("nextHopIP")
+	if nextHopIP := net.ParseIP(egressAppliance.NextHopIP); nextHopIP == nil {
+		allErrs = append(allErrs, field.Invalid(nextHopPath, egressAppliance.NextHopIP, "must be a valid IP"))
+	} else if _, network, err := net.ParseCIDR(string(egressAppliance.CIDR)); err == nil && !network.Contains(nextHopIP) {
+		allErrs = append(allErrs, field.Invalid(nextHopPath, egressAppliance.NextHopIP, fmt.Sprintf("must be within the egress appliance CIDR %s", egressAppliance.CIDR)))
+	}
+
+	if priority := egressAppliance.Priority; priority != nil && (*priority < 0 || *priority > MaxRoutePriority) {
+		allErrs = append(allErrs, field.Invalid(egressAppliancePath.Child("priority"), *priority, fmt.Sprintf("must be between 0 and %d", MaxRoutePriority)))
+	}
+
+	subnets = append(subnets, namedCIDR{"egress-appliance", &egressAppliance.CIDR})
+	customDestinations := map[string]string{}
+	for _, route := range networks.CustomRoutes {
+		customDestinations[string(route.Destination)] = route.Name
+	}
+	destinations := sets.NewString()
+	for i, destination := range egressAppliance.Destinations {
+		destinationPath := egressAppliancePath.Child("destinations").Index(i)
+
+		_, network, err := net.ParseCIDR(string(destination))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(destinationPath, destination, err.Error()))
+			continue
+		}
+		if destinations.Has(network.String()) {
+			allErrs = append(allErrs, field.Duplicate(destinationPath, destination))
+		}
+		destinations.Insert(network.String())
+
+		for _, subnet := range subnets {
+			if subnet.cidr == nil {
+				continue
+			}
+			if _, subnetNetwork, err := net.ParseCIDR(string(*subnet.cidr)); err == nil && subnetNetwork.Contains(network.IP) && !cidrIsProperSubset(subnetNetwork, network) {
+				allErrs = append(allErrs, field.Invalid(destinationPath, destination, fmt.Sprintf("must not lie within the %s CIDR %s", subnet.name, *subnet.cidr)))
+			}
+		}
+		if name, ok := customDestinations[string(destination)]; ok {
+			allErrs = append(allErrs, field.Invalid(destinationPath, destination, fmt.Sprintf("must not be the destination of the custom route %s", name)))
+		}
+	}
+	if name, ok := customDestinations[string(DefaultEgressApplianceDestination)]; ok && len(egressAppliance.Destinations) == 0 {
+		allErrs = append(allErrs, field.Required(egressAppliancePath.Child("destinations"), fmt.Sprintf("must be set as the custom route %s already has the default destination %s", name, DefaultEgressApplianceDestination)))
+	}
+
+	for i, route := range networks.CustomRoutes {
+		for j := range egressApplianceRoutes(egressAppliance) {
+			if route.Name == egressApplianceRouteName(j) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("customRoutes").Index(i).Child("name"), route.Name, "is reserved for a route steering the egress traffic through the egress appliances"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateInternalSubnetRegion validates that the given region of the internal subnet matches the given region of
+// the nodes subnet, as internal load balancing expects both to be co-located. Differing regions are only allowed
+// if allowMultiRegion is set.
+func ValidateInternalSubnetRegion(internalRegion, nodesRegion string, allowMultiRegion bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if internalRegion != nodesRegion && !allowMultiRegion {
+		allErrs = append(allErrs, field.Invalid(fldPath, internalRegion, fmt.Sprintf("must match the region %s of the nodes subnet unless multiple regions are allowed", nodesRegion)))
+	}
+
+	return allErrs
+}
+
+// ValidateControlPlanePeering validates the control plane peering range of the given NetworkConfig. Its prefix
+// length must not exceed MaxControlPlanePeeringPrefixLength and it must not overlap with the subnets. Its routes
+// can only be configured if the range is.
+func ValidateControlPlanePeering(networks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if networks.ControlPlanePeering == nil {
+		if networks.ControlPlanePeeringRoutes != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("controlPlanePeeringRoutes"), "can only be set if the control plane peering is configured"))
+		}
+		return allErrs
+	}
+
+	peeringPath := fldPath.Child("controlPlanePeering")
+	_, network, err := net.ParseCIDR(string(*networks.ControlPlanePeering))
+	if err != nil {
+		return append(allErrs, field.Invalid(peeringPath, *networks.ControlPlanePeering, err.Error()))
+	}
+	if ones, _ := network.Mask.Size(); ones > MaxControlPlanePeeringPrefixLength {
+		allErrs = append(allErrs, field.Invalid(peeringPath, *networks.ControlPlanePeering, fmt.Sprintf("prefix length must not exceed %d", MaxControlPlanePeeringPrefixLength)))
+	}
+
+	others := []namedCIDR{
+		{"worker", &networks.Worker},
+		{"internal", networks.Internal},
+		{"private-service-connect", networks.PrivateServiceConnect},
+	}
+	if networks.ProxyOnly != nil {
+		others = append(others, namedCIDR{"proxy-only", &networks.ProxyOnly.CIDR})
+	}
+	if networks.DisasterRecovery != nil {
+		others = append(others, namedCIDR{"disaster-recovery", &networks.DisasterRecovery.CIDR})
+	}
+	if networks.EgressAppliance != nil {
+		others = append(others, namedCIDR{"egress-appliance", &networks.EgressAppliance.CIDR})
+	}
+	allErrs = append(allErrs, validateCIDRDisjoint(*networks.ControlPlanePeering, peeringPath, others)...)
+
+	return allErrs
+}
+
+// ValidateCustomRoutes validates the given custom routes. Their names have to be unique lowercase DNS labels,
+// their destinations valid CIDRs and each of them has to reference exactly one next hop.
+func ValidateCustomRoutes(routes []gcpv1alpha1.Route, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.NewString()
+	for i, route := range routes {
+		routePath := fldPath.Index(i)
+
+		if !routeNameRegex.MatchString(route.Name) {
+			allErrs = append(allErrs, field.Invalid(routePath.Child("name"), route.Name, "must consist of lowercase letters, digits and '-' and start and end with a letter or digit"))
+		} else if names.Has(route.Name) {
+			allErrs = append(allErrs, field.Duplicate(routePath.Child("name"), route.Name))
+		}
+		names.Insert(route.Name)
+
+		if _, _, err := net.ParseCIDR(string(route.Destination)); err != nil {
+			allErrs = append(allErrs, field.Invalid(routePath.Child("destination"), route.Destination, err.Error()))
+		}
+
+		var nextHops []string
+		if route.NextHopIP != nil {
+			nextHops = append(nextHops, "nextHopIP")
+			if net.ParseIP(*route.NextHopIP) == nil {
+				allErrs = append(allErrs, field.Invalid(routePath.Child("nextHopIP"), *route.NextHopIP, "must be a valid IP"))
+			}
+		}
+		if route.NextHopVPNTunnel != nil {
+			nextHops = append(nextHops, "nextHopVPNTunnel")
+			if !vpnTunnelRegex.MatchString(*route.NextHopVPNTunnel) {
+				allErrs = append(allErrs, field.Invalid(routePath.Child("nextHopVPNTunnel"), *route.NextHopVPNTunnel, "must be the self-link of a VPN tunnel"))
+			}
+		}
+		if route.NextHopILB != nil {
+			nextHops = append(nextHops, "nextHopILB")
+			if net.ParseIP(*route.NextHopILB) == nil && !forwardingRuleRegex.MatchString(*route.NextHopILB) {
+				allErrs = append(allErrs, field.Invalid(routePath.Child("nextHopILB"), *route.NextHopILB, "must be an IP or the self-link of a forwarding rule"))
+			}
+		}
+		switch len(nextHops) {
+		case 0:
+			allErrs = append(allErrs, field.Required(routePath, "exactly one of nextHopIP, nextHopVPNTunnel and nextHopILB has to be set"))
+		case 1:
+		default:
+			allErrs = append(allErrs, field.Forbidden(routePath, fmt.Sprintf("only one next hop may be set, found %s", strings.Join(nextHops, ", "))))
+		}
+	}
+
+	return allErrs
+}
+
+// namedCIDR is a CIDR together with a name used in validation messages.
+type namedCIDR struct {
+	name string
+	cidr *gardencorev1alpha1.CIDR
+}
+
+// validateCIDRDisjoint validates that the given CIDR can be parsed and does not overlap with any of the others.
+// Others that are nil or cannot be parsed are skipped.
+func validateCIDRDisjoint(cidr gardencorev1alpha1.CIDR, fldPath *field.Path, others []namedCIDR) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	_, network, err := net.ParseCIDR(string(cidr))
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, cidr, err.Error()))
+	}
+
+	for _, other := range others {
+		if other.cidr == nil {
+			continue
+		}
+		if _, otherNetwork, err := net.ParseCIDR(string(*other.cidr)); err == nil && cidrsOverlap(network, otherNetwork) {
+			allErrs = append(allErrs, field.Invalid(fldPath, cidr, fmt.Sprintf("must not overlap with the %s CIDR %s", other.name, *other.cidr)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateCIDRExpansion validates that the new CIDR is either equal to or an expansion of the old CIDR, i.e.
+// the new CIDR contains the old CIDR.
+func validateCIDRExpansion(newCIDR, oldCIDR gardencorev1alpha1.CIDR, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	_, newNetwork, err := net.ParseCIDR(string(newCIDR))
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, newCIDR, err.Error()))
+	}
+	_, oldNetwork, err := net.ParseCIDR(string(oldCIDR))
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, newCIDR, fmt.Sprintf("cannot parse previous CIDR %s: %v", oldCIDR, err)))
+	}
+
+	newOnes, _ := newNetwork.Mask.Size()
+	oldOnes, _ := oldNetwork.Mask.Size()
+	if newOnes > oldOnes || !newNetwork.Contains(oldNetwork.IP) {
+		allErrs = append(allErrs, field.Invalid(fldPath, newCIDR, fmt.Sprintf("may only be expanded to a range containing the previous range %s", oldCIDR)))
+	}
+
+	return allErrs
+}
+
+// ValidateNetworkConfigUpdate validates an update of the given NetworkConfig. The subnet ranges can be expanded
+// in place, but must neither shrink nor move to a different base address. Whether the internal subnet is
+// proxy-only cannot be changed, as this replaces the subnet.
+func ValidateNetworkConfigUpdate(newNetworks, oldNetworks *gcpv1alpha1.NetworkConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateCIDRExpansion(newNetworks.Worker, oldNetworks.Worker, fldPath.Child("worker"))...)
+	if newNetworks.Internal != nil && oldNetworks.Internal != nil {
+		allErrs = append(allErrs, validateCIDRExpansion(*newNetworks.Internal, *oldNetworks.Internal, fldPath.Child("internal"))...)
+		if newNetworks.InternalProxyOnly != oldNetworks.InternalProxyOnly {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("internalProxyOnly"), "cannot be changed as this replaces the internal subnet"))
+		}
+	}
+
+	return allErrs
+}
+
+// CheckRoutingModeConflicts checks the given subnets for layouts that are likely to produce routing conflicts
+// with the given routing mode. Overlapping subnets in the same region are always reported, overlapping subnets
+// in different regions only if routes are advertised globally.
+//
+// The check is advisory, hence it returns warnings instead of errors.
+func CheckRoutingModeConflicts(mode RoutingMode, subnets []RegionalCIDR) []string {
+	var (
+		warnings []string
+		networks = make([]*net.IPNet, len(subnets))
+	)
+
+	for i, subnet := range subnets {
+		_, network, err := net.ParseCIDR(subnet.CIDR)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("cannot check routing of subnet %s in region %s: %v", subnet.CIDR, subnet.Region, err))
+			continue
+		}
+		networks[i] = network
+	}
+
+	for i := range subnets {
+		for j := i + 1; j < len(subnets); j++ {
+			if networks[i] == nil || networks[j] == nil || !cidrsOverlap(networks[i], networks[j]) {
+				continue
+			}
+
+			a, b := subnets[i], subnets[j]
+			switch {
+			case a.Region == b.Region:
+				warnings = append(warnings, fmt.Sprintf("subnets %s and %s overlap in region %s", a.CIDR, b.CIDR, a.Region))
+			case mode == RoutingModeGlobal:
+				warnings = append(warnings, fmt.Sprintf("subnets %s in region %s and %s in region %s overlap and conflict with %s routing", a.CIDR, a.Region, b.CIDR, b.Region, mode))
+			}
+		}
+	}
+
+	return warnings
+}
+
+// CheckCloudNATWithPublicNodeIPs checks whether the Cloud NAT of the given NetworkConfig translates the nodes
+// subnet although the nodes have public IPs, as indicated by publicNodeIPs. Such nodes reach the internet via
+// their public IPs, hence the NAT resources reserved for them are wasted.
+//
+// The check is advisory, hence it returns warnings instead of errors.
+func CheckCloudNATWithPublicNodeIPs(networks *gcpv1alpha1.NetworkConfig, publicNodeIPs bool) []string {
+	var warnings []string
+
+	if networks.CloudNAT == nil || !publicNodeIPs {
+		return warnings
+	}
+
+	for _, subnet := range cloudNATSubnets(networks) {
+		if subnet == string(gcpv1alpha1.PurposeNodes) {
+			warnings = append(warnings, "Cloud NAT translates the nodes subnet although the nodes have public IPs, which bypass it")
+		}
+	}
+
+	return warnings
+}
+
+// ValidateLabels validates the given (merged) labels against the constraints GCP imposes on resource labels,
+// i.e. the number of labels as well as the length and the characters of each key and value.
+func ValidateLabels(labels map[string]string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if len(keys) > MaxLabels {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("must not have more than %d labels, got %d, exceeding: %s", MaxLabels, len(keys), strings.Join(keys[MaxLabels:], ", "))))
+	}
+
+	for _, key := range keys {
+		value := labels[key]
+		if len(key) > MaxLabelLength || !labelKeyRegex.MatchString(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath, key, fmt.Sprintf("label keys must start with a lowercase letter, consist of lowercase letters, digits, '_' and '-' and have at most %d characters", MaxLabelLength)))
+		}
+		if len(value) > MaxLabelLength || !labelValueRegex.MatchString(value) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), value, fmt.Sprintf("label values must consist of lowercase letters, digits, '_' and '-' and have at most %d characters", MaxLabelLength)))
+		}
+	}
+
+	return allErrs
+}
+
+// MergeLabels merges the given user labels into the labels Gardener attaches to the resources. User labels must
+// not use the keys of the Gardener labels, which are reserved, and the merged labels have to pass ValidateLabels.
+func MergeLabels(gardenerLabels, userLabels map[string]string, fldPath *field.Path) (map[string]string, field.ErrorList) {
+	allErrs := field.ErrorList{}
+
+	keys := make([]string, 0, len(userLabels))
+	for key := range userLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	merged := make(map[string]string, len(gardenerLabels)+len(userLabels))
+	for key, value := range gardenerLabels {
+		merged[key] = value
+	}
+	for _, key := range keys {
+		if _, ok := gardenerLabels[key]; ok {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Key(key), fmt.Sprintf("label key %q is reserved by Gardener", key)))
+			continue
+		}
+		merged[key] = userLabels[key]
+	}
+	if len(allErrs) > 0 {
+		return nil, allErrs
+	}
+
+	if errs := ValidateLabels(merged, fldPath); len(errs) > 0 {
+		return nil, errs
+	}
+	return merged, nil
+}
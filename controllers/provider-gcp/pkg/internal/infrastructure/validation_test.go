@@ -0,0 +1,2291 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infrastructure
+
+import (
+	"fmt"
+
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
+	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/gcp"
+	"github.com/gardener/gardener-extensions/pkg/controller"
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	gardenv1beta1 "github.com/gardener/gardener/pkg/apis/garden/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var _ = Describe("Validation", func() {
+	Describe("#CheckRoutingModeConflicts", func() {
+		var subnets []RegionalCIDR
+
+		BeforeEach(func() {
+			subnets = []RegionalCIDR{
+				{Region: "europe-west1", CIDR: "10.250.0.0/16"},
+				{Region: "us-east1", CIDR: "10.250.128.0/17"},
+			}
+		})
+
+		It("should warn about overlapping subnets across regions with global routing", func() {
+			Expect(CheckRoutingModeConflicts(RoutingModeGlobal, subnets)).To(HaveLen(1))
+		})
+
+		It("should not warn about overlapping subnets across regions with regional routing", func() {
+			Expect(CheckRoutingModeConflicts(RoutingModeRegional, subnets)).To(BeEmpty())
+		})
+
+		It("should not warn about disjoint subnets with global routing", func() {
+			subnets[1].CIDR = "10.251.0.0/16"
+
+			Expect(CheckRoutingModeConflicts(RoutingModeGlobal, subnets)).To(BeEmpty())
+		})
+	})
+
+	Describe("#CheckCloudNATWithPublicNodeIPs", func() {
+		var networks *gcpv1alpha1.NetworkConfig
+
+		BeforeEach(func() {
+			networks = &gcpv1alpha1.NetworkConfig{
+				Worker:   gardencorev1alpha1.CIDR("10.250.0.0/16"),
+				CloudNAT: &gcpv1alpha1.CloudNAT{},
+			}
+		})
+
+		It("should warn about Cloud NAT for nodes with public IPs", func() {
+			Expect(CheckCloudNATWithPublicNodeIPs(networks, true)).To(HaveLen(1))
+		})
+
+		It("should not warn about Cloud NAT for nodes without public IPs", func() {
+			Expect(CheckCloudNATWithPublicNodeIPs(networks, false)).To(BeEmpty())
+		})
+
+		It("should not warn if Cloud NAT does not translate the nodes subnet", func() {
+			internal := gardencorev1alpha1.CIDR("10.251.0.0/24")
+			networks.Internal = &internal
+			networks.CloudNAT.Subnets = []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeInternal}
+
+			Expect(CheckCloudNATWithPublicNodeIPs(networks, true)).To(BeEmpty())
+		})
+	})
+
+	Describe("#NormalizeRegion", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("region")
+		})
+
+		DescribeTable("valid regions",
+			func(region, expected string) {
+				normalized, errs := NormalizeRegion(region, fldPath)
+
+				Expect(errs).To(BeEmpty())
+				Expect(normalized).To(Equal(expected))
+			},
+			Entry("lowercase region", "europe-west1", "europe-west1"),
+			Entry("uppercase region", "EUROPE-WEST1", "europe-west1"),
+			Entry("mixed case region", "Us-Central1", "us-central1"),
+		)
+
+		DescribeTable("invalid regions",
+			func(region string) {
+				_, errs := NormalizeRegion(region, fldPath)
+
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+				Expect(errs[0].Field).To(Equal("region"))
+			},
+			Entry("empty region", ""),
+			Entry("region with whitespace", "europe west1"),
+			Entry("region with underscore", "europe_west1"),
+			Entry("zone", "europe-west1-b"),
+		)
+	})
+
+	Describe("#ValidateWorkerSubnet", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+		})
+
+		It("should accept an internal subnet next to the nodes subnet", func() {
+			internal := gardencorev1alpha1.CIDR("10.251.0.0/16")
+			networks := &gcpv1alpha1.NetworkConfig{Worker: "10.250.0.0/16", Internal: &internal}
+
+			Expect(ValidateWorkerSubnet(networks, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject an internal subnet without the nodes subnet", func() {
+			internal := gardencorev1alpha1.CIDR("10.251.0.0/16")
+			networks := &gcpv1alpha1.NetworkConfig{Internal: &internal}
+
+			errs := ValidateWorkerSubnet(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+			Expect(errs[0].Field).To(Equal("networks.worker"))
+			Expect(errs[0].Detail).To(ContainSubstring("internal subnet cannot be the only subnet"))
+		})
+
+		It("should require the nodes subnet", func() {
+			errs := ValidateWorkerSubnet(&gcpv1alpha1.NetworkConfig{}, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+			Expect(errs[0].Field).To(Equal("networks.worker"))
+		})
+	})
+
+	Describe("#ValidateReservedCIDRs", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+		})
+
+		It("should accept CIDRs outside of the reserved ranges", func() {
+			internal := gardencorev1alpha1.CIDR("192.168.0.0/16")
+			networks := &gcpv1alpha1.NetworkConfig{Worker: "10.250.0.0/16", Internal: &internal}
+
+			Expect(ValidateReservedCIDRs(networks, fldPath)).To(BeEmpty())
+		})
+
+		DescribeTable("CIDRs overlapping the reserved ranges",
+			func(worker, internal gardencorev1alpha1.CIDR, fieldPath string) {
+				networks := &gcpv1alpha1.NetworkConfig{Worker: worker, Internal: &internal}
+
+				errs := ValidateReservedCIDRs(networks, fldPath)
+
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+				Expect(errs[0].Field).To(Equal(fieldPath))
+			},
+			Entry("link-local worker CIDR", gardencorev1alpha1.CIDR("169.254.0.0/20"), gardencorev1alpha1.CIDR("192.168.0.0/16"), "networks.worker"),
+			Entry("loopback internal CIDR", gardencorev1alpha1.CIDR("10.250.0.0/16"), gardencorev1alpha1.CIDR("127.0.0.0/24"), "networks.internal"),
+			Entry("multicast worker CIDR", gardencorev1alpha1.CIDR("224.1.0.0/16"), gardencorev1alpha1.CIDR("192.168.0.0/16"), "networks.worker"),
+		)
+	})
+
+	Describe("#ValidateInternalCIDRDisjoint", func() {
+		var (
+			fldPath  *field.Path
+			networks *gcpv1alpha1.NetworkConfig
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+			internalCIDR := gardencorev1alpha1.CIDR("10.250.112.0/22")
+			networks = &gcpv1alpha1.NetworkConfig{
+				Worker:   gardencorev1alpha1.CIDR("10.250.0.0/16"),
+				Internal: &internalCIDR,
+			}
+		})
+
+		It("should reject an internal CIDR nested within the worker CIDR", func() {
+			errs := ValidateInternalCIDRDisjoint(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("networks.internal"))
+		})
+
+		It("should reject an internal CIDR containing the worker CIDR", func() {
+			internalCIDR := gardencorev1alpha1.CIDR("10.0.0.0/8")
+			networks.Internal = &internalCIDR
+
+			Expect(ValidateInternalCIDRDisjoint(networks, fldPath)).To(HaveLen(1))
+		})
+
+		It("should allow a disjoint internal CIDR", func() {
+			internalCIDR := gardencorev1alpha1.CIDR("192.168.0.0/16")
+			networks.Internal = &internalCIDR
+
+			Expect(ValidateInternalCIDRDisjoint(networks, fldPath)).To(BeEmpty())
+		})
+
+		It("should allow a missing internal CIDR", func() {
+			networks.Internal = nil
+
+			Expect(ValidateInternalCIDRDisjoint(networks, fldPath)).To(BeEmpty())
+		})
+	})
+
+	Describe("#ValidateCloudNAT", func() {
+		var (
+			fldPath  *field.Path
+			networks *gcpv1alpha1.NetworkConfig
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+			networks = &gcpv1alpha1.NetworkConfig{
+				VPC:      &gcpv1alpha1.VPC{Name: "vpc"},
+				Worker:   gardencorev1alpha1.CIDR("10.250.0.0/16"),
+				CloudNAT: &gcpv1alpha1.CloudNAT{},
+			}
+		})
+
+		It("should reject Cloud NAT for an existing VPC without Cloud Router", func() {
+			errs := ValidateCloudNAT(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+			Expect(errs[0].Field).To(Equal("networks.vpc.cloudRouter.name"))
+		})
+
+		It("should allow Cloud NAT for an existing VPC with an existing Cloud Router", func() {
+			networks.VPC.CloudRouter = &gcpv1alpha1.CloudRouter{Name: "router"}
+
+			Expect(ValidateCloudNAT(networks, fldPath)).To(BeEmpty())
+		})
+
+		It("should allow Cloud NAT for a created VPC", func() {
+			networks.VPC = nil
+
+			Expect(ValidateCloudNAT(networks, fldPath)).To(BeEmpty())
+		})
+	})
+
+	Describe("#ValidateCloudNATSubnets", func() {
+		var (
+			fldPath  *field.Path
+			networks *gcpv1alpha1.NetworkConfig
+		)
+
+		BeforeEach(func() {
+			internal := gardencorev1alpha1.CIDR("10.251.0.0/16")
+			fldPath = field.NewPath("networks", "cloudNAT", "subnets")
+			networks = &gcpv1alpha1.NetworkConfig{
+				Internal: &internal,
+				CloudNAT: &gcpv1alpha1.CloudNAT{
+					Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeNodes, gcpv1alpha1.PurposeInternal},
+				},
+			}
+		})
+
+		It("should accept the supported subnets", func() {
+			Expect(ValidateCloudNATSubnets(networks, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject unsupported and duplicate subnets", func() {
+			networks.CloudNAT.Subnets = []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeProxyOnly, gcpv1alpha1.PurposeNodes, gcpv1alpha1.PurposeNodes}
+
+			errs := ValidateCloudNATSubnets(networks, fldPath)
+
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeNotSupported))
+			Expect(errs[0].Field).To(Equal("networks.cloudNAT.subnets[0]"))
+			Expect(errs[1].Type).To(Equal(field.ErrorTypeDuplicate))
+			Expect(errs[1].Field).To(Equal("networks.cloudNAT.subnets[2]"))
+		})
+
+		It("should reject the internal subnet if it is not configured", func() {
+			networks.Internal = nil
+
+			errs := ValidateCloudNATSubnets(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("networks.cloudNAT.subnets[1]"))
+		})
+	})
+
+	Describe("#ValidateCloudNATGateways", func() {
+		var (
+			fldPath  *field.Path
+			networks *gcpv1alpha1.NetworkConfig
+		)
+
+		BeforeEach(func() {
+			internal := gardencorev1alpha1.CIDR("10.251.0.0/16")
+			fldPath = field.NewPath("networks", "cloudNAT")
+			networks = &gcpv1alpha1.NetworkConfig{
+				Internal: &internal,
+				CloudNAT: &gcpv1alpha1.CloudNAT{
+					Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeNodes},
+					Gateways: []gcpv1alpha1.CloudNATGateway{
+						{Name: "internal", Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeInternal}},
+					},
+				},
+			}
+		})
+
+		It("should accept gateways with disjoint subnets", func() {
+			Expect(ValidateCloudNATGateways(networks, fldPath)).To(BeEmpty())
+		})
+
+		It("should accept a Cloud NAT without gateways", func() {
+			networks.CloudNAT = &gcpv1alpha1.CloudNAT{}
+
+			Expect(ValidateCloudNATGateways(networks, fldPath)).To(BeEmpty())
+		})
+
+		It("should require the subnets of the Cloud NAT to be selected explicitly", func() {
+			networks.CloudNAT.Subnets = nil
+
+			errs := ValidateCloudNATGateways(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+			Expect(errs[0].Field).To(Equal("networks.cloudNAT.subnets"))
+		})
+
+		It("should reject subnets translated by several gateways", func() {
+			networks.CloudNAT.Gateways = append(networks.CloudNAT.Gateways, gcpv1alpha1.CloudNATGateway{Name: "nodes", Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeNodes}})
+			networks.CloudNAT.Gateways[0].Subnets = append(networks.CloudNAT.Gateways[0].Subnets, gcpv1alpha1.PurposeInternal)
+
+			errs := ValidateCloudNATGateways(networks, fldPath)
+
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("networks.cloudNAT.gateways[0].subnets[1]"))
+			Expect(errs[1].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[1].Field).To(Equal("networks.cloudNAT.gateways[1].subnets[0]"))
+		})
+
+		It("should reject malformed and duplicate names", func() {
+			networks.CloudNAT.Subnets = nil
+			networks.CloudNAT.Gateways = []gcpv1alpha1.CloudNATGateway{
+				{Name: "Nodes", Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeNodes}},
+				{Name: "internal", Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeInternal}},
+				{Name: "internal"},
+			}
+
+			errs := ValidateCloudNATGateways(networks, fldPath)
+
+			Expect(errs).To(HaveLen(4))
+			Expect(errs[0].Field).To(Equal("networks.cloudNAT.subnets"))
+			Expect(errs[1].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[1].Field).To(Equal("networks.cloudNAT.gateways[0].name"))
+			Expect(errs[2].Type).To(Equal(field.ErrorTypeDuplicate))
+			Expect(errs[2].Field).To(Equal("networks.cloudNAT.gateways[2].name"))
+			Expect(errs[3].Type).To(Equal(field.ErrorTypeRequired))
+			Expect(errs[3].Field).To(Equal("networks.cloudNAT.gateways[2].subnets"))
+		})
+
+		It("should reject unsupported subnets and the internal subnet if it is not configured", func() {
+			networks.Internal = nil
+			networks.CloudNAT.Gateways[0].Subnets = []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeProxyOnly, gcpv1alpha1.PurposeInternal}
+
+			errs := ValidateCloudNATGateways(networks, fldPath)
+
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeNotSupported))
+			Expect(errs[0].Field).To(Equal("networks.cloudNAT.gateways[0].subnets[0]"))
+			Expect(errs[1].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[1].Field).To(Equal("networks.cloudNAT.gateways[0].subnets[1]"))
+		})
+	})
+
+	Describe("#ValidateFeatureCoherence", func() {
+		var (
+			create         = false
+			mtu      int32 = 1460
+			internal       = gardencorev1alpha1.CIDR("10.251.0.0/16")
+			purpose        = gcpv1alpha1.SubnetPurpose("ilb")
+			cilium         = gcpv1alpha1.NetworkingModeCilium
+			project        = "service-accounts"
+			peering        = gardencorev1alpha1.CIDR("10.252.0.0/20")
+		)
+
+		It("should accept a coherent config", func() {
+			Expect(ValidateFeatureCoherence(&gcpv1alpha1.InfrastructureConfig{
+				Networks: gcpv1alpha1.NetworkConfig{
+					Worker:          gardencorev1alpha1.CIDR("10.250.0.0/16"),
+					Internal:        &internal,
+					InternalPurpose: &purpose,
+					MTU:             &mtu,
+					CloudNAT:        &gcpv1alpha1.CloudNAT{},
+				},
+				ServiceAccount: &gcpv1alpha1.ServiceAccountConfig{WorkloadIdentityUsers: []string{"kube-system/foo"}},
+			})).To(BeEmpty())
+		})
+
+		DescribeTable("should reject incoherent feature combinations",
+			func(config *gcpv1alpha1.InfrastructureConfig, errType field.ErrorType, fld string) {
+				errs := ValidateFeatureCoherence(config)
+
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Type).To(Equal(errType))
+				Expect(errs[0].Field).To(Equal(fld))
+			},
+			Entry("internal purpose without internal subnet", &gcpv1alpha1.InfrastructureConfig{
+				Networks: gcpv1alpha1.NetworkConfig{InternalPurpose: &purpose},
+			}, field.ErrorTypeRequired, "networks.internal"),
+			Entry("Cilium layout with alias IPs", &gcpv1alpha1.InfrastructureConfig{
+				Networks: gcpv1alpha1.NetworkConfig{NetworkingMode: &cilium, AliasIPs: &gcpv1alpha1.AliasIPs{}},
+			}, field.ErrorTypeForbidden, "networks.networkingMode"),
+			Entry("MTU for an existing VPC", &gcpv1alpha1.InfrastructureConfig{
+				Networks: gcpv1alpha1.NetworkConfig{VPC: &gcpv1alpha1.VPC{Name: "vpc"}, MTU: &mtu},
+			}, field.ErrorTypeForbidden, "networks.mtu"),
+			Entry("control plane peering for an existing VPC", &gcpv1alpha1.InfrastructureConfig{
+				Networks: gcpv1alpha1.NetworkConfig{VPC: &gcpv1alpha1.VPC{Name: "vpc"}, ControlPlanePeering: &peering},
+			}, field.ErrorTypeForbidden, "networks.controlPlanePeering"),
+			Entry("Cloud NAT for an existing VPC without Cloud Router", &gcpv1alpha1.InfrastructureConfig{
+				Networks: gcpv1alpha1.NetworkConfig{VPC: &gcpv1alpha1.VPC{Name: "vpc"}, CloudNAT: &gcpv1alpha1.CloudNAT{}},
+			}, field.ErrorTypeRequired, "networks.vpc.cloudRouter.name"),
+			Entry("workload identity users without created service account", &gcpv1alpha1.InfrastructureConfig{
+				ServiceAccount: &gcpv1alpha1.ServiceAccountConfig{Create: &create, WorkloadIdentityUsers: []string{"kube-system/foo"}},
+			}, field.ErrorTypeForbidden, "serviceAccount.workloadIdentityUsers"),
+			Entry("service account project without created service account", &gcpv1alpha1.InfrastructureConfig{
+				ServiceAccount: &gcpv1alpha1.ServiceAccountConfig{Create: &create, Project: &project},
+			}, field.ErrorTypeForbidden, "serviceAccount.project"),
+		)
+	})
+
+	Describe("#ValidateVPCSource", func() {
+		var (
+			fldPath       *field.Path
+			mtu           int32 = 1460
+			peering             = gardencorev1alpha1.CIDR("10.252.0.0/20")
+			description         = "shoot network"
+			referencedVPC       = &gcpv1alpha1.VPC{Name: "vpc"}
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+		})
+
+		It("should accept a referenced VPC", func() {
+			Expect(ValidateVPCSource(&gcpv1alpha1.NetworkConfig{VPC: referencedVPC}, fldPath)).To(BeEmpty())
+		})
+
+		It("should accept a created VPC with its settings", func() {
+			Expect(ValidateVPCSource(&gcpv1alpha1.NetworkConfig{MTU: &mtu, VPCDescription: &description, ControlPlanePeering: &peering}, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject a referenced VPC without name", func() {
+			errs := ValidateVPCSource(&gcpv1alpha1.NetworkConfig{VPC: &gcpv1alpha1.VPC{}}, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+			Expect(errs[0].Field).To(Equal("networks.vpc.name"))
+		})
+
+		It("should reject the settings of a created VPC for a referenced VPC", func() {
+			errs := ValidateVPCSource(&gcpv1alpha1.NetworkConfig{VPC: referencedVPC, MTU: &mtu, VPCDescription: &description, ControlPlanePeering: &peering}, fldPath)
+
+			Expect(errs).To(HaveLen(3))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[0].Field).To(Equal("networks.mtu"))
+			Expect(errs[1].Field).To(Equal("networks.vpcDescription"))
+			Expect(errs[2].Field).To(Equal("networks.controlPlanePeering"))
+			Expect(errs[2].Detail).To(Equal("can only be set if the VPC is created"))
+		})
+	})
+
+	Describe("#ValidateServicePerimeter", func() {
+		var (
+			fldPath          *field.Path
+			servicePerimeter string
+			networks         *gcpv1alpha1.NetworkConfig
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+			servicePerimeter = "accessPolicies/123456/servicePerimeters/shoots"
+			networks = &gcpv1alpha1.NetworkConfig{
+				ServicePerimeter: &servicePerimeter,
+				CustomRoutes:     []gcpv1alpha1.Route{{Name: "default", Destination: "0.0.0.0/0"}},
+			}
+		})
+
+		It("should accept a network without service perimeter", func() {
+			Expect(ValidateServicePerimeter(&gcpv1alpha1.NetworkConfig{}, fldPath)).To(BeEmpty())
+		})
+
+		It("should accept a service perimeter next to less specific routes", func() {
+			Expect(ValidateServicePerimeter(networks, fldPath)).To(BeEmpty())
+		})
+
+		DescribeTable("should reject malformed service perimeters",
+			func(perimeter string) {
+				servicePerimeter = perimeter
+
+				errs := ValidateServicePerimeter(networks, fldPath)
+
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+				Expect(errs[0].Field).To(Equal("networks.servicePerimeter"))
+			},
+			Entry("empty", ""),
+			Entry("without access policy", "servicePerimeters/shoots"),
+			Entry("with non-numeric access policy", "accessPolicies/policy/servicePerimeters/shoots"),
+			Entry("with invalid perimeter name", "accessPolicies/123456/servicePerimeters/shoot-perimeter"),
+		)
+
+		It("should reject a custom route shadowing the restricted Google APIs", func() {
+			networks.CustomRoutes = append(networks.CustomRoutes, gcpv1alpha1.Route{Name: "restricted", Destination: "199.36.153.4/30"})
+
+			errs := ValidateServicePerimeter(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("networks.customRoutes[1].destination"))
+		})
+	})
+
+	Describe("#ValidateInternalSubnetRequired", func() {
+		var (
+			fldPath  *field.Path
+			networks *gcpv1alpha1.NetworkConfig
+		)
+
+		BeforeEach(func() {
+			purpose := gcpv1alpha1.SubnetPurpose("ilb")
+			fldPath = field.NewPath("networks")
+			networks = &gcpv1alpha1.NetworkConfig{InternalPurpose: &purpose}
+		})
+
+		It("should accept internal load balancer labels with an internal subnet", func() {
+			internal := gardencorev1alpha1.CIDR("10.251.0.0/16")
+			networks.Internal = &internal
+
+			Expect(ValidateInternalSubnetRequired(networks, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject internal load balancer labels without an internal subnet", func() {
+			networks.InternalProxyOnly = true
+
+			errs := ValidateInternalSubnetRequired(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+			Expect(errs[0].Field).To(Equal("networks.internal"))
+			Expect(errs[0].Detail).To(Equal("an internal subnet is required by networks.internalPurpose, networks.internalProxyOnly"))
+		})
+	})
+
+	Describe("#ValidateProjectMatch", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("serviceAccount", "project")
+		})
+
+		It("should accept a matching project", func() {
+			project := "my-project"
+
+			Expect(ValidateProjectMatch(&project, "my-project", false, fldPath)).To(BeEmpty())
+		})
+
+		It("should accept an unspecified project", func() {
+			Expect(ValidateProjectMatch(nil, "my-project", false, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject a differing project if cross-project usage is not allowed", func() {
+			project := "other-project"
+
+			errs := ValidateProjectMatch(&project, "my-project", false, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("serviceAccount.project"))
+			Expect(errs[0].Detail).To(ContainSubstring("my-project"))
+		})
+
+		It("should accept a differing project if cross-project usage is allowed", func() {
+			project := "other-project"
+
+			Expect(ValidateProjectMatch(&project, "my-project", true, fldPath)).To(BeEmpty())
+		})
+	})
+
+	Describe("#ValidateTimeouts", func() {
+		It("should report non-positive timeouts in the order of their names", func() {
+			errs := ValidateTimeouts(&gcpv1alpha1.Timeouts{
+				Request: &metav1.Duration{Duration: -1},
+				Create:  &metav1.Duration{Duration: 0},
+				Update:  &metav1.Duration{Duration: 1},
+				Delete:  &metav1.Duration{Duration: -1},
+			}, field.NewPath("timeouts"))
+
+			Expect(errs).To(HaveLen(3))
+			Expect(errs[0].Field).To(Equal("timeouts.create"))
+			Expect(errs[1].Field).To(Equal("timeouts.delete"))
+			Expect(errs[2].Field).To(Equal("timeouts.request"))
+		})
+	})
+
+	Describe("#ValidateProjectID", func() {
+		It("should accept a valid project ID", func() {
+			Expect(ValidateProjectID("my-project-1", field.NewPath("project"))).To(BeEmpty())
+		})
+
+		It("should reject invalid project IDs", func() {
+			for _, projectID := range []string{"proj", "1project", "project-", "My-Project"} {
+				Expect(ValidateProjectID(projectID, field.NewPath("project"))).To(HaveLen(1), projectID)
+			}
+		})
+	})
+
+	Describe("#ValidateFlowLogs", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks", "flowLogs")
+		})
+
+		It("should accept the supported export destinations", func() {
+			for _, destination := range []string{
+				"storage.googleapis.com/flow-logs",
+				"bigquery.googleapis.com/projects/my-project/datasets/flow_logs",
+				"pubsub.googleapis.com/projects/my-project/topics/flow-logs",
+				"logging.googleapis.com/projects/my-project/locations/global/buckets/flow-logs",
+			} {
+				d := destination
+				Expect(ValidateFlowLogs(&gcpv1alpha1.FlowLogs{ExportDestination: &d}, fldPath)).To(BeEmpty(), destination)
+			}
+		})
+
+		It("should reject malformed export destinations", func() {
+			for _, destination := range []string{
+				"gs://flow-logs",
+				"storage.googleapis.com/",
+				"bigquery.googleapis.com/projects/my-project/datasets/flow-logs",
+				"pubsub.googleapis.com/topics/flow-logs",
+				"logging.googleapis.com/projects/my-project/buckets/flow-logs",
+			} {
+				d := destination
+				errs := ValidateFlowLogs(&gcpv1alpha1.FlowLogs{ExportDestination: &d}, fldPath)
+
+				Expect(errs).To(HaveLen(1), destination)
+				Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+				Expect(errs[0].Field).To(Equal("networks.flowLogs.exportDestination"))
+			}
+		})
+
+		It("should accept partitioned tables for a BigQuery dataset", func() {
+			destination := "bigquery.googleapis.com/projects/my-project/datasets/flow_logs"
+
+			Expect(ValidateFlowLogs(&gcpv1alpha1.FlowLogs{ExportDestination: &destination, BigQueryPartitionedTables: true}, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid partitioned tables for other export destinations", func() {
+			destination := "storage.googleapis.com/flow-logs"
+
+			errs := ValidateFlowLogs(&gcpv1alpha1.FlowLogs{ExportDestination: &destination, BigQueryPartitionedTables: true}, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[0].Field).To(Equal("networks.flowLogs.bigQueryPartitionedTables"))
+		})
+	})
+
+	Describe("#ValidateDefaultFlowLogsAggregationInterval", func() {
+		It("should accept an empty or supported interval", func() {
+			Expect(ValidateDefaultFlowLogsAggregationInterval("")).To(Succeed())
+			Expect(ValidateDefaultFlowLogsAggregationInterval("INTERVAL_15_MIN")).To(Succeed())
+		})
+
+		It("should reject an unsupported interval", func() {
+			Expect(ValidateDefaultFlowLogsAggregationInterval("INTERVAL_1_SEC")).NotTo(Succeed())
+		})
+	})
+
+	Describe("#ValidateFlowLogsExportFilters", func() {
+		var (
+			networks *gcpv1alpha1.NetworkConfig
+			fldPath  *field.Path
+		)
+
+		BeforeEach(func() {
+			destination := "storage.googleapis.com/flow-logs"
+			internal := gardencorev1alpha1.CIDR("10.251.0.0/16")
+			networks = &gcpv1alpha1.NetworkConfig{
+				Worker:   gardencorev1alpha1.CIDR("10.250.0.0/16"),
+				Internal: &internal,
+				FlowLogs: &gcpv1alpha1.FlowLogs{ExportDestination: &destination},
+			}
+			fldPath = field.NewPath("networks")
+		})
+
+		It("should accept filters for the nodes and the internal subnet", func() {
+			networks.FlowLogs.ExportFilters = map[gcpv1alpha1.SubnetPurpose]string{
+				gcpv1alpha1.PurposeNodes:    `jsonPayload.reporter="SRC" AND (jsonPayload.connection.dest_port=443 OR jsonPayload.src_instance.vm_name:"(")`,
+				gcpv1alpha1.PurposeInternal: "jsonPayload.connection.protocol=6",
+			}
+
+			Expect(ValidateFlowLogsExportFilters(networks, fldPath)).To(BeEmpty())
+		})
+
+		DescribeTable("should validate each filter independently",
+			func(filter, fieldPath string) {
+				networks.FlowLogs.ExportFilters = map[gcpv1alpha1.SubnetPurpose]string{
+					gcpv1alpha1.PurposeNodes:    "jsonPayload.connection.protocol=6",
+					gcpv1alpha1.PurposeInternal: filter,
+				}
+
+				errs := ValidateFlowLogsExportFilters(networks, fldPath)
+
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+				Expect(errs[0].Field).To(Equal(fieldPath))
+			},
+			Entry("empty", " ", "networks.flowLogs.exportFilters[internal]"),
+			Entry("unbalanced parentheses", "(jsonPayload.connection.protocol=6", "networks.flowLogs.exportFilters[internal]"),
+			Entry("unbalanced quotes", `jsonPayload.reporter="SRC`, "networks.flowLogs.exportFilters[internal]"),
+		)
+
+		It("should forbid the filter of the internal subnet if it is proxy-only", func() {
+			networks.InternalProxyOnly = true
+			networks.FlowLogs.ExportFilters = map[gcpv1alpha1.SubnetPurpose]string{gcpv1alpha1.PurposeInternal: "jsonPayload.connection.protocol=6"}
+
+			errs := ValidateFlowLogsExportFilters(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[0].Field).To(Equal("networks.flowLogs.exportFilters[internal]"))
+		})
+
+		It("should forbid filters without export destination", func() {
+			networks.FlowLogs.ExportDestination = nil
+			networks.FlowLogs.ExportFilters = map[gcpv1alpha1.SubnetPurpose]string{gcpv1alpha1.PurposeNodes: "jsonPayload.connection.protocol=6"}
+
+			errs := ValidateFlowLogsExportFilters(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[0].Field).To(Equal("networks.flowLogs.exportFilters"))
+		})
+	})
+
+	Describe("#ValidateServiceAccountEmail", func() {
+		It("should accept the emails of user-managed and Compute Engine default service accounts", func() {
+			for _, email := range []string{
+				"gardener@my-project.iam.gserviceaccount.com",
+				"123456789012-compute@developer.gserviceaccount.com",
+			} {
+				Expect(ValidateServiceAccountEmail(email)).To(Succeed(), email)
+			}
+		})
+
+		It("should reject malformed emails", func() {
+			for _, email := range []string{
+				"gardener",
+				"gardener@my-project.iam.gserviceacount.com",
+				"gardener@my-project.gserviceaccount.com",
+				"Gardener@my-project.iam.gserviceaccount.com",
+				"project-compute@developer.gserviceaccount.com",
+			} {
+				Expect(ValidateServiceAccountEmail(email)).NotTo(Succeed(), email)
+			}
+		})
+	})
+
+	Describe("#ValidateCloudNATPorts", func() {
+		var (
+			fldPath  *field.Path
+			cloudNAT *gcpv1alpha1.CloudNAT
+		)
+
+		BeforeEach(func() {
+			var (
+				minPortsPerVM               int32 = 64
+				maxPortsPerVM               int32 = 1024
+				enableDynamicPortAllocation       = true
+			)
+			fldPath = field.NewPath("cloudNAT")
+			cloudNAT = &gcpv1alpha1.CloudNAT{
+				MinPortsPerVM:               &minPortsPerVM,
+				MaxPortsPerVM:               &maxPortsPerVM,
+				EnableDynamicPortAllocation: &enableDynamicPortAllocation,
+			}
+		})
+
+		It("should accept a valid dynamic port allocation", func() {
+			Expect(ValidateCloudNATPorts(cloudNAT, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject a minimum greater than the maximum", func() {
+			var minPortsPerVM int32 = 2048
+			cloudNAT.MinPortsPerVM = &minPortsPerVM
+
+			errs := ValidateCloudNATPorts(cloudNAT, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("cloudNAT.minPortsPerVM"))
+		})
+
+		It("should reject a maximum without dynamic port allocation", func() {
+			cloudNAT.EnableDynamicPortAllocation = nil
+
+			errs := ValidateCloudNATPorts(cloudNAT, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[0].Field).To(Equal("cloudNAT.maxPortsPerVM"))
+		})
+
+		It("should reject a minimum that is not a power of two with dynamic port allocation", func() {
+			var minPortsPerVM int32 = 100
+			cloudNAT.MinPortsPerVM = &minPortsPerVM
+
+			errs := ValidateCloudNATPorts(cloudNAT, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Detail).To(ContainSubstring("power of two"))
+		})
+
+		It("should accept a minimum that is a power of two without dynamic port allocation", func() {
+			var minPortsPerVM int32 = 128
+			cloudNAT = &gcpv1alpha1.CloudNAT{MinPortsPerVM: &minPortsPerVM}
+
+			Expect(ValidateCloudNATPorts(cloudNAT, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject a minimum that is not a power of two without dynamic port allocation", func() {
+			var minPortsPerVM int32 = 96
+			cloudNAT = &gcpv1alpha1.CloudNAT{MinPortsPerVM: &minPortsPerVM}
+
+			errs := ValidateCloudNATPorts(cloudNAT, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("cloudNAT.minPortsPerVM"))
+			Expect(errs[0].Detail).To(Equal("must be a power of two"))
+		})
+
+		It("should reject numbers of ports out of range", func() {
+			var maxPortsPerVM int32 = 100000
+			cloudNAT.MaxPortsPerVM = &maxPortsPerVM
+
+			errs := ValidateCloudNATPorts(cloudNAT, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("cloudNAT.maxPortsPerVM"))
+		})
+	})
+
+	Describe("#ValidateCloudNATNetworkTier", func() {
+		It("should accept the supported network tiers", func() {
+			for _, tier := range []gcpv1alpha1.NetworkTier{gcpv1alpha1.NetworkTierPremium, gcpv1alpha1.NetworkTierStandard} {
+				networkTier := tier
+				Expect(ValidateCloudNATNetworkTier(&gcpv1alpha1.CloudNAT{NetworkTier: &networkTier}, field.NewPath("cloudNAT"))).To(BeEmpty())
+			}
+		})
+
+		It("should reject an unsupported network tier", func() {
+			networkTier := gcpv1alpha1.NetworkTier("premium")
+
+			errs := ValidateCloudNATNetworkTier(&gcpv1alpha1.CloudNAT{NetworkTier: &networkTier}, field.NewPath("cloudNAT"))
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("cloudNAT.networkTier"))
+		})
+	})
+
+	Describe("#ValidateCloudNATIPCounts", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("cloudNAT")
+		})
+
+		It("should accept numbers of IPs within range", func() {
+			var ipCount, gatewayIPCount int32 = 1, MaxNATIPCount
+			cloudNAT := &gcpv1alpha1.CloudNAT{
+				IPCount:  &ipCount,
+				Gateways: []gcpv1alpha1.CloudNATGateway{{Name: "internal", IPCount: &gatewayIPCount}},
+			}
+
+			Expect(ValidateCloudNATIPCounts(cloudNAT, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject numbers of IPs out of range", func() {
+			var ipCount, gatewayIPCount int32 = 0, MaxNATIPCount + 1
+			cloudNAT := &gcpv1alpha1.CloudNAT{
+				IPCount:  &ipCount,
+				Gateways: []gcpv1alpha1.CloudNATGateway{{Name: "internal", IPCount: &gatewayIPCount}},
+			}
+
+			errs := ValidateCloudNATIPCounts(cloudNAT, fldPath)
+
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Field).To(Equal("cloudNAT.ipCount"))
+			Expect(errs[1].Field).To(Equal("cloudNAT.gateways[0].ipCount"))
+		})
+	})
+
+	Describe("#ValidateWorkloadIdentityUsers", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("serviceAccount")
+		})
+
+		It("should accept valid Kubernetes service account references", func() {
+			Expect(ValidateWorkloadIdentityUsers(&gcpv1alpha1.ServiceAccountConfig{
+				WorkloadIdentityUsers: []string{"kube-system/foo", "garden/bar.baz"},
+			}, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject malformed and duplicate references", func() {
+			errs := ValidateWorkloadIdentityUsers(&gcpv1alpha1.ServiceAccountConfig{
+				WorkloadIdentityUsers: []string{"foo", "Kube-System/foo", "garden/bar", "garden/bar"},
+			}, fldPath)
+
+			Expect(errs).To(HaveLen(3))
+			Expect(errs[0].Field).To(Equal("serviceAccount.workloadIdentityUsers[0]"))
+			Expect(errs[1].Detail).To(ContainSubstring("namespace"))
+			Expect(errs[2].Type).To(Equal(field.ErrorTypeDuplicate))
+		})
+	})
+
+	Describe("#ValidateSubnetNames", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("subnets")
+		})
+
+		It("should accept distinct subnet names", func() {
+			Expect(ValidateSubnetNames([]gcpv1alpha1.Subnet{
+				{Purpose: gcpv1alpha1.PurposeNodes, Name: "shoot--foo--bar-nodes"},
+				{Purpose: gcpv1alpha1.PurposeInternal, Name: "shoot--foo--bar-internal"},
+			}, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject duplicate subnet names", func() {
+			errs := ValidateSubnetNames([]gcpv1alpha1.Subnet{
+				{Purpose: gcpv1alpha1.PurposeNodes, Name: "shoot--foo--bar"},
+				{Purpose: gcpv1alpha1.PurposeInternal, Name: "shoot--foo--bar"},
+			}, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("subnets[1].name"))
+			Expect(errs[0].Detail).To(ContainSubstring("nodes"))
+		})
+
+		It("should reject unknown subnet purposes", func() {
+			errs := ValidateSubnetNames([]gcpv1alpha1.Subnet{
+				{Purpose: gcpv1alpha1.SubnetPurpose("bastion"), Name: "shoot--foo--bar-bastion"},
+			}, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeNotSupported))
+		})
+	})
+
+	Describe("#ValidateVPCName", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks", "vpc", "name")
+		})
+
+		It("should accept a non-reserved name", func() {
+			Expect(ValidateVPCName("shoot--foo--bar", fldPath)).To(BeEmpty())
+		})
+
+		It("should reject the name of the default network", func() {
+			errs := ValidateVPCName("default", fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("networks.vpc.name"))
+		})
+	})
+
+	Describe("#ValidateNetworkingMode", func() {
+		var (
+			fldPath  *field.Path
+			worker   gardencorev1alpha1.CIDR
+			services gardencorev1alpha1.CIDR
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+			worker = gardencorev1alpha1.CIDR("10.250.0.0/16")
+			services = gardencorev1alpha1.CIDR("100.64.0.0/13")
+		})
+
+		It("should accept the Cilium layout with a disjoint service range", func() {
+			mode := gcpv1alpha1.NetworkingModeCilium
+
+			Expect(ValidateNetworkingMode(&mode, worker, &services, fldPath)).To(BeEmpty())
+		})
+
+		It("should require a service range for the Cilium layout", func() {
+			mode := gcpv1alpha1.NetworkingModeCilium
+
+			errs := ValidateNetworkingMode(&mode, worker, nil, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+		})
+
+		It("should reject the alias IP layout", func() {
+			mode := gcpv1alpha1.NetworkingModeAliasIPs
+
+			errs := ValidateNetworkingMode(&mode, worker, &services, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeNotSupported))
+		})
+	})
+
+	Describe("#ValidateNetworkingConsistency", func() {
+		var (
+			config  *gcpv1alpha1.InfrastructureConfig
+			cluster *controller.Cluster
+		)
+
+		BeforeEach(func() {
+			config = &gcpv1alpha1.InfrastructureConfig{
+				Networks: gcpv1alpha1.NetworkConfig{AliasIPs: &gcpv1alpha1.AliasIPs{}},
+			}
+			cluster = &controller.Cluster{
+				Shoot: &gardenv1beta1.Shoot{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace:   "garden-foo",
+						Name:        "bar",
+						Annotations: map[string]string{gcp.NetworkingModeAnnotation: string(gcpv1alpha1.NetworkingModeAliasIPs)},
+					},
+				},
+			}
+		})
+
+		It("should accept a networking mode matching the config", func() {
+			Expect(ValidateNetworkingConsistency(config, cluster)).To(Succeed())
+		})
+
+		It("should accept a shoot not declaring a networking mode", func() {
+			cluster.Shoot.Annotations = nil
+
+			Expect(ValidateNetworkingConsistency(config, cluster)).To(Succeed())
+		})
+
+		It("should reject a networking mode not matching the config", func() {
+			config.Networks.AliasIPs = nil
+
+			Expect(ValidateNetworkingConsistency(config, cluster)).To(MatchError(ContainSubstring(`declares networking mode "AliasIPs" but the infrastructure config uses "Default"`)))
+		})
+
+		It("should reject an unsupported networking mode", func() {
+			cluster.Shoot.Annotations[gcp.NetworkingModeAnnotation] = "Calico"
+
+			Expect(ValidateNetworkingConsistency(config, cluster)).NotTo(Succeed())
+		})
+	})
+
+	Describe("#ValidateAliasIPPrefixLengths", func() {
+		var (
+			fldPath  *field.Path
+			pods     gardencorev1alpha1.CIDR
+			services gardencorev1alpha1.CIDR
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks", "aliasIPs")
+			pods = gardencorev1alpha1.CIDR("100.96.0.0/11")
+			services = gardencorev1alpha1.CIDR("100.64.0.0/13")
+		})
+
+		It("should accept prefix lengths fitting within the networks", func() {
+			var podsPrefixLength, servicesPrefixLength int32 = 11, 20
+
+			Expect(ValidateAliasIPPrefixLengths(&gcpv1alpha1.AliasIPs{
+				PodsPrefixLength:     &podsPrefixLength,
+				ServicesPrefixLength: &servicesPrefixLength,
+			}, &pods, &services, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject prefix lengths not fitting within the networks", func() {
+			var podsPrefixLength, servicesPrefixLength int32 = 10, 33
+
+			errs := ValidateAliasIPPrefixLengths(&gcpv1alpha1.AliasIPs{
+				PodsPrefixLength:     &podsPrefixLength,
+				ServicesPrefixLength: &servicesPrefixLength,
+			}, &pods, &services, fldPath)
+
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Field).To(Equal("networks.aliasIPs.podsPrefixLength"))
+			Expect(errs[1].Field).To(Equal("networks.aliasIPs.servicesPrefixLength"))
+		})
+	})
+
+	Describe("#ValidatePodServiceCIDRs", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+		})
+
+		DescribeTable("should validate that the pod and service CIDRs are disjoint",
+			func(pods, services string, expectedErrs int) {
+				podsCIDR, servicesCIDR := gardencorev1alpha1.CIDR(pods), gardencorev1alpha1.CIDR(services)
+
+				Expect(ValidatePodServiceCIDRs(&podsCIDR, &servicesCIDR, fldPath)).To(HaveLen(expectedErrs))
+			},
+			Entry("disjoint", "100.96.0.0/11", "100.64.0.0/13", 0),
+			Entry("adjacent", "100.96.0.0/11", "100.128.0.0/13", 0),
+			Entry("services within pods", "100.96.0.0/11", "100.100.0.0/16", 1),
+			Entry("pods within services", "100.100.0.0/16", "100.64.0.0/10", 1),
+		)
+
+		It("should reference both CIDRs", func() {
+			pods, services := gardencorev1alpha1.CIDR("100.96.0.0/11"), gardencorev1alpha1.CIDR("100.96.0.0/13")
+
+			errs := ValidatePodServiceCIDRs(&pods, &services, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("networks.services"))
+			Expect(errs[0].BadValue).To(Equal(services))
+			Expect(errs[0].Detail).To(ContainSubstring("pods CIDR 100.96.0.0/11"))
+		})
+
+		It("should skip unset CIDRs", func() {
+			pods := gardencorev1alpha1.CIDR("100.96.0.0/11")
+
+			Expect(ValidatePodServiceCIDRs(&pods, nil, fldPath)).To(BeEmpty())
+		})
+	})
+
+	Describe("#ValidateAliasIPRanges", func() {
+		var (
+			fldPath  *field.Path
+			worker   gardencorev1alpha1.CIDR
+			pods     gardencorev1alpha1.CIDR
+			services gardencorev1alpha1.CIDR
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+			worker = gardencorev1alpha1.CIDR("10.250.0.0/16")
+			pods = gardencorev1alpha1.CIDR("100.96.0.0/11")
+			services = gardencorev1alpha1.CIDR("100.64.0.0/13")
+		})
+
+		It("should accept disjoint ranges", func() {
+			Expect(ValidateAliasIPRanges(worker, &pods, &services, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject pods overlapping the worker range", func() {
+			pods = gardencorev1alpha1.CIDR("10.250.128.0/17")
+
+			errs := ValidateAliasIPRanges(worker, &pods, &services, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("networks.pods"))
+			Expect(errs[0].Detail).To(ContainSubstring("worker"))
+		})
+
+		It("should reject services overlapping the worker range", func() {
+			services = gardencorev1alpha1.CIDR("10.0.0.0/8")
+
+			errs := ValidateAliasIPRanges(worker, &pods, &services, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("networks.services"))
+			Expect(errs[0].Detail).To(ContainSubstring("worker"))
+		})
+
+		It("should reject services overlapping the pods range", func() {
+			services = gardencorev1alpha1.CIDR("100.100.0.0/16")
+
+			errs := ValidateAliasIPRanges(worker, &pods, &services, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("networks.services"))
+			Expect(errs[0].Detail).To(ContainSubstring("pods"))
+		})
+
+		It("should require the pods and services ranges", func() {
+			Expect(ValidateAliasIPRanges(worker, nil, nil, fldPath)).To(HaveLen(2))
+		})
+	})
+
+	Describe("#ValidateProxyOnlySubnet", func() {
+		var (
+			fldPath  *field.Path
+			networks *gcpv1alpha1.NetworkConfig
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+			networks = &gcpv1alpha1.NetworkConfig{
+				Worker:    gardencorev1alpha1.CIDR("10.250.0.0/16"),
+				ProxyOnly: &gcpv1alpha1.ProxyOnlySubnet{CIDR: gardencorev1alpha1.CIDR("10.251.0.0/23")},
+			}
+		})
+
+		It("should accept a disjoint proxy-only subnet without role", func() {
+			Expect(ValidateProxyOnlySubnet(networks, fldPath)).To(BeEmpty())
+		})
+
+		It("should accept the BACKUP role", func() {
+			role := gcpv1alpha1.ProxyOnlySubnetRoleBackup
+			networks.ProxyOnly.Role = &role
+
+			Expect(ValidateProxyOnlySubnet(networks, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject an unknown role", func() {
+			role := gcpv1alpha1.ProxyOnlySubnetRole("PASSIVE")
+			networks.ProxyOnly.Role = &role
+
+			errs := ValidateProxyOnlySubnet(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeNotSupported))
+			Expect(errs[0].Field).To(Equal("networks.proxyOnly.role"))
+		})
+
+		It("should reject a proxy-only subnet overlapping the worker range", func() {
+			networks.ProxyOnly.CIDR = gardencorev1alpha1.CIDR("10.250.128.0/23")
+
+			errs := ValidateProxyOnlySubnet(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("networks.proxyOnly.cidr"))
+			Expect(errs[0].Detail).To(ContainSubstring("worker"))
+		})
+	})
+
+	Describe("#ValidatePrivateServiceConnectSubnet", func() {
+		var (
+			fldPath  *field.Path
+			networks *gcpv1alpha1.NetworkConfig
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+			pscCIDR := gardencorev1alpha1.CIDR("10.252.0.0/24")
+			networks = &gcpv1alpha1.NetworkConfig{
+				Worker:                gardencorev1alpha1.CIDR("10.250.0.0/16"),
+				ProxyOnly:             &gcpv1alpha1.ProxyOnlySubnet{CIDR: gardencorev1alpha1.CIDR("10.251.0.0/23")},
+				PrivateServiceConnect: &pscCIDR,
+			}
+		})
+
+		It("should accept a disjoint subnet", func() {
+			Expect(ValidatePrivateServiceConnectSubnet(networks, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject a subnet overlapping the proxy-only subnet", func() {
+			pscCIDR := gardencorev1alpha1.CIDR("10.251.1.0/24")
+			networks.PrivateServiceConnect = &pscCIDR
+
+			errs := ValidatePrivateServiceConnectSubnet(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("networks.privateServiceConnect"))
+			Expect(errs[0].Detail).To(ContainSubstring("proxy-only"))
+		})
+	})
+
+	Describe("#ValidateInternalSubnetRegion", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks", "internalRegion")
+		})
+
+		It("should accept matching regions", func() {
+			Expect(ValidateInternalSubnetRegion("europe-west1", "europe-west1", false, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject differing regions if multiple regions are not allowed", func() {
+			errs := ValidateInternalSubnetRegion("europe-west4", "europe-west1", false, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("networks.internalRegion"))
+			Expect(errs[0].Detail).To(ContainSubstring("europe-west1"))
+		})
+
+		It("should accept differing regions if multiple regions are allowed", func() {
+			Expect(ValidateInternalSubnetRegion("europe-west4", "europe-west1", true, fldPath)).To(BeEmpty())
+		})
+	})
+
+	Describe("#ValidateDisasterRecoverySubnet", func() {
+		var (
+			fldPath  *field.Path
+			networks *gcpv1alpha1.NetworkConfig
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+			networks = &gcpv1alpha1.NetworkConfig{
+				Worker: gardencorev1alpha1.CIDR("10.250.0.0/16"),
+				DisasterRecovery: &gcpv1alpha1.DisasterRecoverySubnet{
+					Region: "europe-west4",
+					CIDR:   gardencorev1alpha1.CIDR("10.251.0.0/16"),
+				},
+			}
+		})
+
+		It("should accept a disjoint subnet in another region", func() {
+			Expect(ValidateDisasterRecoverySubnet(networks, "europe-west1", fldPath)).To(BeEmpty())
+		})
+
+		It("should reject a subnet in the primary region", func() {
+			errs := ValidateDisasterRecoverySubnet(networks, "europe-west4", fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("networks.disasterRecovery.region"))
+		})
+
+		It("should require the secondary region", func() {
+			networks.DisasterRecovery.Region = ""
+
+			errs := ValidateDisasterRecoverySubnet(networks, "europe-west1", fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+		})
+
+		It("should reject a subnet overlapping the worker subnet", func() {
+			networks.DisasterRecovery.CIDR = gardencorev1alpha1.CIDR("10.250.128.0/17")
+
+			errs := ValidateDisasterRecoverySubnet(networks, "europe-west1", fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("networks.disasterRecovery.cidr"))
+			Expect(errs[0].Detail).To(ContainSubstring("worker"))
+		})
+	})
+
+	Describe("#ValidateEgressApplianceSubnet", func() {
+		var (
+			fldPath  *field.Path
+			networks *gcpv1alpha1.NetworkConfig
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+			networks = &gcpv1alpha1.NetworkConfig{
+				Worker: gardencorev1alpha1.CIDR("10.250.0.0/16"),
+				EgressAppliance: &gcpv1alpha1.EgressApplianceSubnet{
+					CIDR:      gardencorev1alpha1.CIDR("10.251.0.0/24"),
+					NextHopIP: "10.251.0.10",
+				},
+			}
+		})
+
+		It("should accept a disjoint subnet containing the next hop", func() {
+			Expect(ValidateEgressApplianceSubnet(networks, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject a subnet overlapping the worker subnet", func() {
+			networks.EgressAppliance.CIDR = gardencorev1alpha1.CIDR("10.250.128.0/24")
+			networks.EgressAppliance.NextHopIP = "10.250.128.10"
+
+			errs := ValidateEgressApplianceSubnet(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("networks.egressAppliance.cidr"))
+			Expect(errs[0].Detail).To(ContainSubstring("worker"))
+		})
+
+		It("should reject a next hop outside of the subnet", func() {
+			networks.EgressAppliance.NextHopIP = "10.250.0.10"
+
+			errs := ValidateEgressApplianceSubnet(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("networks.egressAppliance.nextHopIP"))
+		})
+
+		It("should reject a priority out of range", func() {
+			priority := MaxRoutePriority + 1
+			networks.EgressAppliance.Priority = &priority
+
+			errs := ValidateEgressApplianceSubnet(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("networks.egressAppliance.priority"))
+		})
+
+		It("should reject duplicate destinations and destinations within a subnet", func() {
+			networks.EgressAppliance.Destinations = []gardencorev1alpha1.CIDR{"192.168.0.0/16", "192.168.0.0/16", "10.250.1.0/24"}
+
+			errs := ValidateEgressApplianceSubnet(networks, fldPath)
+
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeDuplicate))
+			Expect(errs[0].Field).To(Equal("networks.egressAppliance.destinations[1]"))
+			Expect(errs[1].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[1].Field).To(Equal("networks.egressAppliance.destinations[2]"))
+			Expect(errs[1].Detail).To(ContainSubstring("worker"))
+		})
+
+		It("should accept destinations containing a subnet", func() {
+			networks.EgressAppliance.Destinations = []gardencorev1alpha1.CIDR{"10.0.0.0/8"}
+
+			Expect(ValidateEgressApplianceSubnet(networks, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject destinations of custom routes", func() {
+			nextHopIP := "10.250.0.5"
+			networks.CustomRoutes = []gcpv1alpha1.Route{{Name: "on-prem", Destination: "0.0.0.0/0", NextHopIP: &nextHopIP}}
+
+			errs := ValidateEgressApplianceSubnet(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+			Expect(errs[0].Field).To(Equal("networks.egressAppliance.destinations"))
+
+			networks.EgressAppliance.Destinations = []gardencorev1alpha1.CIDR{"0.0.0.0/0"}
+
+			errs = ValidateEgressApplianceSubnet(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("networks.egressAppliance.destinations[0]"))
+			Expect(errs[0].Detail).To(ContainSubstring("on-prem"))
+		})
+
+		It("should reject custom routes named like the steering routes", func() {
+			nextHopIP := "10.250.0.5"
+			networks.CustomRoutes = []gcpv1alpha1.Route{{Name: "egress-appliance-0", Destination: "192.168.0.0/16", NextHopIP: &nextHopIP}}
+
+			errs := ValidateEgressApplianceSubnet(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("networks.customRoutes[0].name"))
+		})
+	})
+
+	Describe("#ValidateControlPlanePeering", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+		})
+
+		DescribeTable("peering ranges",
+			func(cidr string, valid bool) {
+				peering := gardencorev1alpha1.CIDR(cidr)
+				internal := gardencorev1alpha1.CIDR("10.251.0.0/16")
+				networks := &gcpv1alpha1.NetworkConfig{
+					Worker:              gardencorev1alpha1.CIDR("10.250.0.0/16"),
+					Internal:            &internal,
+					ControlPlanePeering: &peering,
+				}
+
+				errs := ValidateControlPlanePeering(networks, fldPath)
+
+				if valid {
+					Expect(errs).To(BeEmpty())
+					return
+				}
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+				Expect(errs[0].Field).To(Equal("networks.controlPlanePeering"))
+			},
+			Entry("disjoint range", "10.252.0.0/20", true),
+			Entry("range of the largest prefix length", "10.252.0.0/24", true),
+			Entry("range overlapping the worker subnet", "10.250.16.0/20", false),
+			Entry("range overlapping the internal subnet", "10.251.0.0/24", false),
+			Entry("too small range", "10.252.0.0/25", false),
+			Entry("invalid range", "10.252.0.0", false),
+		)
+	})
+
+	Describe("#ValidateMTU", func() {
+		var (
+			fldPath  *field.Path
+			networks *gcpv1alpha1.NetworkConfig
+		)
+
+		BeforeEach(func() {
+			var mtu int32 = 1500
+			fldPath = field.NewPath("networks")
+			networks = &gcpv1alpha1.NetworkConfig{MTU: &mtu}
+		})
+
+		It("should accept an MTU within the default range", func() {
+			Expect(ValidateMTU(networks, DefaultMTURange, fldPath)).To(BeEmpty())
+		})
+
+		It("should accept an unset MTU", func() {
+			networks.MTU = nil
+
+			Expect(ValidateMTU(networks, MTURange{Min: 1460, Max: 1460}, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject an MTU outside of the default range", func() {
+			var mtu int32 = 9000
+			networks.MTU = &mtu
+
+			errs := ValidateMTU(networks, DefaultMTURange, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("networks.mtu"))
+		})
+
+		It("should reject an MTU outside of the allowed range", func() {
+			errs := ValidateMTU(networks, MTURange{Min: 1300, Max: 1460}, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Detail).To(Equal("must be between 1300 and 1460"))
+		})
+	})
+
+	Describe("#ValidateFirewallPriorities", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks", "firewall")
+		})
+
+		It("should accept an unset firewall configuration", func() {
+			Expect(ValidateFirewallPriorities(nil, fldPath)).To(BeEmpty())
+		})
+
+		DescribeTable("priority bases",
+			func(priorityBase int32, denyAllEgress bool, valid bool) {
+				firewall := &gcpv1alpha1.FirewallConfig{DenyAllEgress: denyAllEgress, PriorityBase: &priorityBase}
+
+				errs := ValidateFirewallPriorities(firewall, fldPath)
+
+				if valid {
+					Expect(errs).To(BeEmpty())
+					return
+				}
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+				Expect(errs[0].Field).To(Equal("networks.firewall.priorityBase"))
+			},
+			Entry("lowering all priorities", int32(-1000), true, true),
+			Entry("raising the allow priorities", int32(60000), false, true),
+			Entry("raising the deny-all egress priority out of range", int32(2), true, false),
+			Entry("lowering the allow priorities out of range", int32(-1001), false, false),
+		)
+	})
+
+	Describe("#ValidateUnmanagedFirewall", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks", "firewall")
+		})
+
+		It("should accept an unmanaged firewall without further options", func() {
+			Expect(ValidateUnmanagedFirewall(&gcpv1alpha1.FirewallConfig{Unmanaged: true}, fldPath)).To(BeEmpty())
+		})
+
+		It("should accept options of a managed firewall", func() {
+			Expect(ValidateUnmanagedFirewall(&gcpv1alpha1.FirewallConfig{DenyAllEgress: true}, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid options of the created rules for an unmanaged firewall", func() {
+			var priorityBase int32 = 100
+			firewall := &gcpv1alpha1.FirewallConfig{
+				Unmanaged:                  true,
+				DisableAllowInternalAccess: true,
+				DisableAllowHealthChecks:   true,
+				PriorityBase:               &priorityBase,
+			}
+
+			errs := ValidateUnmanagedFirewall(firewall, fldPath)
+
+			Expect(errs).To(HaveLen(3))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[0].Field).To(Equal("networks.firewall.disableAllowInternalAccess"))
+			Expect(errs[1].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[1].Field).To(Equal("networks.firewall.disableAllowHealthChecks"))
+			Expect(errs[2].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[2].Field).To(Equal("networks.firewall.priorityBase"))
+		})
+	})
+
+	Describe("#ValidateSecondaryRangeCount", func() {
+		var (
+			fldPath *field.Path
+			ranges  func(n int) []string
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("secondaryRanges")
+			ranges = func(n int) []string {
+				names := make([]string, 0, n)
+				for i := 0; i < n; i++ {
+					names = append(names, fmt.Sprintf("range-%d", i))
+				}
+				return names
+			}
+		})
+
+		It("should accept subnets meeting the limit", func() {
+			Expect(ValidateSecondaryRangeCount(map[gcpv1alpha1.SubnetPurpose][]string{
+				gcpv1alpha1.PurposeNodes:    ranges(MaxSecondaryRangesPerSubnet),
+				gcpv1alpha1.PurposeInternal: nil,
+			}, fldPath)).To(BeEmpty())
+		})
+
+		It("should list the ranges exceeding the limit", func() {
+			errs := ValidateSecondaryRangeCount(map[gcpv1alpha1.SubnetPurpose][]string{
+				gcpv1alpha1.PurposeNodes: ranges(MaxSecondaryRangesPerSubnet + 2),
+			}, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("secondaryRanges[nodes]"))
+			Expect(errs[0].Detail).To(HaveSuffix("excess ranges: range-30, range-31"))
+		})
+	})
+
+	Describe("#ValidateSubnetIPFamilies", func() {
+		var (
+			fldPath  *field.Path
+			networks *gcpv1alpha1.NetworkConfig
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+			internal := gardencorev1alpha1.CIDR("fd00:10::/64")
+			networks = &gcpv1alpha1.NetworkConfig{
+				Worker:   gardencorev1alpha1.CIDR("10.250.0.0/16"),
+				Internal: &internal,
+			}
+		})
+
+		It("should accept IPv4 CIDRs", func() {
+			networks.Internal = nil
+			Expect(ValidateSubnetIPFamilies(networks, false, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject an IPv6 CIDR if dual-stack is disabled", func() {
+			errs := ValidateSubnetIPFamilies(networks, false, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("networks.internal"))
+		})
+
+		It("should accept an IPv6 CIDR if dual-stack is enabled", func() {
+			Expect(ValidateSubnetIPFamilies(networks, true, fldPath)).To(BeEmpty())
+		})
+	})
+
+	Describe("#ValidateStateRefresh", func() {
+		var (
+			fldPath      *field.Path
+			stateRefresh bool
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("stateRefresh")
+			stateRefresh = false
+		})
+
+		It("should accept a disabled state refresh without a Cloud NAT", func() {
+			Expect(ValidateStateRefresh(&stateRefresh, &gcpv1alpha1.NetworkConfig{}, fldPath)).To(BeEmpty())
+		})
+
+		It("should accept an enabled state refresh with a Cloud NAT", func() {
+			stateRefresh = true
+			Expect(ValidateStateRefresh(&stateRefresh, &gcpv1alpha1.NetworkConfig{CloudNAT: &gcpv1alpha1.CloudNAT{}}, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid a disabled state refresh with a Cloud NAT", func() {
+			errs := ValidateStateRefresh(&stateRefresh, &gcpv1alpha1.NetworkConfig{CloudNAT: &gcpv1alpha1.CloudNAT{}}, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[0].Field).To(Equal("stateRefresh"))
+		})
+	})
+
+	Describe("#ValidateSecondaryRangeNames", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("secondaryRanges")
+		})
+
+		It("should accept names without a reserved prefix", func() {
+			Expect(ValidateSecondaryRangeNames(map[gcpv1alpha1.SubnetPurpose][]string{
+				gcpv1alpha1.PurposeNodes: {"shoot--foo--bar-pods", "shoot--foo--bar-services"},
+			}, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject names with a reserved prefix", func() {
+			errs := ValidateSecondaryRangeNames(map[gcpv1alpha1.SubnetPurpose][]string{
+				gcpv1alpha1.PurposeNodes: {"shoot--foo--bar-pods", "gke-foo-services"},
+			}, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("secondaryRanges[nodes][1]"))
+		})
+	})
+
+	Describe("#ValidateCloudRouterASN", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("cloudRouter", "asn")
+		})
+
+		DescribeTable("private ASNs",
+			func(asn int64) {
+				Expect(ValidateCloudRouterASN(asn, fldPath)).To(BeEmpty())
+			},
+			Entry("lower bound of the 16-bit range", int64(64512)),
+			Entry("upper bound of the 16-bit range", int64(65534)),
+			Entry("lower bound of the 32-bit range", int64(4200000000)),
+			Entry("upper bound of the 32-bit range", int64(4294967294)),
+		)
+
+		DescribeTable("non-private ASNs",
+			func(asn int64) {
+				errs := ValidateCloudRouterASN(asn, fldPath)
+
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+				Expect(errs[0].Field).To(Equal("cloudRouter.asn"))
+			},
+			Entry("zero", int64(0)),
+			Entry("public 16-bit ASN", int64(15169)),
+			Entry("reserved 16-bit ASN", int64(65535)),
+			Entry("public 32-bit ASN", int64(4199999999)),
+			Entry("reserved 32-bit ASN", int64(4294967295)),
+			Entry("negative ASN", int64(-1)),
+		)
+	})
+
+	Describe("#ValidateCloudRouterBGP", func() {
+		var (
+			fldPath  *field.Path
+			networks *gcpv1alpha1.NetworkConfig
+			custom   = gcpv1alpha1.BGPAdvertiseModeCustom
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks", "cloudRouterBGP")
+			networks = &gcpv1alpha1.NetworkConfig{
+				CloudNAT:       &gcpv1alpha1.CloudNAT{},
+				CloudRouterBGP: &gcpv1alpha1.CloudRouterBGP{ASN: 64514},
+			}
+		})
+
+		It("should accept the default advertise mode", func() {
+			Expect(ValidateCloudRouterBGP(networks, fldPath)).To(BeEmpty())
+		})
+
+		It("should accept the custom advertise mode with advertised ranges", func() {
+			networks.CloudRouterBGP.AdvertiseMode = &custom
+			networks.CloudRouterBGP.AdvertisedIPRanges = []gardencorev1alpha1.CIDR{"10.252.0.0/20"}
+
+			Expect(ValidateCloudRouterBGP(networks, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject BGP if the Cloud Router is not created", func() {
+			networks.CloudNAT = nil
+
+			errs := ValidateCloudRouterBGP(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[0].Field).To(Equal("networks.cloudRouterBGP"))
+		})
+
+		It("should reject a non-private ASN", func() {
+			networks.CloudRouterBGP.ASN = 15169
+
+			errs := ValidateCloudRouterBGP(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("networks.cloudRouterBGP.asn"))
+		})
+
+		It("should reject advertised ranges in the default advertise mode", func() {
+			networks.CloudRouterBGP.AdvertisedIPRanges = []gardencorev1alpha1.CIDR{"10.252.0.0/20"}
+
+			errs := ValidateCloudRouterBGP(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[0].Field).To(Equal("networks.cloudRouterBGP.advertisedIPRanges"))
+		})
+
+		It("should require advertisements in the custom advertise mode", func() {
+			networks.CloudRouterBGP.AdvertiseMode = &custom
+
+			errs := ValidateCloudRouterBGP(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+			Expect(errs[0].Field).To(Equal("networks.cloudRouterBGP.advertisedIPRanges"))
+		})
+
+		It("should reject invalid and duplicate advertised ranges", func() {
+			networks.CloudRouterBGP.AdvertiseMode = &custom
+			networks.CloudRouterBGP.AdvertisedIPRanges = []gardencorev1alpha1.CIDR{"10.252.0.0/20", "10.252.0.0", "10.252.0.0/20"}
+
+			errs := ValidateCloudRouterBGP(networks, fldPath)
+
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("networks.cloudRouterBGP.advertisedIPRanges[1]"))
+			Expect(errs[1].Type).To(Equal(field.ErrorTypeDuplicate))
+			Expect(errs[1].Field).To(Equal("networks.cloudRouterBGP.advertisedIPRanges[2]"))
+		})
+
+		It("should reject an unsupported advertise mode", func() {
+			mode := gcpv1alpha1.BGPAdvertiseMode("ALL")
+			networks.CloudRouterBGP.AdvertiseMode = &mode
+
+			errs := ValidateCloudRouterBGP(networks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeNotSupported))
+			Expect(errs[0].Field).To(Equal("networks.cloudRouterBGP.advertiseMode"))
+		})
+	})
+
+	Describe("#ValidateInternalSubnetCapacity", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+		})
+
+		DescribeTable("internal CIDRs",
+			func(cidr string, minAddresses int, valid bool) {
+				internal := gardencorev1alpha1.CIDR(cidr)
+				networks := &gcpv1alpha1.NetworkConfig{Internal: &internal}
+
+				errs := ValidateInternalSubnetCapacity(networks, minAddresses, fldPath)
+
+				if valid {
+					Expect(errs).To(BeEmpty())
+					return
+				}
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+				Expect(errs[0].Field).To(Equal("networks.internal"))
+			},
+			Entry("sufficient CIDR", "10.251.0.0/27", DefaultMinInternalSubnetAddresses, true),
+			Entry("CIDR providing exactly the minimum", "10.251.0.0/28", 12, true),
+			Entry("undersized CIDR", "10.251.0.0/28", DefaultMinInternalSubnetAddresses, false),
+			Entry("invalid CIDR", "10.251.0.0", DefaultMinInternalSubnetAddresses, false),
+		)
+
+		It("should accept an unset internal subnet", func() {
+			Expect(ValidateInternalSubnetCapacity(&gcpv1alpha1.NetworkConfig{}, DefaultMinInternalSubnetAddresses, fldPath)).To(BeEmpty())
+		})
+	})
+
+	Describe("#ValidateNodeCIDRMaskSize", func() {
+		var (
+			fldPath *field.Path
+			pods    gardencorev1alpha1.CIDR
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks", "aliasIPs")
+			pods = "100.96.0.0/11"
+		})
+
+		DescribeTable("node CIDR mask sizes",
+			func(worker string, podsPrefixLength, maskSize int32, valid bool) {
+				aliasIPs := &gcpv1alpha1.AliasIPs{NodeCIDRMaskSize: &maskSize}
+				if podsPrefixLength > 0 {
+					aliasIPs.PodsPrefixLength = &podsPrefixLength
+				}
+
+				errs := ValidateNodeCIDRMaskSize(aliasIPs, gardencorev1alpha1.CIDR(worker), &pods, fldPath)
+
+				if valid {
+					Expect(errs).To(BeEmpty())
+					return
+				}
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+				Expect(errs[0].Field).To(Equal("networks.aliasIPs.nodeCIDRMaskSize"))
+			},
+			Entry("aligned worker subnet", "10.250.0.0/19", int32(0), int32(24), true),
+			Entry("worker subnet with more addresses than pod CIDRs", "10.250.0.0/16", int32(0), int32(24), false),
+			Entry("aligned worker subnet for a carved pods range", "10.250.0.0/20", int32(12), int32(24), true),
+			Entry("misaligned worker subnet for a carved pods range", "10.250.0.0/19", int32(12), int32(24), false),
+			Entry("mask size larger than the pods range", "10.250.0.0/19", int32(0), int32(10), false),
+			Entry("mask size without pod addresses", "10.250.0.0/19", int32(0), int32(32), false),
+		)
+
+		It("should accept an unset node CIDR mask size", func() {
+			Expect(ValidateNodeCIDRMaskSize(&gcpv1alpha1.AliasIPs{}, "10.250.0.0/16", &pods, fldPath)).To(BeEmpty())
+		})
+	})
+
+	Describe("#NodePodCIDRPrefixLength", func() {
+		DescribeTable("per-node pod CIDR slices",
+			func(maxPodsPerNode int32, prefixLength int) {
+				Expect(NodePodCIDRPrefixLength(maxPodsPerNode)).To(Equal(prefixLength))
+			},
+			Entry("Kubernetes default", int32(110), 24),
+			Entry("power of two", int32(64), 25),
+			Entry("few pods", int32(8), 28),
+			Entry("many pods", int32(256), 23),
+		)
+	})
+
+	Describe("#ValidatePodCIDRCapacity", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks", "pods")
+		})
+
+		DescribeTable("pod CIDRs",
+			func(pods string, maxPodsPerNode int32, nodes int, valid bool) {
+				errs := ValidatePodCIDRCapacity(gardencorev1alpha1.CIDR(pods), maxPodsPerNode, nodes, fldPath)
+
+				if valid {
+					Expect(errs).To(BeEmpty())
+					return
+				}
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+				Expect(errs[0].Field).To(Equal("networks.pods"))
+			},
+			Entry("sufficient pod CIDR", "100.96.0.0/11", int32(110), 8192, true),
+			Entry("pod CIDR for exactly the node count", "100.96.0.0/16", int32(110), 256, true),
+			Entry("undersized pod CIDR", "100.96.0.0/16", int32(110), 257, false),
+			Entry("undersized pod CIDR for many pods per node", "100.96.0.0/16", int32(256), 200, false),
+			Entry("pod CIDR smaller than a single node slice", "100.96.0.0/25", int32(110), 1, false),
+			Entry("invalid pod CIDR", "100.96.0.0", int32(110), 1, false),
+		)
+	})
+
+	Describe("#ValidatePodsSecondaryRangeCapacity", func() {
+		var (
+			fldPath *field.Path
+			pods    gardencorev1alpha1.CIDR
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks", "aliasIPs", "podsPrefixLength")
+			pods = gardencorev1alpha1.CIDR("100.96.0.0/11")
+		})
+
+		It("should accept a pods secondary range fitting the nodes", func() {
+			prefixLength := int32(16)
+
+			Expect(ValidatePodsSecondaryRangeCapacity(&gcpv1alpha1.AliasIPs{PodsPrefixLength: &prefixLength}, &pods, 110, 256, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject a pods secondary range too small for the nodes", func() {
+			prefixLength := int32(20)
+
+			errs := ValidatePodsSecondaryRangeCapacity(&gcpv1alpha1.AliasIPs{PodsPrefixLength: &prefixLength}, &pods, 110, 32, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+			Expect(errs[0].Field).To(Equal("networks.aliasIPs.podsPrefixLength"))
+			Expect(errs[0].BadValue).To(Equal(gardencorev1alpha1.CIDR("100.96.0.0/20")))
+		})
+
+		It("should default the pods secondary range to the pod network", func() {
+			Expect(ValidatePodsSecondaryRangeCapacity(&gcpv1alpha1.AliasIPs{}, &pods, 110, 8192, fldPath)).To(BeEmpty())
+		})
+	})
+
+	Describe("#ValidateInternalPurpose", func() {
+		It("should accept a custom purpose label", func() {
+			purpose := gcpv1alpha1.SubnetPurpose("ilb")
+
+			Expect(ValidateInternalPurpose(&gcpv1alpha1.NetworkConfig{InternalPurpose: &purpose}, field.NewPath("networks"))).To(BeEmpty())
+		})
+
+		It("should reject an empty purpose label", func() {
+			purpose := gcpv1alpha1.SubnetPurpose("")
+
+			errs := ValidateInternalPurpose(&gcpv1alpha1.NetworkConfig{InternalPurpose: &purpose}, field.NewPath("networks"))
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+			Expect(errs[0].Field).To(Equal("networks.internalPurpose"))
+		})
+
+		DescribeTable("should reject a reserved purpose label",
+			func(purpose gcpv1alpha1.SubnetPurpose) {
+				errs := ValidateInternalPurpose(&gcpv1alpha1.NetworkConfig{InternalPurpose: &purpose}, field.NewPath("networks"))
+
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+				Expect(errs[0].Field).To(Equal("networks.internalPurpose"))
+			},
+			Entry("nodes", gcpv1alpha1.PurposeNodes),
+			Entry("internal", gcpv1alpha1.PurposeInternal),
+			Entry("proxy-only", gcpv1alpha1.PurposeProxyOnly),
+			Entry("egress-appliance", gcpv1alpha1.PurposeEgressAppliance),
+		)
+	})
+
+	Describe("#ValidateSubnetPurposePrefix", func() {
+		It("should accept a prefix", func() {
+			prefix := "gardener-"
+
+			Expect(ValidateSubnetPurposePrefix(&gcpv1alpha1.NetworkConfig{SubnetPurposePrefix: &prefix}, field.NewPath("networks"))).To(BeEmpty())
+		})
+
+		It("should reject an empty prefix", func() {
+			prefix := ""
+
+			errs := ValidateSubnetPurposePrefix(&gcpv1alpha1.NetworkConfig{SubnetPurposePrefix: &prefix}, field.NewPath("networks"))
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeRequired))
+			Expect(errs[0].Field).To(Equal("networks.subnetPurposePrefix"))
+		})
+	})
+
+	Describe("#ValidateCustomRoutes", func() {
+		var (
+			fldPath *field.Path
+			routes  []gcpv1alpha1.Route
+		)
+
+		BeforeEach(func() {
+			var (
+				nextHopIP        = "10.250.0.5"
+				nextHopVPNTunnel = "https://www.googleapis.com/compute/v1/projects/foo/regions/bar/vpnTunnels/on-prem"
+				nextHopILB       = "projects/foo/regions/bar/forwardingRules/ilb"
+			)
+			fldPath = field.NewPath("customRoutes")
+			routes = []gcpv1alpha1.Route{
+				{Name: "appliance", Destination: "172.16.0.0/12", NextHopIP: &nextHopIP},
+				{Name: "on-prem", Destination: "192.168.0.0/16", NextHopVPNTunnel: &nextHopVPNTunnel},
+				{Name: "ilb", Destination: "10.0.0.0/8", NextHopILB: &nextHopILB},
+			}
+		})
+
+		It("should accept valid routes", func() {
+			Expect(ValidateCustomRoutes(routes, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject an invalid destination", func() {
+			routes[0].Destination = "172.16.0.0"
+
+			errs := ValidateCustomRoutes(routes, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("customRoutes[0].destination"))
+		})
+
+		It("should reject duplicate names", func() {
+			routes[1].Name = routes[0].Name
+
+			errs := ValidateCustomRoutes(routes, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeDuplicate))
+		})
+
+		It("should reject invalid next hop references", func() {
+			nextHopVPNTunnel := "on-prem"
+			routes[1].NextHopVPNTunnel = &nextHopVPNTunnel
+
+			errs := ValidateCustomRoutes(routes, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("customRoutes[1].nextHopVPNTunnel"))
+		})
+
+		It("should require exactly one next hop", func() {
+			routes[0].NextHopILB = routes[2].NextHopILB
+			routes[2].NextHopILB = nil
+
+			errs := ValidateCustomRoutes(routes, fldPath)
+
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[1].Type).To(Equal(field.ErrorTypeRequired))
+		})
+	})
+
+	Describe("#ValidateAgainstCloudProfile", func() {
+		var (
+			fldPath *field.Path
+			profile *gardenv1beta1.CloudProfile
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("spec")
+			profile = &gardenv1beta1.CloudProfile{
+				Spec: gardenv1beta1.CloudProfileSpec{
+					GCP: &gardenv1beta1.GCPProfile{
+						Constraints: gardenv1beta1.GCPConstraints{
+							Zones: []gardenv1beta1.Zone{
+								{Region: "europe-west1", Names: []string{"europe-west1-b"}},
+								{Region: "us-east1", Names: []string{"us-east1-c"}},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("should accept a region of the cloud profile", func() {
+			Expect(ValidateAgainstCloudProfile("us-east1", profile, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject a region that is not in the cloud profile", func() {
+			errs := ValidateAgainstCloudProfile("asia-east1", profile, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeNotSupported))
+			Expect(errs[0].Field).To(Equal("spec.region"))
+		})
+
+		It("should accept every region if the cloud profile has no zone constraints", func() {
+			Expect(ValidateAgainstCloudProfile("asia-east1", &gardenv1beta1.CloudProfile{}, fldPath)).To(BeEmpty())
+			Expect(ValidateAgainstCloudProfile("asia-east1", nil, fldPath)).To(BeEmpty())
+		})
+	})
+
+	Describe("#ValidateLabels", func() {
+		var fldPath *field.Path
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("labels")
+		})
+
+		It("should accept a compliant set of labels", func() {
+			labels := make(map[string]string, MaxLabels)
+			for i := 0; i < MaxLabels; i++ {
+				labels[fmt.Sprintf("key-%d", i)] = "value_1"
+			}
+
+			Expect(ValidateLabels(labels, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject too many labels", func() {
+			labels := make(map[string]string, MaxLabels+1)
+			for i := 0; i <= MaxLabels; i++ {
+				labels[fmt.Sprintf("key-%02d", i)] = "value"
+			}
+
+			errs := ValidateLabels(labels, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[0].Detail).To(ContainSubstring(fmt.Sprintf("key-%02d", MaxLabels)))
+		})
+
+		It("should reject invalid keys and values", func() {
+			errs := ValidateLabels(map[string]string{"Key": "value", "key": "Value"}, fldPath)
+
+			Expect(errs).To(HaveLen(2))
+		})
+	})
+
+	Describe("#MergeLabels", func() {
+		var (
+			fldPath        *field.Path
+			gardenerLabels map[string]string
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("labels")
+			gardenerLabels = map[string]string{"cluster": "shoot--foo--bar"}
+		})
+
+		It("should merge non-reserved user labels", func() {
+			merged, errs := MergeLabels(gardenerLabels, map[string]string{"team": "network"}, fldPath)
+
+			Expect(errs).To(BeEmpty())
+			Expect(merged).To(Equal(map[string]string{"cluster": "shoot--foo--bar", "team": "network"}))
+		})
+
+		It("should reject user labels using reserved keys", func() {
+			merged, errs := MergeLabels(gardenerLabels, map[string]string{"cluster": "other", "team": "network"}, fldPath)
+
+			Expect(merged).To(BeNil())
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[0].Field).To(Equal("labels[cluster]"))
+			Expect(errs[0].Detail).To(ContainSubstring(`"cluster" is reserved`))
+		})
+
+		It("should reject merged labels violating the GCP constraints", func() {
+			merged, errs := MergeLabels(gardenerLabels, map[string]string{"Team": "network"}, fldPath)
+
+			Expect(merged).To(BeNil())
+			Expect(errs).To(HaveLen(1))
+		})
+	})
+
+	Describe("#ValidateNetworkConfigUpdate", func() {
+		var (
+			fldPath     *field.Path
+			oldNetworks *gcpv1alpha1.NetworkConfig
+			newNetworks *gcpv1alpha1.NetworkConfig
+		)
+
+		BeforeEach(func() {
+			fldPath = field.NewPath("networks")
+			oldNetworks = &gcpv1alpha1.NetworkConfig{Worker: gardencorev1alpha1.CIDR("10.250.0.0/20")}
+			newNetworks = oldNetworks.DeepCopy()
+		})
+
+		It("should allow an unchanged worker CIDR", func() {
+			Expect(ValidateNetworkConfigUpdate(newNetworks, oldNetworks, fldPath)).To(BeEmpty())
+		})
+
+		It("should allow expanding the worker CIDR", func() {
+			newNetworks.Worker = gardencorev1alpha1.CIDR("10.250.0.0/16")
+
+			Expect(ValidateNetworkConfigUpdate(newNetworks, oldNetworks, fldPath)).To(BeEmpty())
+		})
+
+		It("should reject shrinking the worker CIDR", func() {
+			newNetworks.Worker = gardencorev1alpha1.CIDR("10.250.0.0/22")
+
+			Expect(ValidateNetworkConfigUpdate(newNetworks, oldNetworks, fldPath)).To(HaveLen(1))
+		})
+
+		It("should reject moving the worker CIDR to a different base", func() {
+			newNetworks.Worker = gardencorev1alpha1.CIDR("10.251.0.0/16")
+
+			errs := ValidateNetworkConfigUpdate(newNetworks, oldNetworks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Field).To(Equal("networks.worker"))
+		})
+
+		It("should reject making the internal subnet proxy-only", func() {
+			internal := gardencorev1alpha1.CIDR("10.251.0.0/24")
+			oldNetworks.Internal = &internal
+			newNetworks = oldNetworks.DeepCopy()
+			newNetworks.InternalProxyOnly = true
+
+			errs := ValidateNetworkConfigUpdate(newNetworks, oldNetworks, fldPath)
+
+			Expect(errs).To(HaveLen(1))
+			Expect(errs[0].Type).To(Equal(field.ErrorTypeForbidden))
+			Expect(errs[0].Field).To(Equal("networks.internalProxyOnly"))
+		})
+
+		It("should allow adding a proxy-only internal subnet", func() {
+			internal := gardencorev1alpha1.CIDR("10.251.0.0/24")
+			newNetworks.Internal = &internal
+			newNetworks.InternalProxyOnly = true
+
+			Expect(ValidateNetworkConfigUpdate(newNetworks, oldNetworks, fldPath)).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,3 @@
+//go:generate mockgen -package=infrastructure -destination=mocks.go github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/internal/infrastructure TerraformStateReader
+
+package infrastructure
@@ -15,14 +15,21 @@
 package internal
 
 import (
+	"fmt"
+
 	"github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/install"
 	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/yaml"
 )
 
+// infrastructureConfigKind is the kind of the GCP InfrastructureConfig.
+const infrastructureConfigKind = "InfrastructureConfig"
+
 var (
 	// Scheme is a scheme with the types relevant for GCP actuators.
 	Scheme *runtime.Scheme
@@ -34,16 +41,61 @@ func init() {
 	Scheme = runtime.NewScheme()
 	utilruntime.Must(install.AddToScheme(Scheme))
 
-	decoder = serializer.NewCodecFactory(Scheme).UniversalDecoder()
+	codecs := serializer.NewCodecFactory(Scheme)
+	decoder = codecs.UniversalDecoder()
 }
 
 // InfrastructureConfigFromInfrastructure extracts the InfrastructureConfig from the
 // ProviderConfig section of the given Infrastructure.
 func InfrastructureConfigFromInfrastructure(infra *extensionsv1alpha1.Infrastructure) (*gcpv1alpha1.InfrastructureConfig, error) {
+	return DecodeInfrastructureConfig(infra.Spec.ProviderConfig.Raw)
+}
+
+// DecodeInfrastructureConfig decodes the given raw JSON or YAML bytes into an InfrastructureConfig.
+// The apiVersion and kind of the raw config are validated before decoding it.
+func DecodeInfrastructureConfig(raw []byte) (*gcpv1alpha1.InfrastructureConfig, error) {
+	typeMeta := metav1.TypeMeta{}
+	if err := yaml.Unmarshal(raw, &typeMeta); err != nil {
+		return nil, err
+	}
+	if err := ValidateInfrastructureConfigTypeMeta(typeMeta); err != nil {
+		return nil, err
+	}
+
 	config := &gcpv1alpha1.InfrastructureConfig{}
-	if _, _, err := decoder.Decode(infra.Spec.ProviderConfig.Raw, nil, config); err != nil {
+	if _, _, err := decoder.Decode(raw, nil, config); err != nil {
 		return nil, err
 	}
 
 	return config, nil
 }
+
+// ValidateInfrastructureConfigTypeMeta validates that the given TypeMeta denotes a GCP InfrastructureConfig.
+func ValidateInfrastructureConfigTypeMeta(typeMeta metav1.TypeMeta) error {
+	expected := gcpv1alpha1.SchemeGroupVersion.WithKind(infrastructureConfigKind)
+	if gvk := typeMeta.GroupVersionKind(); gvk != expected {
+		return fmt.Errorf("expected provider config with apiVersion %q and kind %q but got apiVersion %q and kind %q",
+			expected.GroupVersion().String(), expected.Kind, typeMeta.APIVersion, typeMeta.Kind)
+	}
+
+	return nil
+}
+
+// InfrastructureStatusFromInfrastructure extracts the InfrastructureStatus from the
+// ProviderStatus section of the given Infrastructure. It returns nil if the Infrastructure
+// has no ProviderStatus yet.
+func InfrastructureStatusFromInfrastructure(infra *extensionsv1alpha1.Infrastructure) (*gcpv1alpha1.InfrastructureStatus, error) {
+	if infra.Status.ProviderStatus == nil {
+		return nil, nil
+	}
+	if status, ok := infra.Status.ProviderStatus.Object.(*gcpv1alpha1.InfrastructureStatus); ok {
+		return status, nil
+	}
+
+	status := &gcpv1alpha1.InfrastructureStatus{}
+	if _, _, err := decoder.Decode(infra.Status.ProviderStatus.Raw, nil, status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"time"
+
+	gcpv1alpha1 "github.com/gardener/gardener-extensions/controllers/provider-gcp/pkg/apis/gcp/v1alpha1"
+	gardencorev1alpha1 "github.com/gardener/gardener/pkg/apis/core/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/utils/pointer"
+)
+
+// roundTripConfig encodes the given InfrastructureConfig to JSON via the Scheme and decodes it back, converting it
+// through the internal version, to verify that configs are serialized losslessly.
+func roundTripConfig(config *gcpv1alpha1.InfrastructureConfig) (*gcpv1alpha1.InfrastructureConfig, error) {
+	encoder := serializer.NewCodecFactory(Scheme).LegacyCodec(gcpv1alpha1.SchemeGroupVersion)
+	raw, err := runtime.Encode(encoder, config.DeepCopy())
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeInfrastructureConfig(raw)
+}
+
+var _ = Describe("Scheme", func() {
+	Describe("#ValidateInfrastructureConfigTypeMeta", func() {
+		It("should accept the GCP InfrastructureConfig", func() {
+			Expect(ValidateInfrastructureConfigTypeMeta(metav1.TypeMeta{
+				APIVersion: "gcp.provider.extensions.gardener.cloud/v1alpha1",
+				Kind:       "InfrastructureConfig",
+			})).To(Succeed())
+		})
+
+		It("should reject a mismatched kind", func() {
+			err := ValidateInfrastructureConfigTypeMeta(metav1.TypeMeta{
+				APIVersion: "gcp.provider.extensions.gardener.cloud/v1alpha1",
+				Kind:       "InfrastructureStatus",
+			})
+
+			Expect(err).To(MatchError(ContainSubstring(`kind "InfrastructureStatus"`)))
+		})
+	})
+
+	Describe("#DecodeInfrastructureConfig", func() {
+		DescribeTable("should decode the raw config",
+			func(raw string) {
+				config, err := DecodeInfrastructureConfig([]byte(raw))
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.Networks.VPC).To(Equal(&gcpv1alpha1.VPC{Name: "vpc"}))
+				Expect(config.Networks.Worker).To(Equal(gardencorev1alpha1.CIDR("10.250.0.0/16")))
+			},
+			Entry("JSON", `{
+  "apiVersion": "gcp.provider.extensions.gardener.cloud/v1alpha1",
+  "kind": "InfrastructureConfig",
+  "networks": {
+    "vpc": {"name": "vpc"},
+    "worker": "10.250.0.0/16"
+  }
+}`),
+			Entry("YAML", `apiVersion: gcp.provider.extensions.gardener.cloud/v1alpha1
+kind: InfrastructureConfig
+networks:
+  vpc:
+    name: vpc
+  worker: 10.250.0.0/16
+`),
+		)
+
+		DescribeTable("should fail for a mismatched apiVersion or kind",
+			func(raw string) {
+				_, err := DecodeInfrastructureConfig([]byte(raw))
+
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("missing type meta", `{"networks": {"worker": "10.250.0.0/16"}}`),
+			Entry("other kind of the group", `{"apiVersion": "gcp.provider.extensions.gardener.cloud/v1alpha1", "kind": "ControlPlaneConfig"}`),
+			Entry("other group", `{"apiVersion": "aws.provider.extensions.gardener.cloud/v1alpha1", "kind": "InfrastructureConfig"}`),
+		)
+
+		It("should fail for an unknown kind", func() {
+			_, err := DecodeInfrastructureConfig([]byte(`{"apiVersion": "gcp.provider.extensions.gardener.cloud/v1alpha1", "kind": "Foo"}`))
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("#roundTripConfig", func() {
+		It("should round-trip a fully populated config unchanged", func() {
+			var (
+				internal        = gardencorev1alpha1.CIDR("10.251.0.0/16")
+				psc             = gardencorev1alpha1.CIDR("10.252.0.0/24")
+				peering         = gardencorev1alpha1.CIDR("10.253.0.0/20")
+				internalPurpose = gcpv1alpha1.SubnetPurpose("ilb")
+				role            = gcpv1alpha1.ProxyOnlySubnetRoleBackup
+				networkingMode  = gcpv1alpha1.NetworkingModeCilium
+				advertiseMode   = gcpv1alpha1.BGPAdvertiseModeCustom
+				networkTier     = gcpv1alpha1.NetworkTierStandard
+				timeout         = &metav1.Duration{Duration: 10 * time.Minute}
+			)
+			config := &gcpv1alpha1.InfrastructureConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: gcpv1alpha1.SchemeGroupVersion.String(),
+					Kind:       "InfrastructureConfig",
+				},
+				Networks: gcpv1alpha1.NetworkConfig{
+					VPC:                       &gcpv1alpha1.VPC{Name: "vpc", CloudRouter: &gcpv1alpha1.CloudRouter{Name: "router"}},
+					Internal:                  &internal,
+					InternalPurpose:           &internalPurpose,
+					SubnetPurposePrefix:       pointer.StringPtr("gardener-"),
+					InternalProxyOnly:         true,
+					Worker:                    gardencorev1alpha1.CIDR("10.250.0.0/16"),
+					ProxyOnly:                 &gcpv1alpha1.ProxyOnlySubnet{CIDR: gardencorev1alpha1.CIDR("10.254.0.0/23"), Role: &role},
+					PrivateServiceConnect:     &psc,
+					DisasterRecovery:          &gcpv1alpha1.DisasterRecoverySubnet{Region: "europe-west3", CIDR: gardencorev1alpha1.CIDR("10.255.0.0/16")},
+					EgressAppliance:           &gcpv1alpha1.EgressApplianceSubnet{CIDR: gardencorev1alpha1.CIDR("10.254.2.0/24"), NextHopIP: "10.254.2.10", Destinations: []gardencorev1alpha1.CIDR{"0.0.0.0/0"}, Priority: pointer.Int32Ptr(900)},
+					ControlPlanePeering:       &peering,
+					ServicePerimeter:          pointer.StringPtr("accessPolicies/123456/servicePerimeters/shoots"),
+					ControlPlanePeeringRoutes: &gcpv1alpha1.PeeringRoutes{ImportCustomRoutes: true, ExportCustomRoutes: true},
+					CloudNAT: &gcpv1alpha1.CloudNAT{
+						MinPortsPerVM:               pointer.Int32Ptr(64),
+						MaxPortsPerVM:               pointer.Int32Ptr(1024),
+						EnableDynamicPortAllocation: pointer.BoolPtr(true),
+						Subnets:                     []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeNodes},
+						IPCount:                     pointer.Int32Ptr(2),
+						NetworkTier:                 &networkTier,
+						Gateways:                    []gcpv1alpha1.CloudNATGateway{{Name: "internal", Subnets: []gcpv1alpha1.SubnetPurpose{gcpv1alpha1.PurposeInternal}, IPCount: pointer.Int32Ptr(3)}},
+					},
+					CloudRouterBGP: &gcpv1alpha1.CloudRouterBGP{
+						ASN:                 64514,
+						AdvertiseMode:       &advertiseMode,
+						AdvertiseAllSubnets: true,
+						AdvertisedIPRanges:  []gardencorev1alpha1.CIDR{"10.253.0.0/20"},
+					},
+					AirGapped: true,
+					AliasIPs: &gcpv1alpha1.AliasIPs{
+						PodsPrefixLength:     pointer.Int32Ptr(14),
+						ServicesPrefixLength: pointer.Int32Ptr(20),
+						NodeCIDRMaskSize:     pointer.Int32Ptr(24),
+					},
+					Firewall: &gcpv1alpha1.FirewallConfig{
+						Unmanaged:                  true,
+						DenyAllEgress:              true,
+						DisableAllowInternalAccess: true,
+						DisableAllowHealthChecks:   true,
+						PriorityBase:               pointer.Int32Ptr(-100),
+					},
+					FlowLogs: &gcpv1alpha1.FlowLogs{
+						AggregationInterval:       pointer.StringPtr("INTERVAL_5_SEC"),
+						ExportDestination:         pointer.StringPtr("storage.googleapis.com/flow-logs"),
+						ExportFilters:             map[gcpv1alpha1.SubnetPurpose]string{gcpv1alpha1.PurposeInternal: `jsonPayload.reporter="SRC"`},
+						BigQueryPartitionedTables: true,
+					},
+					MTU:            pointer.Int32Ptr(1500),
+					VPCDescription: pointer.StringPtr("my vpc"),
+					CustomRoutes: []gcpv1alpha1.Route{
+						{Name: "on-prem", Destination: gardencorev1alpha1.CIDR("192.168.0.0/16"), NextHopIP: pointer.StringPtr("10.250.0.10")},
+						{Name: "vpn", Destination: gardencorev1alpha1.CIDR("172.16.0.0/12"), NextHopVPNTunnel: pointer.StringPtr("tunnel")},
+						{Name: "ilb", Destination: gardencorev1alpha1.CIDR("172.31.0.0/16"), NextHopILB: pointer.StringPtr("10.251.0.10")},
+					},
+					NetworkingMode: &networkingMode,
+				},
+				ServiceAccount: &gcpv1alpha1.ServiceAccountConfig{
+					Create:                pointer.BoolPtr(true),
+					WorkloadIdentityUsers: []string{"kube-system/my-service-account"},
+					Project:               pointer.StringPtr("my-service-account-project"),
+					Email:                 pointer.StringPtr("gardener@my-project.iam.gserviceaccount.com"),
+				},
+				OutputKeyOverrides: map[string]string{"vpc_name": "network_name"},
+				Timeouts:           &gcpv1alpha1.Timeouts{Request: timeout, Create: timeout, Update: timeout, Delete: timeout},
+				DiskEncryptionKey:  "projects/my-project/locations/europe-west1/keyRings/ring/cryptoKeys/key",
+				StateRefresh:       pointer.BoolPtr(false),
+			}
+
+			roundTripped, err := roundTripConfig(config)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roundTripped).To(Equal(config))
+		})
+	})
+})
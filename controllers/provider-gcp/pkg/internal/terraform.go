@@ -26,6 +26,11 @@ import (
 const (
 	// TerraformVarServiceAccount is the name of the terraform service account environment variable.
 	TerraformVarServiceAccount = "TF_VAR_SERVICEACCOUNT"
+	// TerraformCLIArgsApply is the name of the environment variable containing additional arguments of the
+	// terraform apply command.
+	TerraformCLIArgsApply = "TF_CLI_ARGS_apply"
+	// TerraformNoRefreshArgs are the arguments disabling the refresh of the state when applying the configuration.
+	TerraformNoRefreshArgs = "-refresh=false"
 )
 
 // TerraformerVariablesEnvironmentFromServiceAccount computes the Terraformer variables environment from the
@@ -41,20 +46,36 @@ func TerraformerVariablesEnvironmentFromServiceAccount(account *ServiceAccount)
 	}, nil
 }
 
-// NewTerraformer initializes a new Terraformer that has the ServiceAccount credentials.
+// TerraformerVariablesEnvironment computes the Terraformer variables environment from the given ServiceAccount.
+// If refresh is false, terraform does not refresh the state before applying the configuration.
+func TerraformerVariablesEnvironment(account *ServiceAccount, refresh bool) (map[string]string, error) {
+	variables, err := TerraformerVariablesEnvironmentFromServiceAccount(account)
+	if err != nil {
+		return nil, err
+	}
+	if !refresh {
+		variables[TerraformCLIArgsApply] = TerraformNoRefreshArgs
+	}
+
+	return variables, nil
+}
+
+// NewTerraformer initializes a new Terraformer that has the ServiceAccount credentials. If refresh is false, the
+// Terraformer does not refresh the state before applying the configuration.
 func NewTerraformer(
 	restConfig *rest.Config,
 	serviceAccount *ServiceAccount,
 	purpose,
 	namespace,
 	name string,
+	refresh bool,
 ) (*terraformer.Terraformer, error) {
 	tf, err := terraformer.NewForConfig(logger.NewLogger("info"), restConfig, purpose, namespace, name, imagevector.TerraformerImage())
 	if err != nil {
 		return nil, err
 	}
 
-	variables, err := TerraformerVariablesEnvironmentFromServiceAccount(serviceAccount)
+	variables, err := TerraformerVariablesEnvironment(serviceAccount, refresh)
 	if err != nil {
 		return nil, err
 	}
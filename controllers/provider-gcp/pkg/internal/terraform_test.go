@@ -42,4 +42,25 @@ var _ = Describe("Terraform", func() {
 			}))
 		})
 	})
+
+	Describe("#TerraformerVariablesEnvironment", func() {
+		It("should refresh the state by default", func() {
+			variables, err := TerraformerVariablesEnvironment(serviceAccount, true)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(variables).To(Equal(map[string]string{
+				TerraformVarServiceAccount: fmt.Sprintf(`{"project_id":"%s"}`, projectID),
+			}))
+		})
+
+		It("should propagate a disabled state refresh to terraform apply", func() {
+			variables, err := TerraformerVariablesEnvironment(serviceAccount, false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(variables).To(Equal(map[string]string{
+				TerraformVarServiceAccount: fmt.Sprintf(`{"project_id":"%s"}`, projectID),
+				TerraformCLIArgsApply:      TerraformNoRefreshArgs,
+			}))
+		})
+	})
 })